@@ -311,6 +311,26 @@ func GenerateMiniBlogModels(g *gen.Generator) {
 		}),
 	)
 
+	// 上传文件表模型生成
+	g.GenerateModelAs(
+		"uploaded_file",
+		"UploadedFileM",
+		gen.FieldIgnore("placeholder"),
+		gen.FieldRename("file_id", "FileID"),
+		gen.FieldRename("user_id", "UserID"),
+		gen.FieldRename("object_key", "ObjectKey"),
+		gen.FieldRename("url", "URL"),
+		gen.FieldRename("sha256", "SHA256"),
+		gen.FieldRename("mime", "MIME"),
+		gen.FieldRename("created_at", "CreatedAt"),
+		gen.FieldRename("updated_at", "UpdatedAt"),
+		gen.FieldRename("deleted_at", "DeletedAt"),
+		gen.FieldGORMTag("sha256", func(tag field.GormTag) field.GormTag {
+			tag.Set("index", "idx_sha256")
+			return tag
+		}),
+	)
+
 	// Casbin 规则表模型生成
 	g.GenerateModelAs(
 		"casbin_rule",
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/clin211/miniblog-v2/internal/apiserver"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
 	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
 	"github.com/clin211/miniblog-v2/pkg/strings"
 	"github.com/spf13/pflag"
@@ -23,15 +24,28 @@ var availableServerModes = sets.New(
 	apiserver.GinServerMode,
 	apiserver.GRPCServerMode,
 	apiserver.GRPCGatewayServerMode,
+	apiserver.CombinedServerMode,
 )
 
 type ServerOptions struct {
-	// ServerMode 定义服务器模式 gRPC、Gin HTTP、HTTP Reverse Proxy
+	// ServerMode 定义服务器模式 gRPC、Gin HTTP、HTTP Reverse Proxy、单端口 Combined
 	ServerMode string `json:"server-mode" mapstructure:"server-mode"`
 	// JWTKey 定义 JWT 秘钥
 	JWTKey string `json:"jwt-key" mapstructure:"jwt-key"`
 	// Expiration 定义 JWT Token 过期时间
 	Expiration time.Duration `json:"expiration" mapstructure:"expiration"`
+	// RefreshExpiration 定义刷新令牌的有效期，每次刷新重新计时
+	RefreshExpiration time.Duration `json:"refresh-expiration" mapstructure:"refresh-expiration"`
+	// AuthnBypass 为 true 时 gRPC 模式跳过 JWT 认证并信任 x-user-id 元数据，仅供本地开发使用
+	AuthnBypass bool `json:"authn-bypass" mapstructure:"authn-bypass"`
+	// PublicURL 定义前端页面的访问地址，用于生成邮件中的链接
+	PublicURL string `json:"public-url" mapstructure:"public-url"`
+	// EmailVerificationExpiration 定义邮箱验证链接的有效期
+	EmailVerificationExpiration time.Duration `json:"email-verification-expiration" mapstructure:"email-verification-expiration"`
+	// PasswordResetExpiration 定义重置密码链接的有效期
+	PasswordResetExpiration time.Duration `json:"password-reset-expiration" mapstructure:"password-reset-expiration"`
+	// RequireVerifiedEmail 为 true 时作者验证邮箱后才能发布文章
+	RequireVerifiedEmail bool `json:"require-verified-email" mapstructure:"require-verified-email"`
 	// TLSOptions 包含 TLS 配置选项.
 	TLSOptions *genericoptions.TLSOptions `json:"tls" mapstructure:"tls"`
 	// HTTPOptions 包含 HTTP 配置选项.
@@ -46,21 +60,31 @@ type ServerOptions struct {
 	RedisOptions *genericoptions.RedisOptions `json:"redis" mapstructure:"redis"`
 	// UploadOptions 包含文件上传配置选项
 	UploadOptions *genericoptions.UploadOptions `json:"upload" mapstructure:"upload"`
+	// GeoIPOptions 包含客户端 IP 解析与地理位置查询配置选项
+	GeoIPOptions *genericoptions.GeoIPOptions `json:"geoip" mapstructure:"geoip"`
+	// MailerOptions 包含发送邮件配置选项
+	MailerOptions *genericoptions.MailerOptions `json:"mailer" mapstructure:"mailer"`
 }
 
 // NewServerOptions 创建带有默认值的 ServerOptions 实例
 func NewServerOptions() *ServerOptions {
 	opts := &ServerOptions{
-		ServerMode:    apiserver.GRPCGatewayServerMode,
-		JWTKey:        "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
-		Expiration:    2 * time.Hour,
-		TLSOptions:    genericoptions.NewTLSOptions(),
-		HTTPOptions:   genericoptions.NewHTTPOptions(),
-		GRPCOptions:   genericoptions.NewGRPCOptions(),
-		MySQLOptions:  genericoptions.NewMySQLOptions(),
-		MongoOptions:  genericoptions.NewMongoOptions(),
-		RedisOptions:  genericoptions.NewRedisOptions(),
-		UploadOptions: genericoptions.NewUploadOptions(),
+		ServerMode:                  apiserver.GRPCGatewayServerMode,
+		JWTKey:                      "Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5",
+		Expiration:                  2 * time.Hour,
+		RefreshExpiration:           7 * 24 * time.Hour,
+		PublicURL:                   "http://127.0.0.1:5555",
+		EmailVerificationExpiration: 24 * time.Hour,
+		PasswordResetExpiration:     30 * time.Minute,
+		TLSOptions:                  genericoptions.NewTLSOptions(),
+		HTTPOptions:                 genericoptions.NewHTTPOptions(),
+		GRPCOptions:                 genericoptions.NewGRPCOptions(),
+		MySQLOptions:                genericoptions.NewMySQLOptions(),
+		MongoOptions:                genericoptions.NewMongoOptions(),
+		RedisOptions:                genericoptions.NewRedisOptions(),
+		UploadOptions:               genericoptions.NewUploadOptions(),
+		GeoIPOptions:                genericoptions.NewGeoIPOptions(),
+		MailerOptions:               genericoptions.NewMailerOptions(),
 	}
 	opts.HTTPOptions.Addr = ":5555"
 	opts.GRPCOptions.Addr = ":6666"
@@ -74,12 +98,20 @@ func (o *ServerOptions) AddFlags(fs *pflag.FlagSet) {
 	// 绑定 JWT Token 的过期时间选项到命令行标志
 	// 参数名称 `--expiration`，默认值为 o.Expiration
 	fs.DurationVar(&o.Expiration, "expiration", o.Expiration, "JWT expiration")
+	fs.DurationVar(&o.RefreshExpiration, "refresh-expiration", o.RefreshExpiration, "Refresh token expiration, restarted on every refresh.")
+	fs.BoolVar(&o.AuthnBypass, "authn-bypass", o.AuthnBypass, "Development only: skip JWT authentication in gRPC modes and trust the x-user-id metadata.")
+	fs.StringVar(&o.PublicURL, "public-url", o.PublicURL, "Base URL of the web frontend, used to build links in outgoing mails.")
+	fs.DurationVar(&o.EmailVerificationExpiration, "email-verification-expiration", o.EmailVerificationExpiration, "Expiration of email verification links.")
+	fs.DurationVar(&o.PasswordResetExpiration, "password-reset-expiration", o.PasswordResetExpiration, "Expiration of password reset links.")
+	fs.BoolVar(&o.RequireVerifiedEmail, "require-verified-email", o.RequireVerifiedEmail, "Require authors to verify their email address before publishing posts.")
 	o.TLSOptions.AddFlags(fs)
 	o.HTTPOptions.AddFlags(fs)
 	o.GRPCOptions.AddFlags(fs)
 	o.MySQLOptions.AddFlags(fs)
 	o.MongoOptions.AddFlags(fs)
 	o.RedisOptions.AddFlags(fs)
+	o.GeoIPOptions.AddFlags(fs)
+	o.MailerOptions.AddFlags(fs)
 }
 
 // Validate 检验 ServerOptions 中的选项是否合法
@@ -102,9 +134,19 @@ func (o *ServerOptions) Validate() error {
 	errs = append(errs, o.MySQLOptions.Validate()...)
 	errs = append(errs, o.MongoOptions.Validate()...)
 	errs = append(errs, o.RedisOptions.Validate()...)
+	errs = append(errs, o.UploadOptions.Validate()...)
+	errs = append(errs, o.GeoIPOptions.Validate()...)
+	errs = append(errs, o.MailerOptions.Validate()...)
 
-	// 如果是 gRPC 或 gRPC-Gateway 模式，校验 gRPC 配置
-	if strings.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode}) {
+	// 校验对象键模板，避免启动后才在上传时暴露错误
+	if o.UploadOptions != nil {
+		if _, err := uploader.ParseKeyTemplate(o.UploadOptions.KeyTemplate); err != nil {
+			errs = append(errs, fmt.Errorf("upload.keyTemplate: %w", err))
+		}
+	}
+
+	// 如果是 gRPC、gRPC-Gateway 或单端口模式，校验 gRPC 配置
+	if strings.StringIn(o.ServerMode, []string{apiserver.GRPCServerMode, apiserver.GRPCGatewayServerMode, apiserver.CombinedServerMode}) {
 		errs = append(errs, o.GRPCOptions.Validate()...)
 	}
 
@@ -115,15 +157,23 @@ func (o *ServerOptions) Validate() error {
 // Config 基于 ServerOptions 创建新的 apiserver.Config。
 func (o *ServerOptions) Config() (*apiserver.Config, error) {
 	return &apiserver.Config{
-		ServerMode:    o.ServerMode,
-		JWTKey:        o.JWTKey,
-		TLSOptions:    o.TLSOptions,
-		Expiration:    o.Expiration,
-		HTTPOptions:   o.HTTPOptions,
-		GRPCOptions:   o.GRPCOptions,
-		MySQLOptions:  o.MySQLOptions,
-		MongoOptions:  o.MongoOptions,
-		RedisOptions:  o.RedisOptions,
-		UploadOptions: o.UploadOptions,
+		ServerMode:                  o.ServerMode,
+		JWTKey:                      o.JWTKey,
+		TLSOptions:                  o.TLSOptions,
+		Expiration:                  o.Expiration,
+		RefreshExpiration:           o.RefreshExpiration,
+		AuthnBypass:                 o.AuthnBypass,
+		PublicURL:                   o.PublicURL,
+		HTTPOptions:                 o.HTTPOptions,
+		GRPCOptions:                 o.GRPCOptions,
+		MySQLOptions:                o.MySQLOptions,
+		MongoOptions:                o.MongoOptions,
+		RedisOptions:                o.RedisOptions,
+		UploadOptions:               o.UploadOptions,
+		GeoIPOptions:                o.GeoIPOptions,
+		MailerOptions:               o.MailerOptions,
+		EmailVerificationExpiration: o.EmailVerificationExpiration,
+		PasswordResetExpiration:     o.PasswordResetExpiration,
+		RequireVerifiedEmail:        o.RequireVerifiedEmail,
 	}, nil
 }
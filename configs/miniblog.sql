@@ -8,6 +8,7 @@ CREATE DATABASE IF NOT EXISTS miniblog_v2 DEFAULT CHARACTER SET utf8mb4 COLLATE
 USE miniblog_v2;
 
 -- 删除已存在的表（按依赖关系逆序删除）
+DROP TABLE IF EXISTS uploaded_file;
 DROP TABLE IF EXISTS post_tag;
 DROP TABLE IF EXISTS post;
 DROP TABLE IF EXISTS tag;
@@ -133,6 +134,29 @@ CREATE TABLE post_tag (
     INDEX idx_tag_id (`tag_id`)
 ) COMMENT='文章标签关联表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
 
+-- 上传文件表（同一内容的多条记录可指向同一个对象键）
+CREATE TABLE uploaded_file (
+    `id` BIGINT AUTO_INCREMENT PRIMARY KEY COMMENT '主键',
+    `file_id` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '文件ID',
+    `user_id` VARCHAR(32) NOT NULL COMMENT '上传者ID',
+    `provider` VARCHAR(16) NOT NULL COMMENT '存储后端：local | s3 | alioss',
+    `object_key` VARCHAR(255) NOT NULL COMMENT '对象键',
+    `url` VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '访问URL',
+    `sha256` CHAR(64) NOT NULL COMMENT '内容 sha256（十六进制）',
+    `size` BIGINT NOT NULL DEFAULT 0 COMMENT '字节数',
+    `mime` VARCHAR(128) NOT NULL DEFAULT '' COMMENT 'MIME 类型',
+    `scene` VARCHAR(32) NOT NULL DEFAULT '' COMMENT '上传场景',
+    `filename` VARCHAR(255) NOT NULL DEFAULT '' COMMENT '原始文件名',
+    `image_metadata` TEXT COMMENT '图片信息（JSON）：尺寸与衍生图 URL',
+    `created_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() COMMENT '创建时间',
+    `updated_at` TIMESTAMP DEFAULT CURRENT_TIMESTAMP() ON UPDATE CURRENT_TIMESTAMP() COMMENT '更新时间',
+    `deleted_at` TIMESTAMP NULL COMMENT '删除时间',
+    INDEX idx_file_id (`file_id`),
+    INDEX idx_sha256 (`sha256`),
+    INDEX idx_user_id_sha256 (`user_id`, `sha256`),
+    INDEX idx_object_key (`object_key`)
+) COMMENT='上传文件表' ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
 -- casbin_rule
 CREATE TABLE `casbin_rule` (
   `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
@@ -0,0 +1,50 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/client"
+)
+
+var (
+	// 定义命令行参数
+	addr     = flag.String("addr", "localhost:6666", "The server address to connect to.")           // 服务地址
+	rest     = flag.Bool("rest", false, "Use the REST transport, addr should be the HTTP address.") // 是否使用 REST 传输
+	username = flag.String("username", "root", "Username to login.")                                // 登录用户名
+	password = flag.String("password", "miniblog1234", "Password to login.")                        // 登录密码
+)
+
+func main() {
+	flag.Parse() // 解析命令行参数
+
+	// 根据传输方式创建客户端，调用非公开接口时自动登录并携带令牌
+	newClient := client.NewGRPC
+	if *rest {
+		newClient = client.NewREST
+	}
+	c, err := newClient(*addr, client.WithCredentials(*username, *password))
+	if err != nil {
+		log.Fatalf("Failed to create client: %v", err)
+	}
+	defer c.Close()
+
+	// 指定请求 ID，便于在服务端日志中定位本次调用
+	ctx := client.ContextWithRequestID(context.Background(), "sdk-example")
+	resp, err := c.ListPost(ctx, &v1.ListPostRequest{Limit: 10})
+	if err != nil {
+		log.Fatalf("Failed to list posts: %v", err)
+	}
+
+	jsonData, _ := json.Marshal(resp)
+	fmt.Println(string(jsonData))
+}
@@ -10,10 +10,19 @@ import (
 
 	"github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/category"
 	devicev1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/device"
+	mediav1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/media"
 	postv1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/post"
+	posttagv1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/posttag"
 	tagv1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/tag"
+	uploadv1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/upload"
 	userv1 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v1/user"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/pubsub"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/quota"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/session"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
 	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	apiv1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
 	"github.com/clin211/miniblog-v2/pkg/auth"
 	// Post V2 版本（未实现，仅展示用）
 	// postv2 "github.com/clin211/miniblog-v2/internal/apiserver/biz/v2/post".
@@ -33,10 +42,16 @@ type IBiz interface {
 	PostV1() postv1.PostBiz
 	// 获取标签业务接口.
 	TagV1() tagv1.TagBiz
+	// 获取文章标签关联业务接口.
+	PostTagV1() posttagv1.PostTagBiz
 	// 获取分类业务接口.
 	CategoryV1() category.CategoryBiz
 	// 获取设备业务接口.
 	Device() devicev1.DeviceBiz
+	// 获取文件上传业务接口.
+	UploadV1() uploadv1.UploadBiz
+	// 获取媒体库业务接口.
+	MediaV1() mediav1.MediaBiz
 	// 获取帖子业务接口（V2版本）.
 	// PostV2() post.PostBiz
 }
@@ -45,24 +60,32 @@ type IBiz interface {
 type biz struct {
 	store store.IStore
 	authz *auth.Authz
+	upl   uploader.Uploader
+	quota *quota.Limiter
+	// postEvents 为文章变更事件的发布订阅，供 WatchPosts 使用
+	postEvents *pubsub.Broker[*apiv1.PostEvent]
+	// sessions 管理登录会话的刷新令牌
+	sessions *session.Manager
+	// verifier 发送并校验邮件中的一次性验证令牌
+	verifier *verification.Verifier
 }
 
 // 确保 biz 实现了 IBiz 接口.
 var _ IBiz = (*biz)(nil)
 
 // NewBiz 创建一个 IBiz 类型的实例.
-func NewBiz(store store.IStore, authz *auth.Authz) *biz {
-	return &biz{store: store, authz: authz}
+func NewBiz(store store.IStore, authz *auth.Authz, upl uploader.Uploader, quota *quota.Limiter, postEvents *pubsub.Broker[*apiv1.PostEvent], sessions *session.Manager, verifier *verification.Verifier) *biz {
+	return &biz{store: store, authz: authz, upl: upl, quota: quota, postEvents: postEvents, sessions: sessions, verifier: verifier}
 }
 
 // UserV1 返回一个实现了 UserBiz 接口的实例.
 func (b *biz) UserV1() userv1.UserBiz {
-	return userv1.New(b.store, b.authz)
+	return userv1.New(b.store, b.authz, b.sessions, b.verifier)
 }
 
 // PostV1 返回一个实现了 PostBiz 接口的实例.
 func (b *biz) PostV1() postv1.PostBiz {
-	return postv1.New(b.store)
+	return postv1.New(b.store, b.postEvents, b.verifier.RequireVerifiedEmail())
 }
 
 // TagV1 返回一个实现了 TagBiz 接口的实例.
@@ -70,6 +93,11 @@ func (b *biz) TagV1() tagv1.TagBiz {
 	return tagv1.New(b.store)
 }
 
+// PostTagV1 返回一个实现了 PostTagBiz 接口的实例.
+func (b *biz) PostTagV1() posttagv1.PostTagBiz {
+	return posttagv1.New(b.store)
+}
+
 func (b *biz) CategoryV1() category.CategoryBiz {
 	return category.New(b.store)
 }
@@ -78,3 +106,13 @@ func (b *biz) CategoryV1() category.CategoryBiz {
 func (b *biz) Device() devicev1.DeviceBiz {
 	return devicev1.NewDeviceBiz(b.store)
 }
+
+// UploadV1 返回一个实现了 UploadBiz 接口的实例.
+func (b *biz) UploadV1() uploadv1.UploadBiz {
+	return uploadv1.New(b.store, b.upl, b.quota)
+}
+
+// MediaV1 返回一个实现了 MediaBiz 接口的实例.
+func (b *biz) MediaV1() mediav1.MediaBiz {
+	return mediav1.New(b.store, b.upl, b.quota)
+}
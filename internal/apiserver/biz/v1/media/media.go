@@ -0,0 +1,254 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/conversion"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/quota"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// MediaBiz 定义处理媒体库请求所需的方法.
+type MediaBiz interface {
+	List(ctx context.Context, rq *v1.ListMediaRequest) (*v1.ListMediaResponse, error)
+	Get(ctx context.Context, rq *v1.GetMediaRequest) (*v1.GetMediaResponse, error)
+	Delete(ctx context.Context, rq *v1.DeleteMediaRequest) (*v1.DeleteMediaResponse, error)
+
+	MediaExpansion
+}
+
+// MediaExpansion 定义额外的媒体库操作方法.
+type MediaExpansion interface {
+	// CollectOrphans 删除创建时间早于 before 且未被任何文章或用户引用的文件，返回删除的对象数.
+	CollectOrphans(ctx context.Context, before time.Time, batchSize int) (int, error)
+}
+
+// mediaBiz 是 MediaBiz 接口的实现.
+type mediaBiz struct {
+	store store.IStore
+	upl   uploader.Uploader
+	quota *quota.Limiter
+}
+
+// 确保 mediaBiz 实现了 MediaBiz 接口.
+var _ MediaBiz = (*mediaBiz)(nil)
+
+// New 创建 mediaBiz 的实例.
+func New(store store.IStore, upl uploader.Uploader, quota *quota.Limiter) *mediaBiz {
+	return &mediaBiz{store: store, upl: upl, quota: quota}
+}
+
+// List 实现 MediaBiz 接口中的 List 方法.
+// 管理员可以看到所有用户上传的文件，其他用户只能看到自己上传的文件.
+func (b *mediaBiz) List(ctx context.Context, rq *v1.ListMediaRequest) (*v1.ListMediaResponse, error) {
+	whr := b.scoped(ctx).O(int(rq.GetOffset())).L(int(rq.GetLimit()))
+	if rq.Scene != nil {
+		whr.F("scene", rq.GetScene())
+	}
+	if rq.Mime != nil {
+		// image/* 按前缀匹配
+		if prefix, ok := strings.CutSuffix(rq.GetMime(), "/*"); ok {
+			whr.Q("mime LIKE ?", prefix+"/%")
+		} else {
+			whr.F("mime", rq.GetMime())
+		}
+	}
+
+	count, fileList, err := b.store.UploadedFile().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]*v1.MediaFile, 0, len(fileList))
+	for _, file := range fileList {
+		files = append(files, b.signed(conversion.UploadedFileModelToMediaFileV1(file)))
+	}
+	return &v1.ListMediaResponse{Total: count, Files: files}, nil
+}
+
+// Get 实现 MediaBiz 接口中的 Get 方法.
+func (b *mediaBiz) Get(ctx context.Context, rq *v1.GetMediaRequest) (*v1.GetMediaResponse, error) {
+	fileM, err := b.get(ctx, rq.GetFileID())
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := b.references(ctx, fileM)
+	if err != nil {
+		return nil, err
+	}
+
+	references := make([]*v1.MediaReference, 0, len(refs))
+	for _, ref := range refs {
+		references = append(references, &v1.MediaReference{Kind: ref.Kind, ResourceID: ref.ResourceID, Title: ref.Title})
+	}
+	return &v1.GetMediaResponse{File: b.signed(conversion.UploadedFileModelToMediaFileV1(fileM)), References: references}, nil
+}
+
+// Delete 实现 MediaBiz 接口中的 Delete 方法.
+// 文件仍被引用时返回 ErrMediaInUse，除非指定 force；对象本身在没有其他记录指向时才从存储中删除.
+func (b *mediaBiz) Delete(ctx context.Context, rq *v1.DeleteMediaRequest) (*v1.DeleteMediaResponse, error) {
+	fileM, err := b.get(ctx, rq.GetFileID())
+	if err != nil {
+		return nil, err
+	}
+
+	if !rq.GetForce() {
+		refs, err := b.references(ctx, fileM)
+		if err != nil {
+			return nil, err
+		}
+		if len(refs) > 0 {
+			return nil, errno.ErrMediaInUse.WithMessage("Media file is referenced by %d resource(s), for example %s %s", len(refs), refs[0].Kind, refs[0].ResourceID)
+		}
+	}
+
+	if err := b.store.UploadedFile().Delete(ctx, where.F("file_id", fileM.FileID)); err != nil {
+		return nil, err
+	}
+	b.quota.Invalidate(ctx, fileM.UserID)
+	b.removeObject(ctx, fileM)
+
+	return &v1.DeleteMediaResponse{}, nil
+}
+
+// CollectOrphans 实现 MediaExpansion 接口中的 CollectOrphans 方法.
+// 同一对象键可能被多条记录共享（内容去重），只有全部记录都超过宽限期且对象未被引用时才回收.
+func (b *mediaBiz) CollectOrphans(ctx context.Context, before time.Time, batchSize int) (int, error) {
+	var (
+		removed int
+		afterID int64
+		checked = map[string]bool{}
+	)
+	for {
+		files, err := b.store.UploadedFile().ListCreatedBefore(ctx, before, afterID, batchSize)
+		if err != nil {
+			return removed, err
+		}
+
+		for _, fileM := range files {
+			afterID = fileM.ID
+
+			id := fileM.Provider + "/" + fileM.ObjectKey
+			if checked[id] {
+				continue
+			}
+			checked[id] = true
+
+			shared, orphan, err := b.isOrphan(ctx, fileM, before)
+			if err != nil {
+				return removed, err
+			}
+			if !orphan {
+				continue
+			}
+
+			if err := b.store.UploadedFile().Delete(ctx, where.F("provider", fileM.Provider, "object_key", fileM.ObjectKey)); err != nil {
+				return removed, err
+			}
+			userIDs := make([]string, 0, len(shared))
+			for _, other := range shared {
+				userIDs = append(userIDs, other.UserID)
+			}
+			b.quota.Invalidate(ctx, userIDs...)
+			b.removeObject(ctx, fileM)
+			removed++
+
+			log.W(ctx).Infow("Collected orphan media file", "provider", fileM.Provider, "key", fileM.ObjectKey)
+		}
+
+		if len(files) < batchSize {
+			return removed, nil
+		}
+	}
+}
+
+// isOrphan 判断对象是否可以回收：共享该对象键的记录都早于 before，且没有文章或用户引用.
+// 同时返回共享该对象键的全部记录.
+func (b *mediaBiz) isOrphan(ctx context.Context, fileM *model.UploadedFileM, before time.Time) ([]*model.UploadedFileM, bool, error) {
+	_, shared, err := b.store.UploadedFile().List(ctx, where.F("provider", fileM.Provider, "object_key", fileM.ObjectKey).L(-1))
+	if err != nil {
+		return nil, false, err
+	}
+	for _, other := range shared {
+		if other.CreatedAt == nil || !other.CreatedAt.Before(before) {
+			return shared, false, nil
+		}
+	}
+
+	refs, err := b.references(ctx, fileM)
+	if err != nil {
+		return nil, false, err
+	}
+	return shared, len(refs) == 0, nil
+}
+
+// scoped 返回限定当前用户可见范围的查询条件.
+func (b *mediaBiz) scoped(ctx context.Context) *where.Options {
+	whr := where.NewWhere()
+	if contextx.Username(ctx) != known.AdminUsername {
+		whr.T(ctx)
+	}
+	return whr
+}
+
+// get 查询当前用户可见的文件记录.
+func (b *mediaBiz) get(ctx context.Context, fileID string) (*model.UploadedFileM, error) {
+	fileM, err := b.store.UploadedFile().Get(ctx, b.scoped(ctx).F("file_id", fileID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	return fileM, nil
+}
+
+// signed 为私有对象的 URL 追加签名.
+func (b *mediaBiz) signed(file *v1.MediaFile) *v1.MediaFile {
+	if file.GetProvider() == b.upl.Provider() {
+		file.Url = uploader.SignObjectURLs(b.upl, file.GetScene(), file.GetUrl(), file.GetMetadata())
+	}
+	return file
+}
+
+// references 返回引用了文件（含衍生图）的文章与用户.
+// 内容中的 URL 可能使用不同的访问前缀，因此按对象键匹配.
+func (b *mediaBiz) references(ctx context.Context, fileM *model.UploadedFileM) ([]*store.ObjectReference, error) {
+	return b.store.UploadedFile().References(ctx, fileM.ObjectKey, uploader.DerivativePrefix(fileM.ObjectKey))
+}
+
+// removeObject 在没有其他记录指向同一对象时，从存储中删除对象及其衍生图.
+// 存储删除失败只记录日志，残留对象不影响业务.
+func (b *mediaBiz) removeObject(ctx context.Context, fileM *model.UploadedFileM) {
+	remover, ok := b.upl.(uploader.Remover)
+	if !ok || fileM.Provider != b.upl.Provider() {
+		return
+	}
+
+	count, _, err := b.store.UploadedFile().List(ctx, where.F("provider", fileM.Provider, "object_key", fileM.ObjectKey).L(1))
+	if err != nil || count > 0 {
+		return
+	}
+	if err := remover.Remove(ctx, fileM.ObjectKey); err != nil {
+		log.W(ctx).Errorw("Failed to remove media object", "key", fileM.ObjectKey, "err", err)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package media
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// fakeUploader 记录被删除的对象键.
+type fakeUploader struct {
+	removed []string
+}
+
+func (u *fakeUploader) Provider() string { return "local" }
+
+func (u *fakeUploader) Upload(context.Context, *uploader.UploadInput, io.Reader) (*uploader.UploadedObject, error) {
+	return nil, nil
+}
+
+func (u *fakeUploader) Remove(_ context.Context, key string) error {
+	u.removed = append(u.removed, key)
+	return nil
+}
+
+var (
+	testOnce  sync.Once
+	testDB    *gorm.DB
+	testStore store.IStore
+)
+
+// newTestBiz 返回基于内存 SQLite 的 mediaBiz. store 为进程内单例，因此各用例共享数据库并在开始前清空数据.
+func newTestBiz(t *testing.T) (*mediaBiz, *gorm.DB, *fakeUploader) {
+	testOnce.Do(func() {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&model.UploadedFileM{}))
+		// 仅创建引用追踪用到的列；SQLite 的索引名全局唯一，无法直接迁移 post 与 user 模型
+		require.NoError(t, db.Exec("CREATE TABLE post (id INTEGER PRIMARY KEY, post_id TEXT, title TEXT, cover TEXT, content TEXT, deleted_at DATETIME)").Error)
+		require.NoError(t, db.Exec("CREATE TABLE user (id INTEGER PRIMARY KEY, user_id TEXT, username TEXT, avatar TEXT, deleted_at DATETIME)").Error)
+
+		where.RegisterTenant("user_id", contextx.UserID)
+		testDB, testStore = db, store.NewStore(db, nil, nil)
+	})
+	for _, table := range []string{"uploaded_file", "post", "user"} {
+		require.NoError(t, testDB.Exec("DELETE FROM "+table).Error)
+	}
+
+	upl := &fakeUploader{}
+	return New(testStore, upl, nil), testDB, upl
+}
+
+func createFile(t *testing.T, db *gorm.DB, userID string, key string, createdAt time.Time) *model.UploadedFileM {
+	fileM := &model.UploadedFileM{UserID: userID, Provider: "local", ObjectKey: key, URL: "/static/uploads/" + key, MIME: "image/png", Scene: "cover", CreatedAt: &createdAt}
+	require.NoError(t, db.Create(fileM).Error)
+	return fileM
+}
+
+func TestMediaBiz_Delete(t *testing.T) {
+	b, db, upl := newTestBiz(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	fileM := createFile(t, db, "user-000001", "2025/01/02/a.png", time.Now())
+	// 文章正文引用了衍生图
+	require.NoError(t, db.Exec("INSERT INTO post (post_id, title, content) VALUES (?, ?, ?)", "post-000001", "hello", "![](/static/uploads/2025/01/02/a@thumb.jpg)").Error)
+
+	// 其他用户不可见
+	_, err := b.Get(contextx.WithUserID(context.Background(), "user-000002"), &v1.GetMediaRequest{FileID: fileM.FileID})
+	assert.Equal(t, errno.ErrMediaNotFound, err)
+
+	resp, err := b.Get(ctx, &v1.GetMediaRequest{FileID: fileM.FileID})
+	require.NoError(t, err)
+	require.Len(t, resp.References, 1)
+	assert.Equal(t, store.ReferencePostContent, resp.References[0].Kind)
+
+	_, err = b.Delete(ctx, &v1.DeleteMediaRequest{FileID: fileM.FileID})
+	assert.ErrorIs(t, err, errno.ErrMediaInUse)
+
+	_, err = b.Delete(ctx, &v1.DeleteMediaRequest{FileID: fileM.FileID, Force: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{fileM.ObjectKey}, upl.removed)
+}
+
+func TestMediaBiz_CollectOrphans(t *testing.T) {
+	b, db, upl := newTestBiz(t)
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+
+	createFile(t, db, "user-000001", "orphan.png", old)
+	// 被头像引用
+	createFile(t, db, "user-000001", "avatar.png", old)
+	require.NoError(t, db.Exec("INSERT INTO user (user_id, username, avatar) VALUES (?, ?, ?)", "user-000001", "alice", "/static/uploads/avatar.png").Error)
+	// 去重共享的对象中有一条记录仍在宽限期内
+	createFile(t, db, "user-000001", "shared.png", old)
+	createFile(t, db, "user-000002", "shared.png", time.Now())
+	// 仍在宽限期内
+	createFile(t, db, "user-000001", "fresh.png", time.Now())
+
+	removed, err := b.CollectOrphans(ctx, time.Now().Add(-24*time.Hour), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []string{"orphan.png"}, upl.removed)
+
+	var count int64
+	require.NoError(t, db.Model(&model.UploadedFileM{}).Count(&count).Error)
+	assert.Equal(t, int64(4), count)
+}
@@ -14,8 +14,10 @@ import (
 	"gorm.io/gorm/clause"
 
 	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/pubsub"
 	"github.com/clin211/miniblog-v2/internal/apiserver/store"
 	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
 	"github.com/clin211/miniblog-v2/internal/pkg/log"
 	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
 	"github.com/clin211/miniblog-v2/pkg/where"
@@ -38,19 +40,25 @@ type PostExpansion interface {
 	AppGet(ctx context.Context, rq *v1.GetPostRequest) (*v1.GetPostResponse, error)
 	// AppBatchGet 批量按 postID 获取文章
 	AppBatchGet(ctx context.Context, rq *v1.BatchGetPostsRequest) (*v1.BatchGetPostsResponse, error)
+	// Watch 订阅文章变更事件，阻塞直到 ctx 结束或 send 返回错误
+	Watch(ctx context.Context, rq *v1.WatchPostsRequest, send func(*v1.PostEvent) error) error
 }
 
 // postBiz 是 PostBiz 接口的实现.
 type postBiz struct {
-	store store.IStore
+	store  store.IStore
+	events *pubsub.Broker[*v1.PostEvent]
+	// requireVerifiedEmail 为 true 时，作者验证邮箱后才能发布文章
+	requireVerifiedEmail bool
 }
 
 // 确保 postBiz 实现了 PostBiz 接口.
 var _ PostBiz = (*postBiz)(nil)
 
-// New 创建 postBiz 的实例.
-func New(store store.IStore) *postBiz {
-	return &postBiz{store: store}
+// New 创建 postBiz 的实例. events 为 nil 时不发布文章变更事件；
+// requireVerifiedEmail 为 true 时，邮箱未验证的作者只能保存草稿.
+func New(store store.IStore, events *pubsub.Broker[*v1.PostEvent], requireVerifiedEmail bool) *postBiz {
+	return &postBiz{store: store, events: events, requireVerifiedEmail: requireVerifiedEmail}
 }
 
 // loadPostsWithRelations 批量加载文章及其关联的分类和标签信息
@@ -75,6 +83,12 @@ func (b *postBiz) Create(ctx context.Context, rq *v1.CreatePostRequest) (*v1.Cre
 	postM.CreatedAt = &now
 	postM.UpdatedAt = &now
 
+	if isPublished(&postM) {
+		if err := b.checkCanPublish(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	// 使用事务确保创建文章和标签关联的原子性
 	err := b.store.TX(ctx, func(txCtx context.Context) error {
 		// 创建文章
@@ -105,6 +119,11 @@ func (b *postBiz) Create(ctx context.Context, rq *v1.CreatePostRequest) (*v1.Cre
 		return nil, err
 	}
 
+	b.publish(ctx, v1.PostEventType_POST_EVENT_TYPE_CREATED, &postM)
+	if isPublished(&postM) {
+		b.publish(ctx, v1.PostEventType_POST_EVENT_TYPE_PUBLISHED, &postM)
+	}
+
 	return &v1.CreatePostResponse{PostID: postM.PostID}, nil
 }
 
@@ -115,6 +134,13 @@ func (b *postBiz) Update(ctx context.Context, rq *v1.UpdatePostRequest) (*v1.Upd
 	if err != nil {
 		return nil, err
 	}
+	wasPublished := isPublished(postM)
+
+	if !wasPublished && rq.GetStatus() == v1.PostStatus_POST_STATUS_PUBLISHED {
+		if err := b.checkCanPublish(ctx); err != nil {
+			return nil, err
+		}
+	}
 
 	// 使用事务确保更新文章和标签关联的原子性
 	err = b.store.TX(ctx, func(txCtx context.Context) error {
@@ -209,16 +235,33 @@ func (b *postBiz) Update(ctx context.Context, rq *v1.UpdatePostRequest) (*v1.Upd
 		return nil, err
 	}
 
+	b.publish(ctx, v1.PostEventType_POST_EVENT_TYPE_UPDATED, postM)
+	if !wasPublished && isPublished(postM) {
+		b.publish(ctx, v1.PostEventType_POST_EVENT_TYPE_PUBLISHED, postM)
+	}
+
 	return &v1.UpdatePostResponse{}, nil
 }
 
 // Delete 实现 PostBiz 接口中的 Delete 方法.
 func (b *postBiz) Delete(ctx context.Context, rq *v1.DeletePostRequest) (*v1.DeletePostResponse, error) {
 	whr := where.T(ctx).F("post_id", rq.GetPostIDs())
+	// 删除前查询文章的作者与分类，供事件订阅者过滤
+	var deleted []*model.PostM
+	if b.events != nil && b.events.Len() > 0 {
+		var err error
+		if _, deleted, err = b.store.Post().List(ctx, whr); err != nil {
+			return nil, err
+		}
+	}
 	if err := b.store.Post().Delete(ctx, whr); err != nil {
 		return nil, err
 	}
 
+	for _, postM := range deleted {
+		b.publish(ctx, v1.PostEventType_POST_EVENT_TYPE_DELETED, postM)
+	}
+
 	return &v1.DeletePostResponse{}, nil
 }
 
@@ -341,3 +384,19 @@ func (b *postBiz) AppBatchGet(ctx context.Context, rq *v1.BatchGetPostsRequest)
 	}
 	return &v1.BatchGetPostsResponse{Posts: posts}, nil
 }
+
+// checkCanPublish 在要求验证邮箱时，检查当前用户的邮箱是否已验证.
+func (b *postBiz) checkCanPublish(ctx context.Context) error {
+	if !b.requireVerifiedEmail {
+		return nil
+	}
+
+	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	if err != nil {
+		return err
+	}
+	if userM.EmailVerified == nil || *userM.EmailVerified != 1 {
+		return errno.ErrEmailNotVerified
+	}
+	return nil
+}
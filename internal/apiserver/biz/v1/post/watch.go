@@ -0,0 +1,93 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package post
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// Watch 实现 PostExpansion 接口中的 Watch 方法.
+// 非管理员只能订阅自己文章的事件. 订阅者处理过慢导致缓冲区溢出时返回 ErrWatchLagged，客户端应重新订阅并通过 ListPost 补齐数据.
+func (b *postBiz) Watch(ctx context.Context, rq *v1.WatchPostsRequest, send func(*v1.PostEvent) error) error {
+	if b.events == nil {
+		return errno.ErrWatchUnavailable
+	}
+
+	userID := rq.UserID
+	if contextx.Username(ctx) != known.AdminUsername {
+		self := contextx.UserID(ctx)
+		if userID != nil && *userID != self {
+			return errno.ErrPermissionDenied.WithMessage("only the administrator can watch posts of other users")
+		}
+		userID = &self
+	}
+
+	sub := b.events.Subscribe(func(event *v1.PostEvent) bool {
+		if userID != nil && event.GetUserID() != *userID {
+			return false
+		}
+		if rq.CategoryID != nil && (event.CategoryID == nil || event.GetCategoryID() != rq.GetCategoryID()) {
+			return false
+		}
+		return len(rq.GetTypes()) == 0 || slices.Contains(rq.GetTypes(), event.GetType())
+	})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-sub.C():
+			if !ok {
+				if sub.Lagged() {
+					return errno.ErrWatchLagged
+				}
+				return nil
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// publish 发布文章变更事件. 删除事件不携带文章内容，其余事件携带包含分类与标签的文章.
+func (b *postBiz) publish(ctx context.Context, eventType v1.PostEventType, postM *model.PostM) {
+	// 没有订阅者时跳过加载文章关联
+	if !b.events.Active() {
+		return
+	}
+
+	event := &v1.PostEvent{
+		Type:       eventType,
+		PostID:     postM.PostID,
+		UserID:     postM.UserID,
+		CategoryID: postM.CategoryID,
+		OccurredAt: time.Now().Unix(),
+	}
+	if eventType != v1.PostEventType_POST_EVENT_TYPE_DELETED {
+		post, err := b.loadSinglePostWithRelations(ctx, postM)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to load post for event", "postID", postM.PostID, "err", err)
+		}
+		event.Post = post
+	}
+	b.events.Publish(event)
+}
+
+// isPublished 返回文章是否处于已发布状态.
+func isPublished(postM *model.PostM) bool {
+	return postM.Status != nil && *postM.Status == int32(v1.PostStatus_POST_STATUS_PUBLISHED)
+}
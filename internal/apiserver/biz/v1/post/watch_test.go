@@ -0,0 +1,95 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package post
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/pubsub"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// watch 在后台订阅事件，返回接收到的事件与 Watch 的返回值.
+func watch(ctx context.Context, b *postBiz, rq *v1.WatchPostsRequest) (<-chan *v1.PostEvent, <-chan error) {
+	events := make(chan *v1.PostEvent, 16)
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Watch(ctx, rq, func(event *v1.PostEvent) error {
+			events <- event
+			return nil
+		})
+	}()
+	return events, done
+}
+
+// waitSubscribed 等待订阅建立，避免事件在订阅前发布.
+func waitSubscribed(t *testing.T, broker *pubsub.Broker[*v1.PostEvent], n int) {
+	require.Eventually(t, func() bool { return broker.Len() == n }, time.Second, time.Millisecond)
+}
+
+func TestPostBiz_Watch(t *testing.T) {
+	broker := pubsub.NewBroker[*v1.PostEvent](0)
+	b := New(nil, broker, false)
+	ctx, cancel := context.WithCancel(contextx.WithUserID(context.Background(), "user-000001"))
+	defer cancel()
+
+	// 非管理员不能订阅其他用户的文章
+	other := "user-000002"
+	err := b.Watch(ctx, &v1.WatchPostsRequest{UserID: &other}, nil)
+	assert.ErrorIs(t, err, errno.ErrPermissionDenied)
+
+	categoryID := int32(3)
+	events, done := watch(ctx, b, &v1.WatchPostsRequest{
+		CategoryID: &categoryID,
+		Types:      []v1.PostEventType{v1.PostEventType_POST_EVENT_TYPE_PUBLISHED, v1.PostEventType_POST_EVENT_TYPE_DELETED},
+	})
+	waitSubscribed(t, broker, 1)
+
+	otherCategory := int32(4)
+	broker.Publish(&v1.PostEvent{Type: v1.PostEventType_POST_EVENT_TYPE_PUBLISHED, PostID: "post-1", UserID: "user-000002", CategoryID: &categoryID})
+	broker.Publish(&v1.PostEvent{Type: v1.PostEventType_POST_EVENT_TYPE_PUBLISHED, PostID: "post-2", UserID: "user-000001", CategoryID: &otherCategory})
+	broker.Publish(&v1.PostEvent{Type: v1.PostEventType_POST_EVENT_TYPE_UPDATED, PostID: "post-3", UserID: "user-000001", CategoryID: &categoryID})
+	broker.Publish(&v1.PostEvent{Type: v1.PostEventType_POST_EVENT_TYPE_DELETED, PostID: "post-4", UserID: "user-000001", CategoryID: &categoryID})
+
+	event := <-events
+	assert.Equal(t, "post-4", event.GetPostID())
+
+	cancel()
+	assert.NoError(t, <-done)
+	assert.Empty(t, events)
+	waitSubscribed(t, broker, 0)
+}
+
+func TestPostBiz_WatchLagged(t *testing.T) {
+	broker := pubsub.NewBroker[*v1.PostEvent](1)
+	b := New(nil, broker, false)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	block := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- b.Watch(ctx, &v1.WatchPostsRequest{}, func(*v1.PostEvent) error {
+			<-block
+			return nil
+		})
+	}()
+	waitSubscribed(t, broker, 1)
+
+	// 第一条事件阻塞在 send 中，第二条填满缓冲区，第三条导致订阅被关闭
+	for range 3 {
+		broker.Publish(&v1.PostEvent{UserID: "user-000001"})
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(block)
+	assert.ErrorIs(t, <-done, errno.ErrWatchLagged)
+}
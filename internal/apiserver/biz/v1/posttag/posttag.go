@@ -0,0 +1,171 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package posttag
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/conversion"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// PostTagBiz 定义处理文章标签关联请求所需的方法.
+type PostTagBiz interface {
+	Create(ctx context.Context, rq *v1.CreatePostTagRequest) (*v1.CreatePostTagResponse, error)
+	Delete(ctx context.Context, rq *v1.DeletePostTagRequest) (*v1.DeletePostTagResponse, error)
+	List(ctx context.Context, rq *v1.ListPostTagsRequest) (*v1.ListPostTagsResponse, error)
+
+	PostTagExpansion
+}
+
+// PostTagExpansion 定义额外的文章标签关联操作方法.
+type PostTagExpansion interface {
+	BatchCreate(ctx context.Context, rq *v1.BatchCreatePostTagsRequest) (*v1.BatchCreatePostTagsResponse, error)
+	BatchDelete(ctx context.Context, rq *v1.BatchDeletePostTagsRequest) (*v1.BatchDeletePostTagsResponse, error)
+}
+
+// postTagBiz 是 PostTagBiz 接口的实现.
+type postTagBiz struct {
+	store store.IStore
+}
+
+// 确保 postTagBiz 实现了 PostTagBiz 接口.
+var _ PostTagBiz = (*postTagBiz)(nil)
+
+// New 创建 postTagBiz 的实例.
+func New(store store.IStore) *postTagBiz {
+	return &postTagBiz{store: store}
+}
+
+// Create 实现 PostTagBiz 接口中的 Create 方法.
+func (b *postTagBiz) Create(ctx context.Context, rq *v1.CreatePostTagRequest) (*v1.CreatePostTagResponse, error) {
+	if _, err := b.BatchCreate(ctx, &v1.BatchCreatePostTagsRequest{PostID: rq.GetPostID(), TagIDs: []int32{rq.GetTagID()}}); err != nil {
+		return nil, err
+	}
+	return &v1.CreatePostTagResponse{}, nil
+}
+
+// Delete 实现 PostTagBiz 接口中的 Delete 方法.
+func (b *postTagBiz) Delete(ctx context.Context, rq *v1.DeletePostTagRequest) (*v1.DeletePostTagResponse, error) {
+	if _, err := b.BatchDelete(ctx, &v1.BatchDeletePostTagsRequest{PostID: rq.GetPostID(), TagIDs: []int32{rq.GetTagID()}}); err != nil {
+		return nil, err
+	}
+	return &v1.DeletePostTagResponse{}, nil
+}
+
+// List 实现 PostTagBiz 接口中的 List 方法.
+func (b *postTagBiz) List(ctx context.Context, rq *v1.ListPostTagsRequest) (*v1.ListPostTagsResponse, error) {
+	whr := where.NewWhere()
+	if rq.PostID != nil {
+		whr.F("post_id", rq.GetPostID())
+	}
+	if rq.TagID != nil {
+		whr.F("tag_id", rq.GetTagID())
+	}
+
+	_, postTagList, err := b.store.PostTag().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+
+	postTags := make([]*v1.PostTag, 0, len(postTagList))
+	for _, postTag := range postTagList {
+		postTags = append(postTags, conversion.PostTagModelToPostTagV1(postTag))
+	}
+	return &v1.ListPostTagsResponse{PostTags: postTags}, nil
+}
+
+// BatchCreate 实现 PostTagExpansion 接口中的 BatchCreate 方法.
+// 只能为当前用户的文章关联标签，已存在的关联会被忽略.
+func (b *postTagBiz) BatchCreate(ctx context.Context, rq *v1.BatchCreatePostTagsRequest) (*v1.BatchCreatePostTagsResponse, error) {
+	if err := b.checkPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	tagIDs := unique(rq.GetTagIDs())
+	_, tagList, err := b.store.Tag().List(ctx, where.F("id", tagIDs))
+	if err != nil {
+		return nil, err
+	}
+	if len(tagList) != len(tagIDs) {
+		return nil, errno.ErrTagNotFound
+	}
+
+	err = b.store.TX(ctx, func(txCtx context.Context) error {
+		_, existing, err := b.store.PostTag().List(txCtx, where.F("post_id", rq.GetPostID(), "tag_id", tagIDs))
+		if err != nil {
+			return err
+		}
+		linked := make(map[int32]struct{}, len(existing))
+		for _, postTag := range existing {
+			linked[postTag.TagID] = struct{}{}
+		}
+
+		now := time.Now()
+		for _, tagID := range tagIDs {
+			if _, ok := linked[tagID]; ok {
+				continue
+			}
+			postTagM := model.PostTagM{PostID: rq.GetPostID(), TagID: tagID, CreatedAt: &now, UpdatedAt: &now}
+			if err := b.store.PostTag().Create(txCtx, &postTagM); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &v1.BatchCreatePostTagsResponse{}, nil
+}
+
+// BatchDelete 实现 PostTagExpansion 接口中的 BatchDelete 方法.
+func (b *postTagBiz) BatchDelete(ctx context.Context, rq *v1.BatchDeletePostTagsRequest) (*v1.BatchDeletePostTagsResponse, error) {
+	if err := b.checkPost(ctx, rq.GetPostID()); err != nil {
+		return nil, err
+	}
+
+	whr := where.F("post_id", rq.GetPostID(), "tag_id", unique(rq.GetTagIDs()))
+	if err := b.store.PostTag().Delete(ctx, whr); err != nil {
+		return nil, err
+	}
+
+	return &v1.BatchDeletePostTagsResponse{}, nil
+}
+
+// checkPost 确认文章存在且属于当前用户.
+func (b *postTagBiz) checkPost(ctx context.Context, postID string) error {
+	if _, err := b.store.Post().Get(ctx, where.T(ctx).F("post_id", postID)); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrPostNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// unique 返回去重后的标签 ID，保持原有顺序.
+func unique(ids []int32) []int32 {
+	seen := make(map[int32]struct{}, len(ids))
+	result := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		result = append(result, id)
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package posttag
+
+import (
+	"context"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// newTestBiz 返回基于内存 SQLite 的 postTagBiz，仅创建用到的列.
+func newTestBiz(t *testing.T) *postTagBiz {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	for _, stmt := range []string{
+		"CREATE TABLE post (id INTEGER PRIMARY KEY, post_id TEXT, user_id TEXT, deleted_at DATETIME)",
+		"CREATE TABLE tag (id INTEGER PRIMARY KEY, tag_id TEXT, name TEXT, deleted_at DATETIME)",
+		"CREATE TABLE post_tag (id INTEGER PRIMARY KEY, post_id TEXT, tag_id INTEGER, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)",
+		"INSERT INTO post (post_id, user_id) VALUES ('post-000001', 'user-000001')",
+		"INSERT INTO tag (id, tag_id, name) VALUES (1, 'tag-1', 'go'), (2, 'tag-2', 'grpc')",
+	} {
+		require.NoError(t, db.Exec(stmt).Error)
+	}
+
+	where.RegisterTenant("user_id", contextx.UserID)
+	return New(store.NewStore(db, nil, nil))
+}
+
+func TestPostTagBiz(t *testing.T) {
+	b := newTestBiz(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+	postID := "post-000001"
+
+	_, err := b.BatchCreate(ctx, &v1.BatchCreatePostTagsRequest{PostID: postID, TagIDs: []int32{1, 2, 1}})
+	require.NoError(t, err)
+	// 重复关联被忽略
+	_, err = b.Create(ctx, &v1.CreatePostTagRequest{PostID: postID, TagID: 2})
+	require.NoError(t, err)
+
+	resp, err := b.List(ctx, &v1.ListPostTagsRequest{PostID: &postID})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetPostTags(), 2)
+
+	// 标签不存在
+	_, err = b.Create(ctx, &v1.CreatePostTagRequest{PostID: postID, TagID: 3})
+	assert.ErrorIs(t, err, errno.ErrTagNotFound)
+
+	// 其他用户的文章
+	other := contextx.WithUserID(context.Background(), "user-000002")
+	_, err = b.Delete(other, &v1.DeletePostTagRequest{PostID: postID, TagID: 1})
+	assert.ErrorIs(t, err, errno.ErrPostNotFound)
+
+	_, err = b.Delete(ctx, &v1.DeletePostTagRequest{PostID: postID, TagID: 1})
+	require.NoError(t, err)
+	tagID := int32(2)
+	resp, err = b.List(ctx, &v1.ListPostTagsRequest{TagID: &tagID})
+	require.NoError(t, err)
+	require.Len(t, resp.GetPostTags(), 1)
+	assert.Equal(t, postID, resp.GetPostTags()[0].GetPostID())
+}
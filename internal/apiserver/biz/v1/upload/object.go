@@ -0,0 +1,145 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package upload
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// ObjectFile 为私有模式下由服务端提供下载的对象，调用方负责关闭 File.
+type ObjectFile struct {
+	File    *os.File
+	Size    int64
+	ModTime time.Time
+	MIME    string
+	// Disposition 为完整的 Content-Disposition 响应头，包含下载文件名
+	Disposition string
+	// Private 为 true 时响应不允许共享缓存（如 CDN）缓存
+	Private bool
+}
+
+// inlineMIMEPrefixes 为浏览器可以直接展示的类型，其余类型（以及可以携带脚本的 SVG）默认以附件形式下载.
+var inlineMIMEPrefixes = []string{"image/", "video/", "audio/", "application/pdf", "text/plain"}
+
+// OpenObject 实现 UploadExpansion 接口中的 OpenObject 方法.
+// 对象被多条记录引用（如去重复用）时，只有所有记录所在场景均公开，对象才可公开访问；
+// 任一记录为私有时都需要签名，避免私有文件因其他记录公开而被绕过签名访问.
+// query 中的 disposition=attachment 可强制以附件形式下载.
+func (b *uploadBiz) OpenObject(ctx context.Context, key string, query url.Values) (*ObjectFile, error) {
+	srv, ok := b.upl.(uploader.ObjectServer)
+	if !ok {
+		return nil, errno.ErrMediaNotFound
+	}
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+
+	records, err := b.objectRecords(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	private := len(records) == 0 && srv.ObjectACL("") == uploader.ACLPrivate
+	for _, record := range records {
+		if srv.ObjectACL(record.Scene) == uploader.ACLPrivate {
+			private = true
+			break
+		}
+	}
+	if private {
+		if err := srv.VerifyURL(key, query); err != nil {
+			return nil, err
+		}
+	}
+
+	f, err := srv.Open(key)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errno.ErrMediaNotFound
+		}
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil || fi.IsDir() {
+		_ = f.Close()
+		return nil, errno.ErrMediaNotFound
+	}
+
+	obj := &ObjectFile{File: f, Size: fi.Size(), ModTime: fi.ModTime(), Private: private}
+	filename := path.Base(key)
+	obj.MIME = mime.TypeByExtension(path.Ext(key))
+	// 衍生图没有独立的记录，使用扩展名对应的类型与对象键中的文件名
+	if len(records) > 0 && records[0].ObjectKey == key {
+		obj.MIME = records[0].MIME
+		if records[0].Filename != "" {
+			filename = records[0].Filename
+		}
+	}
+	obj.Disposition = contentDisposition(obj.MIME, filename, query.Get("disposition"))
+	return obj, nil
+}
+
+// objectACL 返回 scene 下对象的访问权限，存储后端不支持签名时为空.
+func (b *uploadBiz) objectACL(scene string) string {
+	if srv, ok := b.upl.(uploader.ObjectServer); ok {
+		return srv.ObjectACL(scene)
+	}
+	return ""
+}
+
+// objectRecords 返回指向对象 key 的上传记录；key 为衍生图时返回其原对象的记录.
+func (b *uploadBiz) objectRecords(ctx context.Context, key string) ([]*model.UploadedFileM, error) {
+	whr := where.F("provider", b.upl.Provider(), "object_key", key)
+	stem, prefix, derived := uploader.DerivativeOf(key)
+	if derived {
+		// 原对象键为 stem 加上扩展名（或无扩展名）
+		whr = where.NewWhere().Q("provider = ? AND (object_key = ? OR object_key LIKE ?)", b.upl.Provider(), stem, stem+".%")
+	}
+
+	_, records, err := b.store.UploadedFile().List(ctx, whr)
+	if err != nil {
+		return nil, err
+	}
+	if !derived {
+		return records, nil
+	}
+
+	// LIKE 中的 _ 会匹配任意字符，按前缀精确过滤
+	matched := records[:0]
+	for _, record := range records {
+		if uploader.DerivativePrefix(record.ObjectKey) == prefix {
+			matched = append(matched, record)
+		}
+	}
+	return matched, nil
+}
+
+// contentDisposition 返回 Content-Disposition：可安全展示的类型默认 inline，其余类型以及显式要求下载时为 attachment.
+func contentDisposition(mimeType string, filename string, want string) string {
+	disposition := "attachment"
+	if want != "attachment" && mimeType != "image/svg+xml" {
+		for _, prefix := range inlineMIMEPrefixes {
+			if strings.HasPrefix(mimeType, prefix) {
+				disposition = "inline"
+				break
+			}
+		}
+	}
+	if value := mime.FormatMediaType(disposition, map[string]string{"filename": filename}); value != "" {
+		return value
+	}
+	return disposition
+}
@@ -0,0 +1,409 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/conversion"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/quota"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// UploadBiz 定义处理文件上传请求所需的方法.
+type UploadBiz interface {
+	// Upload 上传单个文件，r 为文件内容.
+	Upload(ctx context.Context, rq *v1.UploadFileRequest, r io.Reader) (*v1.UploadedObject, error)
+	InitMultipart(ctx context.Context, rq *v1.InitMultipartRequest) (*v1.InitMultipartResponse, error)
+	PresignParts(ctx context.Context, rq *v1.PresignPartsRequest) (*v1.PresignPartsResponse, error)
+	// UploadPart 代理上传单个分片，r 为分片内容.
+	UploadPart(ctx context.Context, rq *v1.UploadPartRequest, r io.Reader) (*v1.UploadPartResponse, error)
+	ListParts(ctx context.Context, rq *v1.ListPartsRequest) (*v1.ListPartsResponse, error)
+	CompleteMultipart(ctx context.Context, rq *v1.CompleteMultipartRequest) (*v1.CompleteMultipartResponse, error)
+	AbortMultipart(ctx context.Context, rq *v1.AbortMultipartRequest) (*v1.AbortMultipartResponse, error)
+
+	UploadExpansion
+}
+
+// UploadExpansion 定义额外的上传操作方法.
+type UploadExpansion interface {
+	// CheckUpload 检查当前用户能否再上传 size 字节，供内容尚未到达的上传（如可续传上传）尽早拒绝.
+	CheckUpload(ctx context.Context, size int64) error
+	// OpenObject 校验访问权限后打开本地存储中的对象，用于私有模式下由服务端提供下载.
+	// 带签名参数的请求按签名校验，未签名的请求仅能访问公开对象.
+	OpenObject(ctx context.Context, key string, query url.Values) (*ObjectFile, error)
+}
+
+// uploadBiz 是 UploadBiz 接口的实现.
+type uploadBiz struct {
+	store store.IStore
+	upl   uploader.Uploader
+	quota *quota.Limiter
+}
+
+// 确保 uploadBiz 实现了 UploadBiz 接口.
+var _ UploadBiz = (*uploadBiz)(nil)
+
+// New 创建 uploadBiz 的实例.
+func New(store store.IStore, upl uploader.Uploader, quota *quota.Limiter) *uploadBiz {
+	return &uploadBiz{store: store, upl: upl, quota: quota}
+}
+
+// Upload 实现 UploadBiz 接口中的 Upload 方法.
+func (b *uploadBiz) Upload(ctx context.Context, rq *v1.UploadFileRequest, r io.Reader) (*v1.UploadedObject, error) {
+	// 写入前按声明的大小预留配额，实际内容不得超过声明的大小
+	size := rq.GetSize()
+	if err := b.quota.Reserve(ctx, size); err != nil {
+		return nil, err
+	}
+
+	obj, err := b.upl.Upload(ctx, &uploader.UploadInput{
+		Filename: rq.GetFilename(),
+		MIME:     rq.GetMime(),
+		Scene:    rq.GetScene(),
+		Size:     size,
+	}, &sizedReader{r: r, remaining: size, size: size})
+	if err != nil {
+		b.quota.Release(ctx, size)
+		return nil, err
+	}
+
+	return b.record(ctx, obj, size)
+}
+
+// CheckUpload 实现 UploadExpansion 接口中的 CheckUpload 方法.
+func (b *uploadBiz) CheckUpload(ctx context.Context, size int64) error {
+	return b.quota.CheckUpload(ctx, size)
+}
+
+// InitMultipart 实现 UploadBiz 接口中的 InitMultipart 方法.
+// 客户端声明的 sha256 与大小命中自己已上传的文件时直接返回该文件，无需再上传.
+// 仅凭哈希无法证明客户端持有内容，因此不复用其他用户的文件.
+func (b *uploadBiz) InitMultipart(ctx context.Context, rq *v1.InitMultipartRequest) (*v1.InitMultipartResponse, error) {
+	mu, err := b.multipart()
+	if err != nil {
+		return nil, err
+	}
+
+	if b.deduplicate() && rq.GetSha256() != "" {
+		fileM, err := b.store.UploadedFile().FindBySHA256(ctx, b.upl.Provider(), rq.GetSha256(), contextx.UserID(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if fileM != nil && fileM.Size == rq.GetSize() {
+			return &v1.InitMultipartResponse{
+				Key:        fileM.ObjectKey,
+				Duplicated: true,
+				Object:     b.signed(conversion.UploadedFileModelToUploadedObjectV1(fileM)),
+			}, nil
+		}
+	}
+
+	// 按声明的大小尽早拒绝，合并前再按已上传的分片预留配额
+	if err := b.quota.CheckUpload(ctx, rq.GetSize()); err != nil {
+		return nil, err
+	}
+	if err := b.quota.CheckSession(ctx); err != nil {
+		return nil, err
+	}
+
+	sess, err := mu.InitMultipart(ctx, &uploader.MultipartInput{
+		Filename: rq.GetFilename(),
+		Size:     rq.GetSize(),
+		MIME:     rq.GetMime(),
+		Scene:    rq.GetScene(),
+		SHA256:   rq.GetSha256(),
+		PartSize: rq.GetPartSize(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 并发创建会话时 CheckSession 可能同时通过，以计入后的会话数为准
+	if err := b.quota.AcquireSession(ctx, sess.UploadID); err != nil {
+		_ = mu.AbortMultipart(ctx, sess.UploadID)
+		return nil, err
+	}
+
+	mode := v1.MultipartMode_PROXY
+	if sess.Mode == "direct" {
+		mode = v1.MultipartMode_DIRECT
+	}
+	return &v1.InitMultipartResponse{
+		UploadID:              sess.UploadID,
+		Key:                   sess.Key,
+		Mode:                  mode,
+		PartSize:              sess.PartSize,
+		PresignExpiresSeconds: int64(sess.PresignExpires.Seconds()),
+	}, nil
+}
+
+// PresignParts 实现 UploadBiz 接口中的 PresignParts 方法.
+func (b *uploadBiz) PresignParts(ctx context.Context, rq *v1.PresignPartsRequest) (*v1.PresignPartsResponse, error) {
+	presigner, ok := b.upl.(uploader.PartPresigner)
+	if !ok {
+		return nil, errno.ErrPresignDisabled
+	}
+	parts, err := presigner.PresignParts(ctx, rq.GetUploadID(), rq.GetPartNumbers())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.PresignPartsResponse{Items: make([]*v1.PresignedPart, 0, len(parts))}
+	for _, part := range parts {
+		resp.Items = append(resp.Items, &v1.PresignedPart{
+			PartNumber: part.PartNumber,
+			Url:        part.URL,
+			Headers:    part.Headers,
+			ExpiresAt:  part.ExpiresAt.UTC().Format(time.RFC3339),
+		})
+	}
+	return resp, nil
+}
+
+// UploadPart 实现 UploadBiz 接口中的 UploadPart 方法.
+func (b *uploadBiz) UploadPart(ctx context.Context, rq *v1.UploadPartRequest, r io.Reader) (*v1.UploadPartResponse, error) {
+	mu, err := b.multipart()
+	if err != nil {
+		return nil, err
+	}
+	part, err := mu.UploadPart(ctx, rq.GetUploadID(), rq.GetPartNumber(), rq.GetChecksum(), r)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.UploadPartResponse{
+		PartNumber: part.PartNumber,
+		Etag:       part.ETag,
+		Size:       part.Size,
+		Checksum:   part.Checksum,
+	}, nil
+}
+
+// ListParts 实现 UploadBiz 接口中的 ListParts 方法.
+func (b *uploadBiz) ListParts(ctx context.Context, rq *v1.ListPartsRequest) (*v1.ListPartsResponse, error) {
+	mu, err := b.multipart()
+	if err != nil {
+		return nil, err
+	}
+	parts, err := mu.ListParts(ctx, rq.GetUploadID())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &v1.ListPartsResponse{UploadID: rq.GetUploadID(), Parts: make([]*v1.UploadedPart, 0, len(parts))}
+	for _, part := range parts {
+		resp.Parts = append(resp.Parts, &v1.UploadedPart{PartNumber: part.PartNumber, Etag: part.ETag, Size: part.Size})
+	}
+	return resp, nil
+}
+
+// CompleteMultipart 实现 UploadBiz 接口中的 CompleteMultipart 方法.
+func (b *uploadBiz) CompleteMultipart(ctx context.Context, rq *v1.CompleteMultipartRequest) (*v1.CompleteMultipartResponse, error) {
+	mu, err := b.multipart()
+	if err != nil {
+		return nil, err
+	}
+
+	reserved, err := b.reserveParts(ctx, mu, rq.GetUploadID())
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*uploader.Part, 0, len(rq.GetParts()))
+	for _, part := range rq.GetParts() {
+		parts = append(parts, &uploader.Part{PartNumber: part.GetPartNumber(), ETag: part.GetEtag()})
+	}
+	obj, err := mu.CompleteMultipart(ctx, rq.GetUploadID(), parts)
+	if err != nil {
+		b.quota.Release(ctx, reserved)
+		return nil, err
+	}
+	b.quota.ReleaseSession(ctx, rq.GetUploadID())
+
+	object, err := b.record(ctx, obj, reserved)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.CompleteMultipartResponse{Object: object}, nil
+}
+
+// AbortMultipart 实现 UploadBiz 接口中的 AbortMultipart 方法.
+func (b *uploadBiz) AbortMultipart(ctx context.Context, rq *v1.AbortMultipartRequest) (*v1.AbortMultipartResponse, error) {
+	mu, err := b.multipart()
+	if err != nil {
+		return nil, err
+	}
+	if err := mu.AbortMultipart(ctx, rq.GetUploadID()); err != nil {
+		return nil, err
+	}
+	b.quota.ReleaseSession(ctx, rq.GetUploadID())
+	return &v1.AbortMultipartResponse{UploadID: rq.GetUploadID()}, nil
+}
+
+// multipart 返回分片上传实现；当前存储后端不支持分片上传时返回 ErrMultipartDisabled.
+func (b *uploadBiz) multipart() (uploader.MultipartUploader, error) {
+	mu, ok := b.upl.(uploader.MultipartUploader)
+	if !ok {
+		return nil, errno.ErrMultipartDisabled
+	}
+	return mu, nil
+}
+
+// reserveParts 在合并前按已上传分片的总大小预留配额，合并后的对象不会超过该大小.
+func (b *uploadBiz) reserveParts(ctx context.Context, mu uploader.MultipartUploader, uploadID string) (int64, error) {
+	if !b.quota.Enabled() {
+		return 0, nil
+	}
+	parts, err := mu.ListParts(ctx, uploadID)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, part := range parts {
+		size += part.Size
+	}
+	if err := b.quota.Reserve(ctx, size); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// deduplicate 返回是否启用内容去重.
+func (b *uploadBiz) deduplicate() bool {
+	d, ok := b.upl.(uploader.Deduplicator)
+	return ok && d.Deduplicate()
+}
+
+// record 为刚写入存储的对象创建上传文件记录.
+// 启用去重时：调用者已上传过相同内容则直接返回原记录；其他用户上传过相同内容则复用其对象键，
+// 并删除本次写入的重复对象，每个用户仍各自拥有一条记录. Hash 由 Uploader 根据实际内容计算，
+// 因此可以跨用户复用. reserved 为写入前预留的配额，未新建记录时全部释放，否则按实际保存的大小修正.
+func (b *uploadBiz) record(ctx context.Context, obj *uploader.UploadedObject, reserved int64) (*v1.UploadedObject, error) {
+	userID := contextx.UserID(ctx)
+	fileM := &model.UploadedFileM{
+		UserID:    userID,
+		Provider:  obj.Provider,
+		ObjectKey: obj.Key,
+		URL:       obj.URL,
+		SHA256:    obj.Hash,
+		Size:      obj.Size,
+		MIME:      obj.MIME,
+		Scene:     obj.Metadata["scene"],
+		Filename:  obj.Metadata["filename"],
+		// 图片尺寸与衍生图 URL
+		ImageMetadata: imageMetadata(obj.Metadata),
+	}
+
+	// 无法计算内容哈希的对象不参与去重
+	if b.deduplicate() && obj.Hash != "" {
+		canonical, err := b.store.UploadedFile().FindBySHA256(ctx, obj.Provider, obj.Hash, "")
+		if err != nil {
+			b.quota.Release(ctx, reserved)
+			return nil, err
+		}
+		// 访问权限不同的场景不共用对象，否则私有对象会随公开记录被公开访问，公开对象也会要求签名
+		if canonical != nil && b.objectACL(canonical.Scene) != b.objectACL(fileM.Scene) {
+			canonical = nil
+		}
+		if canonical != nil {
+			if canonical.ObjectKey != obj.Key {
+				b.removeDuplicate(ctx, obj)
+				fileM.ObjectKey = canonical.ObjectKey
+				fileM.URL = canonical.URL
+				fileM.ImageMetadata = canonical.ImageMetadata
+			}
+
+			owned := canonical
+			if canonical.UserID != userID {
+				if owned, err = b.store.UploadedFile().FindBySHA256(ctx, obj.Provider, obj.Hash, userID); err != nil {
+					b.quota.Release(ctx, reserved)
+					return nil, err
+				}
+			}
+			if owned != nil {
+				b.quota.Release(ctx, reserved)
+				return b.signed(conversion.UploadedFileModelToUploadedObjectV1(owned)), nil
+			}
+		}
+	}
+
+	if err := b.store.UploadedFile().Create(ctx, fileM); err != nil {
+		b.quota.Release(ctx, reserved)
+		return nil, err
+	}
+	// 图片去除元数据后保存的大小可能小于预留的大小
+	b.quota.Release(ctx, reserved-fileM.Size)
+	return b.signed(conversion.UploadedFileModelToUploadedObjectV1(fileM)), nil
+}
+
+// signed 为私有对象的 URL 追加签名，记录中保存的始终是未签名的 URL.
+func (b *uploadBiz) signed(obj *v1.UploadedObject) *v1.UploadedObject {
+	obj.Url = uploader.SignObjectURLs(b.upl, obj.GetMetadata()["scene"], obj.GetUrl(), obj.GetMetadata())
+	return obj
+}
+
+// removeDuplicate 删除本次写入的重复对象；对象键仍被其他记录引用时保留（如按日期生成的键恰好相同）.
+func (b *uploadBiz) removeDuplicate(ctx context.Context, obj *uploader.UploadedObject) {
+	remover, ok := b.upl.(uploader.Remover)
+	if !ok {
+		return
+	}
+
+	count, _, err := b.store.UploadedFile().List(ctx, where.F("provider", obj.Provider, "object_key", obj.Key).L(1))
+	if err != nil || count > 0 {
+		return
+	}
+	if err := remover.Remove(ctx, obj.Key); err != nil {
+		log.W(ctx).Errorw("Failed to remove duplicated object", "key", obj.Key, "err", err)
+	}
+}
+
+// imageMetadata 返回需要随记录保存的图片信息（JSON），scene 与 filename 已有独立字段，不重复保存.
+func imageMetadata(metadata map[string]string) *string {
+	m := make(map[string]string, len(metadata))
+	for k, v := range metadata {
+		if k != "scene" && k != "filename" {
+			m[k] = v
+		}
+	}
+	if len(m) == 0 {
+		return nil
+	}
+
+	data, _ := json.Marshal(m)
+	s := string(data)
+	return &s
+}
+
+// sizedReader 在读取的内容超过声明的大小时返回 ErrFileTooLarge，保证写入的大小不超过检查配额时使用的大小.
+type sizedReader struct {
+	r         io.Reader
+	remaining int64
+	size      int64
+}
+
+func (s *sizedReader) Read(p []byte) (int, error) {
+	// 多读 1 字节用于判断是否超出
+	if int64(len(p)) > s.remaining+1 {
+		p = p[:s.remaining+1]
+	}
+	n, err := s.r.Read(p)
+	s.remaining -= int64(n)
+	if s.remaining < 0 {
+		return n, errno.ErrFileTooLarge.WithMessage("file exceeds the declared size of %d bytes", s.size)
+	}
+	return n, err
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// SendVerificationEmail 向当前用户的邮箱发送验证邮件.
+func (b *userBiz) SendVerificationEmail(ctx context.Context, rq *v1.SendVerificationEmailRequest) (*v1.SendVerificationEmailResponse, error) {
+	userM, err := b.store.User().Get(ctx, where.T(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if userM.EmailVerified != nil && *userM.EmailVerified == 1 {
+		return nil, errno.ErrEmailAlreadyVerified
+	}
+
+	to := verification.Recipient{UserID: userM.UserID, Username: userM.Username, Email: userM.Email}
+	if err := b.verifier.Send(ctx, verification.PurposeVerifyEmail, to); err != nil {
+		if errx := new(errno.ErrorX); errors.As(err, &errx) {
+			return nil, errx
+		}
+		log.W(ctx).Errorw("Failed to send verification email", "user", userM.UserID, "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	return &v1.SendVerificationEmailResponse{}, nil
+}
+
+// ConfirmEmailVerification 校验验证邮件中的令牌，并将令牌所属用户的邮箱标记为已验证.
+func (b *userBiz) ConfirmEmailVerification(ctx context.Context, rq *v1.ConfirmEmailVerificationRequest) (*v1.ConfirmEmailVerificationResponse, error) {
+	ticket, err := b.verifier.Consume(ctx, verification.PurposeVerifyEmail, rq.GetToken())
+	if err != nil {
+		if errx := new(errno.ErrorX); errors.As(err, &errx) {
+			return nil, errx
+		}
+		log.W(ctx).Errorw("Failed to consume verification token", "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	// 用户已被删除，或发送邮件后修改了邮箱
+	userM, err := b.store.User().Get(ctx, where.F("user_id", ticket.UserID))
+	if err != nil || userM.Email != ticket.Email {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	if userM.EmailVerified == nil || *userM.EmailVerified != 1 {
+		// 只更新验证状态，避免整行写回覆盖并发请求对密码、登录失败次数等列的修改
+		if err := b.store.User().UpdateColumns(ctx, userM.UserID, map[string]any{
+			"email_verified": 1,
+			"updated_at":     time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return &v1.ConfirmEmailVerificationResponse{}, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+)
+
+const (
+	// maxFailedLoginAttempts 为锁定账户前允许的连续失败登录次数.
+	maxFailedLoginAttempts = 5
+	// lockoutBaseDuration 为首次锁定的时长，此后每次失败翻倍.
+	lockoutBaseDuration = time.Minute
+	// lockoutMaxDuration 为单次锁定的最长时长.
+	lockoutMaxDuration = 24 * time.Hour
+	// maxLoginDeviceLength 为 last_login_device 列的长度.
+	maxLoginDeviceLength = 100
+
+	keyLockout = "miniblog:login:lockout:%s"
+)
+
+// lockoutDuration 返回连续失败 attempts 次后账户的锁定时长，未达到上限时返回 0.
+// 第 5 次失败锁定 1 分钟，此后每次失败翻倍，最长 24 小时.
+func lockoutDuration(attempts int32) time.Duration {
+	if attempts < maxFailedLoginAttempts {
+		return 0
+	}
+	d := lockoutBaseDuration
+	for range attempts - maxFailedLoginAttempts {
+		d *= 2
+		if d >= lockoutMaxDuration {
+			return lockoutMaxDuration
+		}
+	}
+	return d
+}
+
+// lockedUntil 返回账户的解锁时间，未锁定时返回零值. 锁定状态保存在 Redis 中，未配置 Redis 时不锁定账户.
+func (b *userBiz) lockedUntil(ctx context.Context, userID string) time.Time {
+	rdb := b.store.Redis(ctx)
+	if rdb == nil {
+		return time.Time{}
+	}
+
+	unlockAt, err := rdb.Get(ctx, fmt.Sprintf(keyLockout, userID)).Int64()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			log.W(ctx).Errorw("Failed to get account lockout", "user", userID, "err", err)
+		}
+		return time.Time{}
+	}
+	return time.UnixMilli(unlockAt)
+}
+
+// unlock 解除账户锁定，用户通过邮件重置密码后无需等待锁定到期.
+func (b *userBiz) unlock(ctx context.Context, userID string) {
+	rdb := b.store.Redis(ctx)
+	if rdb == nil {
+		return
+	}
+	if err := rdb.Del(ctx, fmt.Sprintf(keyLockout, userID)).Err(); err != nil {
+		log.W(ctx).Errorw("Failed to unlock account", "user", userID, "err", err)
+	}
+}
+
+// loginFailed 记录一次失败的登录，连续失败达到上限后按指数退避锁定账户.
+func (b *userBiz) loginFailed(ctx context.Context, userM *model.UserM) error {
+	// 并发的失败请求各自原子加一，不会互相覆盖而少计次数
+	attempts, err := b.store.User().IncrFailedLoginAttempts(ctx, userM.UserID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to update failed login attempts", "user", userM.UserID, "err", err)
+		if userM.FailedLoginAttempts != nil {
+			attempts = *userM.FailedLoginAttempts
+		}
+		attempts++
+	}
+
+	log.W(ctx).Warnw("User login failed", "user", userM.UserID, "attempts", attempts, "ip", contextx.ClientIP(ctx))
+
+	d := lockoutDuration(attempts)
+	if d == 0 {
+		return errno.ErrPasswordInvalid
+	}
+	// 锁定状态保存在 Redis 中，无法记录锁定时不向客户端报告锁定
+	rdb := b.store.Redis(ctx)
+	if rdb == nil {
+		return errno.ErrPasswordInvalid
+	}
+	unlockAt := time.Now().Add(d)
+	if err := rdb.Set(ctx, fmt.Sprintf(keyLockout, userM.UserID), unlockAt.UnixMilli(), d).Err(); err != nil {
+		log.W(ctx).Errorw("Failed to lock account", "user", userM.UserID, "err", err)
+		return errno.ErrPasswordInvalid
+	}
+	log.W(ctx).Warnw("Account locked", "user", userM.UserID, "attempts", attempts, "unlockAt", unlockAt)
+	return accountLocked(unlockAt)
+}
+
+// loginSucceeded 重置失败次数并记录本次登录的时间、IP 与设备. 记录失败不影响登录.
+func (b *userBiz) loginSucceeded(ctx context.Context, userM *model.UserM) {
+	now := time.Now()
+	ip, device := contextx.ClientIP(ctx), contextx.UserAgent(ctx)
+	if len(device) > maxLoginDeviceLength {
+		device = device[:maxLoginDeviceLength]
+	}
+
+	// 只更新登录相关的列，避免覆盖并发请求（如修改密码）写入的其他列
+	if err := b.store.User().UpdateColumns(ctx, userM.UserID, map[string]any{
+		"failed_login_attempts": 0,
+		"last_login_at":         now,
+		"last_login_ip":         ip,
+		"last_login_device":     device,
+	}); err != nil {
+		log.W(ctx).Errorw("Failed to record user login", "user", userM.UserID, "err", err)
+	}
+
+	log.W(ctx).Infow("User logged in", "user", userM.UserID, "ip", ip, "location", contextx.ClientLocation(ctx), "device", device)
+}
+
+// accountLocked 返回带解锁时间的账户锁定错误.
+func accountLocked(unlockAt time.Time) error {
+	return errno.ErrAccountLocked.Copy().KV("unlockAt", strconv.FormatInt(unlockAt.Unix(), 10))
+}
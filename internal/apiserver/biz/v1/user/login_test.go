@@ -0,0 +1,160 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package user
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/session"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+var (
+	testOnce  sync.Once
+	testDB    *gorm.DB
+	testRedis *miniredis.Miniredis
+	testStore store.IStore
+)
+
+// newTestBiz 返回基于内存 SQLite 与 miniredis 的 userBiz，并创建用户 alice.
+// store 为进程内单例，因此各用例共享数据库与 Redis，并在开始前清空数据.
+func newTestBiz(t *testing.T) (*userBiz, *model.UserM, *miniredis.Miniredis) {
+	testOnce.Do(func() {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+		require.NoError(t, err)
+		require.NoError(t, db.AutoMigrate(&model.UserM{}))
+		// 内存数据库每个连接相互独立，限制为单个连接
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+
+		mr, err := miniredis.Run()
+		require.NoError(t, err)
+
+		where.RegisterTenant("user_id", contextx.UserID)
+		testDB, testRedis = db, mr
+		testStore = store.NewStore(db, nil, redis.NewClient(&redis.Options{Addr: mr.Addr()}))
+	})
+	require.NoError(t, testDB.Exec("DELETE FROM user").Error)
+	testRedis.FlushAll()
+	testRedis.SetError("")
+
+	alice := &model.UserM{Username: "alice", Password: "miniblog1234", Email: "alice@example.com", Phone: "13800138000"}
+	require.NoError(t, testDB.Create(alice).Error)
+
+	rdb := testStore.Redis(context.Background())
+	return New(testStore, nil, session.New(rdb, time.Hour, time.Hour), nil), alice, testRedis
+}
+
+func TestLockoutDuration(t *testing.T) {
+	assert.Equal(t, time.Duration(0), lockoutDuration(0))
+	assert.Equal(t, time.Duration(0), lockoutDuration(4))
+	assert.Equal(t, time.Minute, lockoutDuration(5))
+	assert.Equal(t, 2*time.Minute, lockoutDuration(6))
+	assert.Equal(t, 16*time.Minute, lockoutDuration(9))
+	assert.Equal(t, 24*time.Hour, lockoutDuration(20))
+	assert.Equal(t, 24*time.Hour, lockoutDuration(1000))
+}
+
+func TestLoginLockout(t *testing.T) {
+	b, alice, mr := newTestBiz(t)
+	ctx := contextx.WithClientIP(context.Background(), "203.0.113.7")
+	ctx = contextx.WithUserAgent(ctx, "miniblog-test/1.0")
+	wrong := &v1.LoginRequest{Username: "alice", Password: "wrong-password1"}
+	right := &v1.LoginRequest{Username: "alice", Password: "miniblog1234"}
+
+	for range 4 {
+		_, err := b.Login(ctx, wrong)
+		assert.ErrorIs(t, err, errno.ErrPasswordInvalid)
+	}
+
+	// 第 5 次失败后锁定 1 分钟
+	_, err := b.Login(ctx, wrong)
+	require.ErrorIs(t, err, errno.ErrAccountLocked)
+	unlockAt, err := strconv.ParseInt(errno.FromError(err).Metadata["unlockAt"], 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), time.Unix(unlockAt, 0), 2*time.Second)
+
+	// 锁定期间密码正确也无法登录
+	_, err = b.Login(ctx, right)
+	assert.ErrorIs(t, err, errno.ErrAccountLocked)
+
+	// 解锁后再次失败，锁定时长翻倍
+	mr.FastForward(time.Minute)
+	_, err = b.Login(ctx, wrong)
+	assert.ErrorIs(t, err, errno.ErrAccountLocked)
+	assert.InDelta(t, 2*time.Minute, mr.TTL("miniblog:login:lockout:"+alice.UserID), float64(time.Second))
+
+	mr.FastForward(2 * time.Minute)
+	resp, err := b.Login(ctx, right)
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.GetToken())
+
+	// 登录成功后重置失败次数并记录登录信息
+	userM, err := b.store.User().Get(ctx, where.F("user_id", alice.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), *userM.FailedLoginAttempts)
+	assert.Equal(t, "203.0.113.7", *userM.LastLoginIP)
+	assert.Equal(t, "miniblog-test/1.0", *userM.LastLoginDevice)
+	assert.WithinDuration(t, time.Now(), *userM.LastLoginAt, time.Minute)
+}
+
+func TestLoginFailedConcurrent(t *testing.T) {
+	b, alice, _ := newTestBiz(t)
+
+	// 并发的失败登录各自计数，不会互相覆盖
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := b.Login(context.Background(), &v1.LoginRequest{Username: "alice", Password: "wrong-password1"})
+			assert.ErrorIs(t, err, errno.ErrPasswordInvalid)
+		}()
+	}
+	wg.Wait()
+
+	userM, err := b.store.User().Get(context.Background(), where.F("user_id", alice.UserID))
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), *userM.FailedLoginAttempts)
+}
+
+// noRedisStore 模拟未配置 Redis 的存储.
+type noRedisStore struct {
+	store.IStore
+}
+
+func (noRedisStore) Redis(context.Context) *redis.Client { return nil }
+
+func TestLoginFailedWithoutRedis(t *testing.T) {
+	b, _, _ := newTestBiz(t)
+	b.store = noRedisStore{IStore: b.store}
+	wrong := &v1.LoginRequest{Username: "alice", Password: "wrong-password1"}
+
+	// 无法记录锁定时不报告锁定
+	for range maxFailedLoginAttempts + 1 {
+		_, err := b.Login(context.Background(), wrong)
+		assert.ErrorIs(t, err, errno.ErrPasswordInvalid)
+		assert.False(t, errno.ErrAccountLocked.Is(err))
+	}
+}
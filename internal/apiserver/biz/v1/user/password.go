@@ -0,0 +1,110 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/auth"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// sendPasswordResetTimeout 为后台发送重置密码邮件的超时时间.
+const sendPasswordResetTimeout = 30 * time.Second
+
+// ForgotPassword 向邮箱发送重置密码邮件.
+// 为避免泄露邮箱是否已注册，邮箱不存在、发送过于频繁或发送失败时同样返回成功，
+// 邮件在后台发送，响应时间也不因邮箱是否存在而不同.
+func (b *userBiz) ForgotPassword(ctx context.Context, rq *v1.ForgotPasswordRequest) (*v1.ForgotPasswordResponse, error) {
+	// 未配置发件服务与邮箱是否存在无关，可以如实返回
+	if !b.verifier.Available() {
+		return nil, errno.ErrMailerUnavailable
+	}
+
+	userM, err := b.store.User().Get(ctx, where.F("email", rq.GetEmail()))
+	if err != nil {
+		log.W(ctx).Infow("Password reset requested for unknown email", "ip", contextx.ClientIP(ctx))
+		return &v1.ForgotPasswordResponse{}, nil
+	}
+
+	// 请求结束后继续发送，但不能无限期占用 SMTP 连接
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), sendPasswordResetTimeout)
+		defer cancel()
+		b.sendPasswordReset(ctx, userM)
+	}()
+
+	return &v1.ForgotPasswordResponse{}, nil
+}
+
+// sendPasswordReset 发送重置密码邮件，错误只记录日志.
+func (b *userBiz) sendPasswordReset(ctx context.Context, userM *model.UserM) {
+	to := verification.Recipient{UserID: userM.UserID, Username: userM.Username, Email: userM.Email}
+	if err := b.verifier.Send(ctx, verification.PurposeResetPassword, to); err != nil {
+		if errno.ErrVerificationThrottled.Is(err) {
+			log.W(ctx).Warnw("Password reset email throttled", "user", userM.UserID, "ip", contextx.ClientIP(ctx))
+			return
+		}
+		log.W(ctx).Errorw("Failed to send password reset email", "user", userM.UserID, "err", err)
+		return
+	}
+	log.W(ctx).Infow("Password reset email sent", "user", userM.UserID, "ip", contextx.ClientIP(ctx))
+}
+
+// ResetPassword 校验重置密码令牌并设置新密码，随后吊销用户的全部令牌.
+func (b *userBiz) ResetPassword(ctx context.Context, rq *v1.ResetPasswordRequest) (*v1.ResetPasswordResponse, error) {
+	ticket, err := b.verifier.Consume(ctx, verification.PurposeResetPassword, rq.GetToken())
+	if err != nil {
+		if errx := new(errno.ErrorX); errors.As(err, &errx) {
+			return nil, errx
+		}
+		log.W(ctx).Errorw("Failed to consume password reset token", "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	// 用户已被删除，或发送邮件后修改了邮箱
+	userM, err := b.store.User().Get(ctx, where.F("user_id", ticket.UserID))
+	if err != nil || userM.Email != ticket.Email {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	// 先吊销全部令牌，可能已泄露的旧密码登录的会话随即失效；吊销失败时密码保持不变，
+	// 避免密码已重置而旧会话仍然有效
+	if err := b.sessions.RevokeUser(ctx, userM.UserID); err != nil {
+		log.W(ctx).Errorw("Failed to revoke user tokens", "user", userM.UserID, "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	// 能够收到重置邮件，说明用户拥有该邮箱，同时解除登录失败导致的锁定
+	// 只更新密码相关的列，避免整行写回覆盖并发请求对其他列的修改
+	password, err := auth.Encrypt(rq.GetNewPassword())
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := b.store.User().UpdateColumns(ctx, userM.UserID, map[string]any{
+		"password":              password,
+		"password_updated_at":   now,
+		"updated_at":            now,
+		"email_verified":        1,
+		"failed_login_attempts": 0,
+	}); err != nil {
+		return nil, err
+	}
+	b.unlock(ctx, userM.UserID)
+
+	log.W(ctx).Infow("User password reset", "user", userM.UserID, "ip", contextx.ClientIP(ctx))
+
+	return &v1.ResetPasswordResponse{}, nil
+}
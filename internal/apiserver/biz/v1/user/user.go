@@ -7,6 +7,7 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -18,6 +19,8 @@ import (
 
 	"github.com/clin211/miniblog-v2/internal/apiserver/model"
 	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/conversion"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/session"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
 	"github.com/clin211/miniblog-v2/internal/apiserver/store"
 	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
 	"github.com/clin211/miniblog-v2/internal/pkg/errno"
@@ -42,20 +45,28 @@ type UserBiz interface {
 type UserExpansion interface {
 	Login(ctx context.Context, rq *v1.LoginRequest) (*v1.LoginResponse, error)
 	RefreshToken(ctx context.Context, rq *v1.RefreshTokenRequest) (*v1.RefreshTokenResponse, error)
+	Logout(ctx context.Context, rq *v1.LogoutRequest) (*v1.LogoutResponse, error)
 	ChangePassword(ctx context.Context, rq *v1.ChangePasswordRequest) (*v1.ChangePasswordResponse, error)
+	RevokeTokens(ctx context.Context, rq *v1.RevokeUserTokensRequest) (*v1.RevokeUserTokensResponse, error)
+	SendVerificationEmail(ctx context.Context, rq *v1.SendVerificationEmailRequest) (*v1.SendVerificationEmailResponse, error)
+	ConfirmEmailVerification(ctx context.Context, rq *v1.ConfirmEmailVerificationRequest) (*v1.ConfirmEmailVerificationResponse, error)
+	ForgotPassword(ctx context.Context, rq *v1.ForgotPasswordRequest) (*v1.ForgotPasswordResponse, error)
+	ResetPassword(ctx context.Context, rq *v1.ResetPasswordRequest) (*v1.ResetPasswordResponse, error)
 }
 
 // userBiz 是 UserBiz 接口的实现.
 type userBiz struct {
-	store store.IStore
-	authz *auth.Authz
+	store    store.IStore
+	authz    *auth.Authz
+	sessions *session.Manager
+	verifier *verification.Verifier
 }
 
 // 确保 userBiz 实现了 UserBiz 接口.
 var _ UserBiz = (*userBiz)(nil)
 
-func New(store store.IStore, authz *auth.Authz) *userBiz {
-	return &userBiz{store: store, authz: authz}
+func New(store store.IStore, authz *auth.Authz, sessions *session.Manager, verifier *verification.Verifier) *userBiz {
+	return &userBiz{store: store, authz: authz, sessions: sessions, verifier: verifier}
 }
 
 // Login 实现 UserBiz 接口中的 Login 方法.
@@ -67,10 +78,16 @@ func (b *userBiz) Login(ctx context.Context, rq *v1.LoginRequest) (*v1.LoginResp
 		return nil, errno.ErrUserNotFound
 	}
 
+	// 账户锁定期间不再校验密码
+	if unlockAt := b.lockedUntil(ctx, userM.UserID); !unlockAt.IsZero() {
+		log.W(ctx).Warnw("Login rejected, account is locked", "user", userM.UserID, "ip", contextx.ClientIP(ctx))
+		return nil, accountLocked(unlockAt)
+	}
+
 	// 对比传入的明文密码和数据库中已加密过的密码是否匹配
 	if err := auth.Compare(userM.Password, rq.GetPassword()); err != nil {
 		log.W(ctx).Errorw("Failed to compare password", "err", err)
-		return nil, errno.ErrPasswordInvalid
+		return nil, b.loginFailed(ctx, userM)
 	}
 
 	// 如果匹配成功，说明登录成功，签发 token 并返回
@@ -79,19 +96,85 @@ func (b *userBiz) Login(ctx context.Context, rq *v1.LoginRequest) (*v1.LoginResp
 		return nil, errno.ErrSignToken
 	}
 
-	return &v1.LoginResponse{Token: tokenStr, ExpireAt: expireAt.Unix()}, nil
+	// 每次登录创建新的令牌族
+	refresh, err := b.sessions.Issue(ctx, userM.UserID)
+	if err != nil {
+		log.W(ctx).Errorw("Failed to issue refresh token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	b.loginSucceeded(ctx, userM)
+
+	return &v1.LoginResponse{
+		Token:           tokenStr,
+		ExpireAt:        expireAt.Unix(),
+		RefreshToken:    refresh.Token,
+		RefreshExpireAt: refresh.ExpireAt.Unix(),
+	}, nil
 }
 
-// RefreshToken 用于刷新用户的身份验证令牌.
-// 当用户的令牌即将过期时，可以调用此方法生成一个新的令牌.
+// RefreshToken 使用刷新令牌换取新的访问令牌与刷新令牌.
+// 请求中的刷新令牌随即失效，再次使用会吊销同一次登录签发的全部刷新令牌.
 func (b *userBiz) RefreshToken(ctx context.Context, rq *v1.RefreshTokenRequest) (*v1.RefreshTokenResponse, error) {
-	tokenStr, expireAt, err := token.Sign(contextx.UserID(ctx))
+	userID, refresh, err := b.sessions.Rotate(ctx, rq.GetRefreshToken())
+	if err != nil {
+		if errx := new(errno.ErrorX); errors.As(err, &errx) {
+			return nil, errx
+		}
+		log.W(ctx).Errorw("Failed to rotate refresh token", "err", err)
+		return nil, errno.ErrSignToken
+	}
+
+	// 用户已被删除时不再续期
+	if _, err := b.store.User().Get(ctx, where.F("user_id", userID)); err != nil {
+		return nil, errno.ErrRefreshTokenInvalid
+	}
+
+	tokenStr, expireAt, err := token.Sign(userID)
 	if err != nil {
 		log.W(ctx).Errorw("Failed to sign token", "err", err)
 		return nil, errno.ErrSignToken
 	}
 
-	return &v1.RefreshTokenResponse{Token: tokenStr, ExpireAt: expireAt.Unix()}, nil
+	return &v1.RefreshTokenResponse{
+		Token:           tokenStr,
+		ExpireAt:        expireAt.Unix(),
+		RefreshToken:    refresh.Token,
+		RefreshExpireAt: refresh.ExpireAt.Unix(),
+	}, nil
+}
+
+// Logout 吊销当前访问令牌，请求中携带刷新令牌时一并吊销其所在的令牌族.
+func (b *userBiz) Logout(ctx context.Context, rq *v1.LogoutRequest) (*v1.LogoutResponse, error) {
+	userID := contextx.UserID(ctx)
+	claims := &token.Claims{Identity: userID, ID: contextx.TokenID(ctx), ExpiresAt: contextx.TokenExpireAt(ctx)}
+	if err := b.sessions.Deny(ctx, claims); err != nil {
+		log.W(ctx).Errorw("Failed to deny access token", "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	if rq.GetRefreshToken() != "" {
+		if err := b.sessions.Revoke(ctx, userID, rq.GetRefreshToken()); err != nil {
+			log.W(ctx).Errorw("Failed to revoke refresh token", "err", err)
+			return nil, errno.ErrInternal
+		}
+	}
+
+	return &v1.LogoutResponse{}, nil
+}
+
+// RevokeTokens 吊销指定用户的全部访问令牌与刷新令牌.
+func (b *userBiz) RevokeTokens(ctx context.Context, rq *v1.RevokeUserTokensRequest) (*v1.RevokeUserTokensResponse, error) {
+	if _, err := b.store.User().Get(ctx, where.F("user_id", rq.GetUserID())); err != nil {
+		return nil, errno.ErrUserNotFound
+	}
+
+	if err := b.sessions.RevokeUser(ctx, rq.GetUserID()); err != nil {
+		log.W(ctx).Errorw("Failed to revoke user tokens", "user", rq.GetUserID(), "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	return &v1.RevokeUserTokensResponse{}, nil
 }
 
 // ChangePassword 实现 UserBiz 接口中的 ChangePassword 方法.
@@ -106,8 +189,23 @@ func (b *userBiz) ChangePassword(ctx context.Context, rq *v1.ChangePasswordReque
 		return nil, errno.ErrPasswordInvalid
 	}
 
-	userM.Password, _ = auth.Encrypt(rq.GetNewPassword())
-	if err := b.store.User().Update(ctx, userM); err != nil {
+	// 先吊销全部令牌（包括本次请求使用的令牌）再修改密码，吊销失败时密码保持不变，
+	// 避免密码已修改而旧会话仍然有效
+	if err := b.sessions.RevokeUser(ctx, userM.UserID); err != nil {
+		log.W(ctx).Errorw("Failed to revoke user tokens", "user", userM.UserID, "err", err)
+		return nil, errno.ErrInternal
+	}
+
+	password, err := auth.Encrypt(rq.GetNewPassword())
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := b.store.User().UpdateColumns(ctx, userM.UserID, map[string]any{
+		"password":            password,
+		"password_updated_at": now,
+		"updated_at":          now,
+	}); err != nil {
 		return nil, err
 	}
 
@@ -140,7 +238,9 @@ func (b *userBiz) Create(ctx context.Context, rq *v1.CreateUserRequest) (*v1.Cre
 	// 将用户信息缓存到 Redis 中
 	cacheKey := fmt.Sprintf("user:%s", userM.UserID)
 	cacheValue := fmt.Sprintf("username:%s,email:%s,phone:%s", userM.Username, userM.Email, userM.Phone)
-	b.store.Redis(ctx).Set(ctx, cacheKey, cacheValue, 0)
+	if rdb := b.store.Redis(ctx); rdb != nil {
+		rdb.Set(ctx, cacheKey, cacheValue, 0)
+	}
 
 	return &v1.CreateUserResponse{UserID: userM.UserID}, nil
 }
@@ -152,21 +252,21 @@ func (b *userBiz) Update(ctx context.Context, rq *v1.UpdateUserRequest) (*v1.Upd
 		return nil, err
 	}
 
-	// 手动设置更新时间
-	now := time.Now()
-	userM.UpdatedAt = &now
-
+	// 只更新请求中提供的列，手动设置更新时间
+	columns := map[string]any{"updated_at": time.Now()}
 	if rq.Username != nil {
-		userM.Username = rq.GetUsername()
+		columns["username"] = rq.GetUsername()
 	}
-	if rq.Email != nil {
-		userM.Email = rq.GetEmail()
+	if rq.Email != nil && rq.GetEmail() != userM.Email {
+		// 更换邮箱后需要重新验证
+		columns["email"] = rq.GetEmail()
+		columns["email_verified"] = 0
 	}
 	if rq.Phone != nil {
-		userM.Phone = rq.GetPhone()
+		columns["phone"] = rq.GetPhone()
 	}
 
-	if err := b.store.User().Update(ctx, userM); err != nil {
+	if err := b.store.User().UpdateColumns(ctx, userM.UserID, columns); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,85 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package user
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/auth"
+	"github.com/clin211/miniblog-v2/pkg/mailer"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+func TestChangePassword(t *testing.T) {
+	b, alice, mr := newTestBiz(t)
+	ctx := contextx.WithUserID(context.Background(), alice.UserID)
+
+	// 只更新密码相关的列，其他列保持不变
+	require.NoError(t, b.store.User().UpdateColumns(ctx, alice.UserID, map[string]any{"failed_login_attempts": 3}))
+
+	_, err := b.ChangePassword(ctx, &v1.ChangePasswordRequest{OldPassword: "miniblog1234", NewPassword: "miniblog5678"})
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("miniblog:session:revoked:"+alice.UserID))
+
+	userM, err := b.store.User().Get(ctx, where.F("user_id", alice.UserID))
+	require.NoError(t, err)
+	assert.NoError(t, auth.Compare(userM.Password, "miniblog5678"))
+	assert.NotNil(t, userM.PasswordUpdatedAt)
+	assert.Equal(t, int32(3), *userM.FailedLoginAttempts)
+
+	// 吊销失败时密码保持不变
+	mr.SetError("server unavailable")
+	_, err = b.ChangePassword(ctx, &v1.ChangePasswordRequest{OldPassword: "miniblog5678", NewPassword: "miniblog9012"})
+	assert.Error(t, err)
+	mr.SetError("")
+
+	userM, err = b.store.User().Get(ctx, where.F("user_id", alice.UserID))
+	require.NoError(t, err)
+	assert.NoError(t, auth.Compare(userM.Password, "miniblog5678"))
+}
+
+func TestResetPassword(t *testing.T) {
+	b, alice, mr := newTestBiz(t)
+	m := mailer.NewMemory("noreply@example.com")
+	b.verifier = verification.New(b.store.Redis(context.Background()), m, verification.Options{})
+	ctx := context.Background()
+
+	// 邮件在后台发送
+	_, err := b.ForgotPassword(ctx, &v1.ForgotPasswordRequest{Email: alice.Email})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return m.Last() != nil }, 5*time.Second, 10*time.Millisecond)
+
+	var token string
+	for _, field := range strings.Fields(m.Last().Text) {
+		if u, err := url.Parse(field); err == nil && u.Query().Get("token") != "" {
+			token = u.Query().Get("token")
+		}
+	}
+	require.NotEmpty(t, token)
+
+	require.NoError(t, b.store.User().UpdateColumns(ctx, alice.UserID, map[string]any{"failed_login_attempts": 7, "phone": "13900139000"}))
+	_, err = b.ResetPassword(ctx, &v1.ResetPasswordRequest{Token: token, NewPassword: "miniblog5678"})
+	require.NoError(t, err)
+	assert.True(t, mr.Exists("miniblog:session:revoked:"+alice.UserID))
+
+	// 只更新密码相关的列，同时解除锁定并标记邮箱已验证
+	userM, err := b.store.User().Get(ctx, where.F("user_id", alice.UserID))
+	require.NoError(t, err)
+	assert.NoError(t, auth.Compare(userM.Password, "miniblog5678"))
+	assert.Equal(t, int32(0), *userM.FailedLoginAttempts)
+	assert.Equal(t, int32(1), *userM.EmailVerified)
+	assert.Equal(t, "13900139000", userM.Phone)
+}
@@ -0,0 +1,25 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package apiserver
+
+import (
+	"github.com/clin211/miniblog-v2/pkg/server"
+)
+
+// NewCombinedServer 创建单端口服务器，REST 请求由 Gin 处理，gRPC 与 gRPC-Web 请求由 gRPC 服务器处理.
+// 两种协议各自使用原有的中间件与拦截器链，认证、授权与校验行为保持一致.
+func (c *ServerConfig) NewCombinedServer() server.Server {
+	srv := server.NewCombinedServer(
+		c.cfg.HTTPOptions,
+		c.cfg.GRPCOptions,
+		c.cfg.TLSOptions,
+		c.grpcServerOptions(),
+		c.registerGRPCServer,
+		c.newGinEngine(),
+	)
+	srv.StartHealthChecks(healthServices, c.cfg.GRPCOptions.HealthCheckInterval, c.healthChecks()...)
+	return srv
+}
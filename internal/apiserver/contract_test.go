@@ -0,0 +1,570 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/glebarez/sqlite"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/biz"
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/session"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/validation"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	"github.com/clin211/miniblog-v2/pkg/auth"
+	"github.com/clin211/miniblog-v2/pkg/client"
+	"github.com/clin211/miniblog-v2/pkg/mailer"
+	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
+	"github.com/clin211/miniblog-v2/pkg/token"
+	"github.com/clin211/miniblog-v2/pkg/where"
+)
+
+// contractState 保存同一轮用例中前序请求返回的资源 ID.
+type contractState struct {
+	aliceID, bobID string
+	categoryID     string
+	tagID          string
+	postID         string
+	token          string
+	refreshToken   string
+	resetToken     string
+}
+
+// contractCase 为跨模式契约测试中的一个请求. as 为发起请求的用户，空表示匿名.
+type contractCase struct {
+	name string
+	as   string
+	call func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error)
+}
+
+const contractPassword = "miniblog1234"
+
+// contractCases 为各模式共享的请求表，按顺序执行，后续请求依赖前序请求创建的资源.
+var contractCases = []contractCase{
+	{name: "healthz", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.Healthz(ctx, &emptypb.Empty{})
+	}},
+	{name: "create user alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.CreateUser(ctx, &v1.CreateUserRequest{Username: "alice", Password: contractPassword, Email: "alice@example.com", Phone: proto.String("13800138000")})
+		s.aliceID = resp.GetUserID()
+		return resp, err
+	}},
+	{name: "create user bob", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.CreateUser(ctx, &v1.CreateUserRequest{Username: "bob", Password: contractPassword, Email: "bob@example.com", Phone: proto.String("13900139000")})
+		s.bobID = resp.GetUserID()
+		return resp, err
+	}},
+	{name: "create user with invalid email", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.CreateUser(ctx, &v1.CreateUserRequest{Username: "carol", Password: contractPassword, Email: "carol", Phone: proto.String("13700137000")})
+	}},
+	{name: "login with wrong password", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.Login(ctx, &v1.LoginRequest{Username: "alice", Password: "wrong-password1"})
+	}},
+	{name: "login", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.Login(ctx, &v1.LoginRequest{Username: "alice", Password: contractPassword})
+		s.token, s.refreshToken = resp.GetToken(), resp.GetRefreshToken()
+		return resp, err
+	}},
+	{name: "list posts without token", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ListPost(ctx, &v1.ListPostRequest{})
+	}},
+	{name: "refresh token", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.RefreshToken(ctx, &v1.RefreshTokenRequest{RefreshToken: s.refreshToken})
+	}},
+	{name: "reuse refresh token", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.RefreshToken(ctx, &v1.RefreshTokenRequest{RefreshToken: s.refreshToken})
+	}},
+	{name: "refresh without token", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.RefreshToken(ctx, &v1.RefreshTokenRequest{})
+	}},
+	{name: "logout", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.Logout(withContractToken(ctx, s.token), &v1.LogoutRequest{})
+	}},
+	{name: "get user after logout", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetUser(withContractToken(ctx, s.token), &v1.GetUserRequest{UserID: s.aliceID})
+	}},
+	{name: "revoke tokens as non-admin", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.RevokeUserTokens(ctx, &v1.RevokeUserTokensRequest{UserID: s.bobID})
+	}},
+	{name: "get user", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetUser(ctx, &v1.GetUserRequest{UserID: s.aliceID})
+	}},
+	{name: "update another user", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.UpdateUser(ctx, &v1.UpdateUserRequest{UserID: s.bobID, Age: proto.Int32(20)})
+	}},
+	{name: "create category", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.CreateCategory(ctx, &v1.CreateCategoryRequest{Name: "golang", Icon: "go", Theme: "blue", Description: proto.String("Go 语言")})
+		s.categoryID = resp.GetCategoryID()
+		return resp, err
+	}},
+	{name: "update category", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.UpdateCategory(ctx, &v1.UpdateCategoryRequest{CategoryID: s.categoryID, SortOrder: proto.Int32(2)})
+	}},
+	{name: "get category", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetCategory(ctx, &v1.GetCategoryRequest{CategoryID: s.categoryID})
+	}},
+	{name: "list categories", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ListCategory(ctx, &v1.ListCategoryRequest{})
+	}},
+	{name: "create tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.CreateTag(ctx, &v1.CreateTagRequest{Name: "grpc", Color: proto.String("#00add8")})
+		s.tagID = resp.GetTagID()
+		return resp, err
+	}},
+	{name: "create duplicate tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.CreateTag(ctx, &v1.CreateTagRequest{Name: "grpc"})
+	}},
+	{name: "update tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.UpdateTag(ctx, &v1.UpdateTagRequest{TagID: s.tagID, Color: proto.String("#ff0000")})
+	}},
+	{name: "get tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetTag(ctx, &v1.GetTagRequest{TagID: s.tagID})
+	}},
+	{name: "list tags", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ListTag(ctx, &v1.ListTagRequest{Limit: 10})
+	}},
+	{name: "publish post before verifying email", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.CreatePost(ctx, &v1.CreatePostRequest{
+			Title:      "Hello",
+			Content:    "hello world",
+			CategoryID: 1,
+			PostType:   v1.PostType_POST_TYPE_ORIGINAL,
+			Status:     v1.PostStatus_POST_STATUS_PUBLISHED,
+			Tags:       []int32{1},
+		})
+	}},
+	{name: "send verification email for another user", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.SendVerificationEmail(ctx, &v1.SendVerificationEmailRequest{UserID: s.bobID})
+	}},
+	{name: "send verification email", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.SendVerificationEmail(ctx, &v1.SendVerificationEmailRequest{UserID: s.aliceID})
+	}},
+	{name: "resend verification email too soon", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.SendVerificationEmail(ctx, &v1.SendVerificationEmailRequest{UserID: s.aliceID})
+	}},
+	{name: "confirm email with invalid token", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ConfirmEmailVerification(ctx, &v1.ConfirmEmailVerificationRequest{Token: "invalid"})
+	}},
+	{name: "confirm email", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ConfirmEmailVerification(ctx, &v1.ConfirmEmailVerificationRequest{Token: contractMailToken()})
+	}},
+	{name: "reuse email verification token", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ConfirmEmailVerification(ctx, &v1.ConfirmEmailVerificationRequest{Token: contractMailToken()})
+	}},
+	{name: "get user after verifying email", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetUser(ctx, &v1.GetUserRequest{UserID: s.aliceID})
+	}},
+	{name: "create post without title", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.CreatePost(ctx, &v1.CreatePostRequest{Content: "content"})
+	}},
+	{name: "create post", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		resp, err := c.CreatePost(ctx, &v1.CreatePostRequest{
+			Title:      "Hello",
+			Content:    "hello world",
+			Summary:    proto.String("hello"),
+			CategoryID: 1,
+			PostType:   v1.PostType_POST_TYPE_ORIGINAL,
+			Status:     v1.PostStatus_POST_STATUS_PUBLISHED,
+			Tags:       []int32{1},
+		})
+		s.postID = resp.GetPostID()
+		return resp, err
+	}},
+	{name: "update post", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.UpdatePost(ctx, &v1.UpdatePostRequest{PostID: s.postID, Title: proto.String("Hello, MiniBlog"), Tags: []int32{1}})
+	}},
+	{name: "create post tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.CreatePostTag(ctx, &v1.CreatePostTagRequest{PostID: s.postID, TagID: 1})
+	}},
+	{name: "list post tags", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.ListPostTags(ctx, &v1.ListPostTagsRequest{PostID: &s.postID})
+	}},
+	{name: "get post", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetPost(ctx, &v1.GetPostRequest{PostID: s.postID})
+	}},
+	{name: "list posts", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ListPost(ctx, &v1.ListPostRequest{Limit: 10})
+	}},
+	{name: "get post of another user", as: "bob", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetPost(ctx, &v1.GetPostRequest{PostID: s.postID})
+	}},
+	{name: "app list posts", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.AppPostList(ctx, &v1.ListPostRequest{Limit: 10})
+	}},
+	{name: "app get post", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.AppGetPost(ctx, &v1.GetPostRequest{PostID: s.postID})
+	}},
+	{name: "app batch get posts", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.BatchAppGetPosts(ctx, &v1.BatchGetPostsRequest{PostIDs: []string{s.postID}})
+	}},
+	{name: "app get missing post", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.AppGetPost(ctx, &v1.GetPostRequest{PostID: "post-notfound"})
+	}},
+	{name: "app list categories", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.AppListCategory(ctx, &v1.ListCategoryRequest{})
+	}},
+	{name: "app get category", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.AppGetCategory(ctx, &v1.GetCategoryRequest{CategoryID: s.categoryID})
+	}},
+	{name: "delete post tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.DeletePostTag(ctx, &v1.DeletePostTagRequest{PostID: s.postID, TagID: 1})
+	}},
+	{name: "delete post", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.DeletePost(ctx, &v1.DeletePostRequest{PostIDs: []string{s.postID}})
+	}},
+	{name: "get deleted post", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetPost(ctx, &v1.GetPostRequest{PostID: s.postID})
+	}},
+	{name: "delete tag", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.DeleteTag(ctx, &v1.DeleteTagRequest{TagID: s.tagID})
+	}},
+	{name: "delete category", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.DeleteCategory(ctx, &v1.DeleteCategoryRequest{CategoryID: s.categoryID})
+	}},
+	{name: "change password", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.ChangePassword(ctx, &v1.ChangePasswordRequest{UserID: s.aliceID, OldPassword: contractPassword, NewPassword: contractPassword})
+	}},
+	// 修改密码吊销了 alice 的全部令牌，客户端重新登录后请求成功
+	{name: "get user after change password", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetUser(ctx, &v1.GetUserRequest{UserID: s.aliceID})
+	}},
+	// 邮箱是否存在都返回相同的响应
+	{name: "forgot password with unknown email", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ForgotPassword(ctx, &v1.ForgotPasswordRequest{Email: "nobody@example.com"})
+	}},
+	{name: "forgot password", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		sent := len(contractMailer.Messages())
+		resp, err := c.ForgotPassword(ctx, &v1.ForgotPasswordRequest{Email: "alice@example.com"})
+		s.resetToken = waitContractMailToken(sent)
+		return resp, err
+	}},
+	{name: "reset password with weak password", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.ResetPassword(ctx, &v1.ResetPasswordRequest{Token: s.resetToken, NewPassword: "123456"})
+	}},
+	{name: "reset password with invalid token", call: func(ctx context.Context, c v1.MiniBlogClient, _ *contractState) (proto.Message, error) {
+		return c.ResetPassword(ctx, &v1.ResetPasswordRequest{Token: "invalid", NewPassword: contractPassword})
+	}},
+	{name: "reset password", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.ResetPassword(ctx, &v1.ResetPasswordRequest{Token: s.resetToken, NewPassword: contractPassword})
+	}},
+	{name: "reuse password reset token", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.ResetPassword(ctx, &v1.ResetPasswordRequest{Token: s.resetToken, NewPassword: contractPassword})
+	}},
+	// 重置密码吊销了 alice 的全部令牌，客户端重新登录后请求成功
+	{name: "get user after reset password", as: "alice", call: func(ctx context.Context, c v1.MiniBlogClient, s *contractState) (proto.Message, error) {
+		return c.GetUser(ctx, &v1.GetUserRequest{UserID: s.aliceID})
+	}},
+}
+
+// contractMailer 保存服务端发送的邮件.
+var contractMailer = mailer.NewMemory("noreply@example.com")
+
+// contractMailToken 返回最近一封邮件中链接携带的令牌.
+func contractMailToken() string {
+	msg := contractMailer.Last()
+	if msg == nil {
+		return ""
+	}
+	for _, field := range strings.Fields(msg.Text) {
+		if u, err := url.Parse(field); err == nil && u.Query().Has("token") {
+			return u.Query().Get("token")
+		}
+	}
+	return ""
+}
+
+// waitContractMailToken 等待后台发送的邮件，sent 为请求前已发送的邮件数.
+func waitContractMailToken(sent int) string {
+	for deadline := time.Now().Add(2 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if len(contractMailer.Messages()) > sent {
+			return contractMailToken()
+		}
+	}
+	return ""
+}
+
+// withContractToken 为匿名客户端的请求指定访问令牌.
+func withContractToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// contractTarget 为被测的服务模式与客户端传输方式.
+type contractTarget struct {
+	mode      string
+	transport string
+}
+
+var contractTargets = []contractTarget{
+	{GinServerMode, "rest"},
+	{GRPCGatewayServerMode, "rest"},
+	{GRPCGatewayServerMode, "grpc"},
+	{GRPCServerMode, "grpc"},
+	{CombinedServerMode, "rest"},
+	{CombinedServerMode, "grpc"},
+}
+
+// volatileFields 为每次请求都会变化的字段，比较前替换为占位符.
+var volatileFields = map[string]bool{
+	"token":             true,
+	"expireAt":          true,
+	"refreshToken":      true,
+	"refreshExpireAt":   true,
+	"timestamp":         true,
+	"createdAt":         true,
+	"updatedAt":         true,
+	"publishedAt":       true,
+	"lastLoginAt":       true,
+	"lastLoginDevice":   true,
+	"passwordUpdatedAt": true,
+}
+
+// TestContract 在各服务模式下依次回放 contractCases，断言状态码、错误与响应内容与 Gin 模式一致.
+// 响应统一解码为 proto 消息后以 protojson 比较，从而忽略 encoding/json 与 protojson 在 int64、零值上的编码差异.
+func TestContract(t *testing.T) {
+	cfg, db, authz, mr := newContractConfig(t)
+
+	var want []string
+	for _, target := range contractTargets {
+		t.Run(target.mode+"/"+target.transport, func(t *testing.T) {
+			resetContractDB(t, db, authz)
+			mr.FlushAll()
+			httpAddr, grpcAddr := startContractServer(t, cfg, target.mode)
+			addr := httpAddr
+			if target.transport == "grpc" {
+				addr = grpcAddr
+			}
+
+			got := replayContract(t, target.transport, addr)
+			if want == nil {
+				want = got
+				return
+			}
+			for i, c := range contractCases {
+				assert.JSONEq(t, want[i], got[i], c.name)
+			}
+		})
+	}
+}
+
+// newContractConfig 创建基于内存 SQLite 与 miniredis 的服务依赖，不依赖 MongoDB.
+func newContractConfig(t *testing.T) (*ServerConfig, *gorm.DB, *auth.Authz, *miniredis.Miniredis) {
+	db, err := gorm.Open(sqlite.Open("file:contract?mode=memory&cache=shared"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	// SQLite 的索引名在整个数据库内唯一，各模型在独立的数据库中迁移后复制建表语句，并以表名作为索引名前缀
+	for _, m := range []any{&model.UserM{}, &model.PostM{}, &model.CategoryM{}, &model.TagM{}, &model.UploadedFileM{}} {
+		scratch, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Discard})
+		require.NoError(t, err)
+		require.NoError(t, scratch.AutoMigrate(m))
+		var objects []struct{ Type, Name, TblName, SQL string }
+		require.NoError(t, scratch.Raw("SELECT type, name, tbl_name, sql FROM sqlite_master WHERE sql IS NOT NULL ORDER BY type DESC").Scan(&objects).Error)
+		for _, obj := range objects {
+			ddl := obj.SQL
+			if obj.Type == "index" {
+				ddl = strings.Replace(ddl, "`"+obj.Name+"`", "`"+obj.TblName+"_"+obj.Name+"`", 1)
+			}
+			require.NoError(t, db.Exec(ddl).Error)
+		}
+	}
+
+	// post_tag 模型未声明 MySQL 表中的自增 id 列，手动建表
+	require.NoError(t, db.Exec("CREATE TABLE post_tag (id INTEGER PRIMARY KEY, post_id TEXT NOT NULL, tag_id INTEGER NOT NULL, created_at DATETIME, updated_at DATETIME, deleted_at DATETIME)").Error)
+
+	log.Init(&log.Options{Level: "error", Format: "console", OutputPaths: []string{"stdout"}})
+	gin.SetMode(gin.TestMode)
+	where.RegisterTenant("user_id", contextx.UserID)
+	token.Init("Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", known.XUserID, time.Hour)
+
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	s := store.NewStore(db, nil, rdb)
+	authz, err := auth.NewAuthz(s.DB(context.Background()))
+	require.NoError(t, err)
+
+	sessions := session.New(rdb, time.Hour, time.Hour)
+	verifier := verification.New(rdb, contractMailer, verification.Options{Cooldown: time.Minute, RequireVerifiedEmail: true})
+	cfg := &Config{
+		HTTPOptions: genericoptions.NewHTTPOptions(),
+		GRPCOptions: genericoptions.NewGRPCOptions(),
+		TLSOptions:  genericoptions.NewTLSOptions(),
+	}
+	return &ServerConfig{
+		cfg:       cfg,
+		biz:       biz.NewBiz(s, authz, nil, nil, ProvidePostEvents(nil), sessions, verifier),
+		val:       validation.New(s),
+		retriever: &UserRetriever{store: s},
+		authz:     authz,
+		store:     s,
+		sessions:  sessions,
+	}, db, authz, mr
+}
+
+// resetContractDB 清空数据并重置自增 ID，使各模式生成相同的资源 ID.
+func resetContractDB(t *testing.T, db *gorm.DB, authz *auth.Authz) {
+	for _, table := range []string{"user", "post", "category", "tag", "post_tag", "uploaded_file", "casbin_rule"} {
+		require.NoError(t, db.Exec("DELETE FROM `"+table+"`").Error)
+	}
+	require.NoError(t, authz.LoadPolicy())
+}
+
+// startContractServer 以指定模式启动服务器，返回 REST 与 gRPC 客户端应连接的地址，测试结束时优雅关闭.
+func startContractServer(t *testing.T, c *ServerConfig, mode string) (string, string) {
+	cfg := *c.cfg
+	httpOptions, grpcOptions := *cfg.HTTPOptions, *cfg.GRPCOptions
+	httpOptions.Addr, grpcOptions.Addr = freeAddr(t), freeAddr(t)
+	cfg.ServerMode, cfg.HTTPOptions, cfg.GRPCOptions = mode, &httpOptions, &grpcOptions
+
+	sc := *c
+	sc.cfg = &cfg
+	srv, err := NewWebServer(mode, &sc)
+	require.NoError(t, err)
+	us := &UnionServer{srv: srv}
+	go us.srv.RunOrDie()
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		us.srv.GracefulStop(ctx)
+	})
+
+	httpAddr, grpcAddr := httpOptions.Addr, grpcOptions.Addr
+	if mode == CombinedServerMode {
+		grpcAddr = httpAddr
+	}
+	listening := map[string][]string{
+		GinServerMode:         {httpAddr},
+		GRPCServerMode:        {grpcAddr},
+		GRPCGatewayServerMode: {httpAddr, grpcAddr},
+		CombinedServerMode:    {httpAddr},
+	}
+	for _, addr := range listening[mode] {
+		require.Eventually(t, func() bool {
+			conn, err := net.Dial("tcp", addr)
+			if err != nil {
+				return false
+			}
+			_ = conn.Close()
+			return true
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+	return httpAddr, grpcAddr
+}
+
+// replayContract 依次执行 contractCases，返回每个请求规范化后的结果.
+func replayContract(t *testing.T, transport string, addr string) []string {
+	newClient := func(opts ...func(*client.Options)) *client.Client {
+		opts = append(opts, client.WithRetry(0, 0, 0))
+		var (
+			c   *client.Client
+			err error
+		)
+		if transport == "grpc" {
+			opts = append(opts, client.WithDialOptions(grpc.WithTransportCredentials(insecure.NewCredentials())))
+			c, err = client.NewGRPC(addr, opts...)
+		} else {
+			c, err = client.NewREST("http://"+addr, opts...)
+		}
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = c.Close() })
+		return c
+	}
+	clients := map[string]*client.Client{
+		"":      newClient(),
+		"alice": newClient(client.WithCredentials("alice", contractPassword)),
+		"bob":   newClient(client.WithCredentials("bob", contractPassword)),
+	}
+
+	var s contractState
+	results := make([]string, 0, len(contractCases))
+	for _, c := range contractCases {
+		resp, err := c.call(context.Background(), clients[c.as].MiniBlogClient, &s)
+		results = append(results, normalizeContractResult(t, resp, err))
+	}
+	return results
+}
+
+// normalizeContractResult 将响应或错误转换为可比较的 JSON.
+func normalizeContractResult(t *testing.T, resp proto.Message, err error) string {
+	result := map[string]any{"code": 200}
+	if err != nil {
+		errx := errno.FromError(err)
+		metadata := make(map[string]string)
+		for k, v := range errx.Metadata {
+			if k != "X-Request-ID" {
+				metadata[k] = v
+			}
+		}
+		result = map[string]any{"code": errx.Code, "reason": errx.Reason, "message": errx.Message, "metadata": metadata}
+	} else {
+		data, err := protojson.Marshal(resp)
+		require.NoError(t, err)
+		var body any
+		require.NoError(t, json.Unmarshal(data, &body))
+		result["body"] = scrubVolatile(body)
+	}
+
+	data, err := json.Marshal(result)
+	require.NoError(t, err)
+	return string(data)
+}
+
+// scrubVolatile 将 volatileFields 中的字段值替换为占位符.
+func scrubVolatile(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, value := range v {
+			if volatileFields[k] {
+				v[k] = "<volatile>"
+				continue
+			}
+			v[k] = scrubVolatile(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = scrubVolatile(value)
+		}
+	}
+	return v
+}
+
+// freeAddr 返回一个当前空闲的本地地址.
+func freeAddr(t *testing.T) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer lis.Close()
+	return lis.Addr().String()
+}
@@ -7,14 +7,18 @@ package apiserver
 
 import (
 	"context"
+	"strings"
 
 	genericvalidation "github.com/clin211/miniblog-v2/pkg/validation"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/selector"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 
 	handler "github.com/clin211/miniblog-v2/internal/apiserver/handler/grpc/system"
+	"github.com/clin211/miniblog-v2/internal/pkg/core"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
 	mw "github.com/clin211/miniblog-v2/internal/pkg/middleware/grpc"
 	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
 	"github.com/clin211/miniblog-v2/pkg/server"
@@ -38,39 +42,12 @@ var _ server.Server = (*grpcServer)(nil)
 //  2. 处理默认值或回退逻辑
 //  3. 表达灵活选项
 func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
-	// 配置 gRPC 服务器选项，包括拦截器链
-	serverOptions := []grpc.ServerOption{
-		// 注意拦截器顺序！
-		grpc.ChainUnaryInterceptor(
-			// 请求 ID 拦截器
-			mw.RequestIDInterceptor(),
-			// 访问日志拦截器
-			mw.AccessLogger(),
-			// Bypass 拦截器，通过所有请求的认证
-			mw.AuthnBypasswInterceptor(),
-			// 授权拦截器
-			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzWhiteListMatcher()),
-			// 请求默认值设置拦截器
-			mw.DefaulterInterceptor(),
-			// 数据校验拦截器
-			mw.ValidatorInterceptor(genericvalidation.NewValidator(c.val)),
-			// 认证拦截器
-			selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever), NewAuthnWhiteListMatcher()),
-		),
-	}
-
 	// 创建 gRPC 服务器
-	grpcsrv, err := server.NewGRPCServer(
-		c.cfg.GRPCOptions,
-		serverOptions,
-		c.cfg.TLSOptions,
-		func(s grpc.ServiceRegistrar) {
-			v1.RegisterMiniBlogServer(s, handler.NewHandler(c.biz))
-		},
-	)
+	grpcsrv, err := server.NewGRPCServer(c.cfg.GRPCOptions, c.grpcServerOptions(), c.cfg.TLSOptions, c.registerGRPCServer)
 	if err != nil {
 		return nil, err
 	}
+	grpcsrv.StartHealthChecks(healthServices, c.cfg.GRPCOptions.HealthCheckInterval, c.healthChecks()...)
 
 	if c.cfg.ServerMode == GRPCServerMode {
 		return &grpcServer{
@@ -91,6 +68,8 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 		func(mux *runtime.ServeMux, conn *grpc.ClientConn) error {
 			return v1.RegisterMiniBlogHandler(context.Background(), mux, conn)
 		},
+		// 请求 ID 与错误响应格式与 Gin 模式保持一致
+		core.GatewayServeMuxOptions()...,
 	)
 	if err != nil {
 		return nil, err
@@ -105,6 +84,84 @@ func (c *ServerConfig) NewGRPCServerOr() (server.Server, error) {
 	}, nil
 }
 
+// grpcServerOptions 返回 gRPC 服务器选项，包括一元与流式拦截器链.
+func (c *ServerConfig) grpcServerOptions() []grpc.ServerOption {
+	// 认证拦截器：开发模式下信任 x-user-id 元数据，否则校验 JWT Token
+	authn := selector.UnaryServerInterceptor(mw.AuthnInterceptor(c.retriever, c.sessions), NewAuthnWhiteListMatcher())
+	streamAuthn := selector.StreamServerInterceptor(mw.AuthnStreamInterceptor(c.retriever, c.sessions), NewAuthnWhiteListMatcher())
+	if c.cfg.AuthnBypass {
+		log.Warnw("Authentication is bypassed for gRPC requests, never enable authn-bypass in production")
+		authn = mw.AuthnBypasswInterceptor()
+		streamAuthn = mw.AuthnBypassStreamInterceptor()
+	}
+	validator := genericvalidation.NewValidator(c.val)
+
+	// 配置 gRPC 服务器选项，包括拦截器链
+	return []grpc.ServerOption{
+		// 注意拦截器顺序！
+		grpc.ChainUnaryInterceptor(
+			// 请求 ID 拦截器
+			mw.RequestIDInterceptor(),
+			// 客户端 IP 与地理位置拦截器
+			mw.ClientIPInterceptor(c.clientIP, c.geo),
+			// 访问日志拦截器
+			mw.AccessLogger(),
+			// 认证拦截器
+			authn,
+			// 授权拦截器
+			selector.UnaryServerInterceptor(mw.AuthzInterceptor(c.authz), NewAuthzWhiteListMatcher()),
+			// 请求默认值设置拦截器
+			mw.DefaulterInterceptor(),
+			// 数据校验拦截器
+			mw.ValidatorInterceptor(validator),
+		),
+		// 流式拦截器链，顺序与一元拦截器链保持一致
+		grpc.ChainStreamInterceptor(
+			mw.RequestIDStreamInterceptor(),
+			mw.ClientIPStreamInterceptor(c.clientIP, c.geo),
+			mw.AccessLoggerStream(),
+			streamAuthn,
+			selector.StreamServerInterceptor(mw.AuthzStreamInterceptor(c.authz), NewAuthzWhiteListMatcher()),
+			mw.DefaulterStreamInterceptor(),
+			mw.ValidatorStreamInterceptor(validator),
+		),
+	}
+}
+
+// registerGRPCServer 注册 MiniBlog gRPC 服务.
+func (c *ServerConfig) registerGRPCServer(s grpc.ServiceRegistrar) {
+	v1.RegisterMiniBlogServer(s, handler.NewHandler(c.biz))
+}
+
+// healthServices 为 grpc.health.v1 中跟随整体状态的服务名.
+// "MiniBlog" 为早期版本注册的服务名，保留以兼容已按该名称配置的探针.
+var healthServices = []string{v1.MiniBlog_ServiceDesc.ServiceName, "MiniBlog"}
+
+// healthChecks 返回 grpc.health.v1 依赖检查，依次 ping MySQL、Redis 与 MongoDB. 未配置的依赖不参与检查.
+func (c *ServerConfig) healthChecks() []server.HealthCheck {
+	ctx := context.Background()
+	checks := []server.HealthCheck{
+		{Name: "mysql", Check: func(ctx context.Context) error {
+			sqlDB, err := c.store.DB(ctx).DB()
+			if err != nil {
+				return err
+			}
+			return sqlDB.PingContext(ctx)
+		}},
+	}
+	if c.store.Redis(ctx) != nil {
+		checks = append(checks, server.HealthCheck{Name: "redis", Check: func(ctx context.Context) error {
+			return c.store.Redis(ctx).Ping(ctx).Err()
+		}})
+	}
+	if c.store.MongoDB(ctx) != nil {
+		checks = append(checks, server.HealthCheck{Name: "mongodb", Check: func(ctx context.Context) error {
+			return c.store.MongoDB(ctx).Ping(ctx, nil)
+		}})
+	}
+	return checks
+}
+
 // RunOrDie 启动 gRPC 服务器或 HTTP 反向代理服务器，异常时退出.
 func (s *grpcServer) RunOrDie() {
 	s.srv.RunOrDie()
@@ -115,28 +172,39 @@ func (s *grpcServer) GracefulStop(ctx context.Context) {
 	s.stop(ctx)
 }
 
-// NewAuthnWhiteListMatcher 创建认证白名单匹配器.
-func NewAuthnWhiteListMatcher() selector.Matcher {
-	whitelist := map[string]struct{}{
-		v1.MiniBlog_Healthz_FullMethodName:    {},
-		v1.MiniBlog_CreateUser_FullMethodName: {},
-		v1.MiniBlog_Login_FullMethodName:      {},
+// methodAccess 为 MiniBlog 服务各方法在 proto 中通过 (v1.access) 声明的访问级别，以完整方法名为键.
+var methodAccess = func() map[string]v1.Access {
+	sd := v1.File_apiserver_v1_apiserver_proto.Services().ByName("MiniBlog")
+	methods := sd.Methods()
+	access := make(map[string]v1.Access, methods.Len())
+	for i := range methods.Len() {
+		md := methods.Get(i)
+		access["/"+string(sd.FullName())+"/"+string(md.Name())] = proto.GetExtension(md.Options(), v1.E_Access).(v1.Access)
 	}
+	return access
+}()
+
+// accessOf 返回方法的访问级别. pkg/server 注册的健康检查与反射服务无需认证，其余未声明的方法按 ACCESS_PRIVATE 处理.
+func accessOf(fullMethod string) v1.Access {
+	if access, ok := methodAccess[fullMethod]; ok {
+		return access
+	}
+	if strings.HasPrefix(fullMethod, "/grpc.health.v1.") || strings.HasPrefix(fullMethod, "/grpc.reflection.") {
+		return v1.Access_ACCESS_PUBLIC
+	}
+	return v1.Access_ACCESS_PRIVATE
+}
+
+// NewAuthnWhiteListMatcher 创建认证白名单匹配器，声明为 ACCESS_PUBLIC 的方法无需认证.
+func NewAuthnWhiteListMatcher() selector.Matcher {
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
-		_, ok := whitelist[call.FullMethod()]
-		return !ok
+		return accessOf(call.FullMethod()) != v1.Access_ACCESS_PUBLIC
 	})
 }
 
-// NewAuthzWhiteListMatcher 创建授权白名单匹配器.
+// NewAuthzWhiteListMatcher 创建授权白名单匹配器，只有 ACCESS_PRIVATE（默认）的方法需要授权.
 func NewAuthzWhiteListMatcher() selector.Matcher {
-	whitelist := map[string]struct{}{
-		v1.MiniBlog_Healthz_FullMethodName:    {},
-		v1.MiniBlog_CreateUser_FullMethodName: {},
-		v1.MiniBlog_Login_FullMethodName:      {},
-	}
 	return selector.MatchFunc(func(ctx context.Context, call interceptors.CallMeta) bool {
-		_, ok := whitelist[call.FullMethod()]
-		return !ok
+		return accessOf(call.FullMethod()) == v1.Access_ACCESS_PRIVATE
 	})
 }
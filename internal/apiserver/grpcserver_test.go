@@ -0,0 +1,41 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package apiserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors"
+	"github.com/stretchr/testify/assert"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+func TestWhiteListMatcher(t *testing.T) {
+	authn, authz := NewAuthnWhiteListMatcher(), NewAuthzWhiteListMatcher()
+
+	tests := []struct {
+		method    string
+		wantAuthn bool
+		wantAuthz bool
+	}{
+		{v1.MiniBlog_Healthz_FullMethodName, false, false},
+		{v1.MiniBlog_Login_FullMethodName, false, false},
+		{v1.MiniBlog_AppGetPost_FullMethodName, false, false},
+		{v1.MiniBlog_RefreshToken_FullMethodName, false, false},
+		{v1.MiniBlog_CreatePost_FullMethodName, true, true},
+		{v1.MiniBlog_WatchPosts_FullMethodName, true, true},
+		{"/grpc.health.v1.Health/Check", false, false},
+		// 未声明的方法需要认证与授权
+		{"/unknown.Service/Method", true, true},
+	}
+	for _, tt := range tests {
+		call := interceptors.NewServerCallMeta(tt.method, nil, nil)
+		assert.Equal(t, tt.wantAuthn, authn.Match(context.Background(), call), tt.method)
+		assert.Equal(t, tt.wantAuthz, authz.Match(context.Background(), call), tt.method)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// AppPostList 列出前台可见的文章，无需认证.
+func (h *Handler) AppPostList(ctx context.Context, rq *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	return h.biz.PostV1().AppList(ctx, rq)
+}
+
+// AppGetPost 获取前台可见的文章详情，无需认证.
+func (h *Handler) AppGetPost(ctx context.Context, rq *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	return h.biz.PostV1().AppGet(ctx, rq)
+}
+
+// BatchAppGetPosts 批量获取前台可见的文章，无需认证.
+func (h *Handler) BatchAppGetPosts(ctx context.Context, rq *v1.BatchGetPostsRequest) (*v1.BatchGetPostsResponse, error) {
+	return h.biz.PostV1().AppBatchGet(ctx, rq)
+}
+
+// AppGetCategory 获取分类详情，无需认证.
+func (h *Handler) AppGetCategory(ctx context.Context, rq *v1.GetCategoryRequest) (*v1.GetCategoryResponse, error) {
+	return h.biz.CategoryV1().Get(ctx, rq)
+}
+
+// AppListCategory 列出前台可见的分类，无需认证.
+func (h *Handler) AppListCategory(ctx context.Context, rq *v1.ListCategoryRequest) (*v1.ListCategoryResponse, error) {
+	return h.biz.CategoryV1().AppList(ctx, rq)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// CreateCategory 创建分类.
+func (h *Handler) CreateCategory(ctx context.Context, rq *v1.CreateCategoryRequest) (*v1.CreateCategoryResponse, error) {
+	return h.biz.CategoryV1().Create(ctx, rq)
+}
+
+// UpdateCategory 更新分类.
+func (h *Handler) UpdateCategory(ctx context.Context, rq *v1.UpdateCategoryRequest) (*v1.UpdateCategoryResponse, error) {
+	return h.biz.CategoryV1().Update(ctx, rq)
+}
+
+// DeleteCategory 删除分类.
+func (h *Handler) DeleteCategory(ctx context.Context, rq *v1.DeleteCategoryRequest) (*v1.DeleteCategoryResponse, error) {
+	return h.biz.CategoryV1().Delete(ctx, rq)
+}
+
+// GetCategory 获取分类详情.
+func (h *Handler) GetCategory(ctx context.Context, rq *v1.GetCategoryRequest) (*v1.GetCategoryResponse, error) {
+	return h.biz.CategoryV1().Get(ctx, rq)
+}
+
+// ListCategory 列出分类.
+func (h *Handler) ListCategory(ctx context.Context, rq *v1.ListCategoryRequest) (*v1.ListCategoryResponse, error) {
+	return h.biz.CategoryV1().List(ctx, rq)
+}
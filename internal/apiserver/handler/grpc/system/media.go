@@ -0,0 +1,27 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// ListMedia 列出媒体库文件.
+func (h *Handler) ListMedia(ctx context.Context, rq *v1.ListMediaRequest) (*v1.ListMediaResponse, error) {
+	return h.biz.MediaV1().List(ctx, rq)
+}
+
+// GetMedia 获取媒体文件详情及其引用方.
+func (h *Handler) GetMedia(ctx context.Context, rq *v1.GetMediaRequest) (*v1.GetMediaResponse, error) {
+	return h.biz.MediaV1().Get(ctx, rq)
+}
+
+// DeleteMedia 删除媒体文件.
+func (h *Handler) DeleteMedia(ctx context.Context, rq *v1.DeleteMediaRequest) (*v1.DeleteMediaResponse, error) {
+	return h.biz.MediaV1().Delete(ctx, rq)
+}
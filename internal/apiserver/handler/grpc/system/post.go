@@ -0,0 +1,42 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// CreatePost 创建文章.
+func (h *Handler) CreatePost(ctx context.Context, rq *v1.CreatePostRequest) (*v1.CreatePostResponse, error) {
+	return h.biz.PostV1().Create(ctx, rq)
+}
+
+// UpdatePost 更新文章.
+func (h *Handler) UpdatePost(ctx context.Context, rq *v1.UpdatePostRequest) (*v1.UpdatePostResponse, error) {
+	return h.biz.PostV1().Update(ctx, rq)
+}
+
+// DeletePost 批量删除文章.
+func (h *Handler) DeletePost(ctx context.Context, rq *v1.DeletePostRequest) (*v1.DeletePostResponse, error) {
+	return h.biz.PostV1().Delete(ctx, rq)
+}
+
+// GetPost 获取文章详情.
+func (h *Handler) GetPost(ctx context.Context, rq *v1.GetPostRequest) (*v1.GetPostResponse, error) {
+	return h.biz.PostV1().Get(ctx, rq)
+}
+
+// ListPost 列出文章.
+func (h *Handler) ListPost(ctx context.Context, rq *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	return h.biz.PostV1().List(ctx, rq)
+}
+
+// WatchPosts 订阅文章变更事件，连接保持期间持续推送事件.
+func (h *Handler) WatchPosts(rq *v1.WatchPostsRequest, stream v1.MiniBlog_WatchPostsServer) error {
+	return h.biz.PostV1().Watch(stream.Context(), rq, stream.Send)
+}
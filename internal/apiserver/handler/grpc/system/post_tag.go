@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// CreatePostTag 为文章关联标签.
+func (h *Handler) CreatePostTag(ctx context.Context, rq *v1.CreatePostTagRequest) (*v1.CreatePostTagResponse, error) {
+	return h.biz.PostTagV1().Create(ctx, rq)
+}
+
+// DeletePostTag 删除文章与标签的关联.
+func (h *Handler) DeletePostTag(ctx context.Context, rq *v1.DeletePostTagRequest) (*v1.DeletePostTagResponse, error) {
+	return h.biz.PostTagV1().Delete(ctx, rq)
+}
+
+// ListPostTags 列出文章标签关联.
+func (h *Handler) ListPostTags(ctx context.Context, rq *v1.ListPostTagsRequest) (*v1.ListPostTagsResponse, error) {
+	return h.biz.PostTagV1().List(ctx, rq)
+}
+
+// BatchCreatePostTags 为文章批量关联标签.
+func (h *Handler) BatchCreatePostTags(ctx context.Context, rq *v1.BatchCreatePostTagsRequest) (*v1.BatchCreatePostTagsResponse, error) {
+	return h.biz.PostTagV1().BatchCreate(ctx, rq)
+}
+
+// BatchDeletePostTags 批量删除文章与标签的关联.
+func (h *Handler) BatchDeletePostTags(ctx context.Context, rq *v1.BatchDeletePostTagsRequest) (*v1.BatchDeletePostTagsResponse, error) {
+	return h.biz.PostTagV1().BatchDelete(ctx, rq)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// CreateTag 创建标签.
+func (h *Handler) CreateTag(ctx context.Context, rq *v1.CreateTagRequest) (*v1.CreateTagResponse, error) {
+	return h.biz.TagV1().Create(ctx, rq)
+}
+
+// UpdateTag 更新标签.
+func (h *Handler) UpdateTag(ctx context.Context, rq *v1.UpdateTagRequest) (*v1.UpdateTagResponse, error) {
+	return h.biz.TagV1().Update(ctx, rq)
+}
+
+// DeleteTag 删除标签.
+func (h *Handler) DeleteTag(ctx context.Context, rq *v1.DeleteTagRequest) (*v1.DeleteTagResponse, error) {
+	return h.biz.TagV1().Delete(ctx, rq)
+}
+
+// GetTag 获取标签详情.
+func (h *Handler) GetTag(ctx context.Context, rq *v1.GetTagRequest) (*v1.GetTagResponse, error) {
+	return h.biz.TagV1().Get(ctx, rq)
+}
+
+// ListTag 列出标签.
+func (h *Handler) ListTag(ctx context.Context, rq *v1.ListTagRequest) (*v1.ListTagResponse, error) {
+	return h.biz.TagV1().List(ctx, rq)
+}
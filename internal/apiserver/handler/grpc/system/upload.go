@@ -0,0 +1,50 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"bytes"
+	"context"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// UploadFile 单文件上传，文件内容通过 file 字段传递.
+// 文件大小以实际接收的字节数为准，单条消息的大小受 gRPC 最大接收消息大小限制，大文件请使用分片上传.
+func (h *Handler) UploadFile(ctx context.Context, rq *v1.UploadFileRequest) (*v1.UploadedObject, error) {
+	rq.Size = int64(len(rq.GetFile()))
+	return h.biz.UploadV1().Upload(ctx, rq, bytes.NewReader(rq.GetFile()))
+}
+
+// InitMultipart 初始化分片上传会话.
+func (h *Handler) InitMultipart(ctx context.Context, rq *v1.InitMultipartRequest) (*v1.InitMultipartResponse, error) {
+	return h.biz.UploadV1().InitMultipart(ctx, rq)
+}
+
+// PresignParts 为直传模式的分片生成预签名 URL.
+func (h *Handler) PresignParts(ctx context.Context, rq *v1.PresignPartsRequest) (*v1.PresignPartsResponse, error) {
+	return h.biz.UploadV1().PresignParts(ctx, rq)
+}
+
+// UploadPart 代理上传单个分片，分片内容通过 content 字段传递.
+func (h *Handler) UploadPart(ctx context.Context, rq *v1.UploadPartRequest) (*v1.UploadPartResponse, error) {
+	return h.biz.UploadV1().UploadPart(ctx, rq, bytes.NewReader(rq.GetContent()))
+}
+
+// ListParts 查询会话中已上传的分片.
+func (h *Handler) ListParts(ctx context.Context, rq *v1.ListPartsRequest) (*v1.ListPartsResponse, error) {
+	return h.biz.UploadV1().ListParts(ctx, rq)
+}
+
+// CompleteMultipart 合并分片并返回最终对象信息.
+func (h *Handler) CompleteMultipart(ctx context.Context, rq *v1.CompleteMultipartRequest) (*v1.CompleteMultipartResponse, error) {
+	return h.biz.UploadV1().CompleteMultipart(ctx, rq)
+}
+
+// AbortMultipart 中止分片上传并清理已上传分片.
+func (h *Handler) AbortMultipart(ctx context.Context, rq *v1.AbortMultipartRequest) (*v1.AbortMultipartResponse, error) {
+	return h.biz.UploadV1().AbortMultipart(ctx, rq)
+}
@@ -21,6 +21,11 @@ func (h *Handler) RefreshToken(ctx context.Context, rq *v1.RefreshTokenRequest)
 	return h.biz.UserV1().RefreshToken(ctx, rq)
 }
 
+// Logout 退出登录.
+func (h *Handler) Logout(ctx context.Context, rq *v1.LogoutRequest) (*v1.LogoutResponse, error) {
+	return h.biz.UserV1().Logout(ctx, rq)
+}
+
 // ChangePassword 修改用户密码.
 func (h *Handler) ChangePassword(ctx context.Context, rq *v1.ChangePasswordRequest) (*v1.ChangePasswordResponse, error) {
 	return h.biz.UserV1().ChangePassword(ctx, rq)
@@ -41,6 +46,31 @@ func (h *Handler) DeleteUser(ctx context.Context, rq *v1.DeleteUserRequest) (*v1
 	return h.biz.UserV1().Delete(ctx, rq)
 }
 
+// RevokeUserTokens 吊销用户的全部令牌.
+func (h *Handler) RevokeUserTokens(ctx context.Context, rq *v1.RevokeUserTokensRequest) (*v1.RevokeUserTokensResponse, error) {
+	return h.biz.UserV1().RevokeTokens(ctx, rq)
+}
+
+// SendVerificationEmail 发送邮箱验证邮件.
+func (h *Handler) SendVerificationEmail(ctx context.Context, rq *v1.SendVerificationEmailRequest) (*v1.SendVerificationEmailResponse, error) {
+	return h.biz.UserV1().SendVerificationEmail(ctx, rq)
+}
+
+// ConfirmEmailVerification 确认邮箱验证.
+func (h *Handler) ConfirmEmailVerification(ctx context.Context, rq *v1.ConfirmEmailVerificationRequest) (*v1.ConfirmEmailVerificationResponse, error) {
+	return h.biz.UserV1().ConfirmEmailVerification(ctx, rq)
+}
+
+// ForgotPassword 发送重置密码邮件.
+func (h *Handler) ForgotPassword(ctx context.Context, rq *v1.ForgotPasswordRequest) (*v1.ForgotPasswordResponse, error) {
+	return h.biz.UserV1().ForgotPassword(ctx, rq)
+}
+
+// ResetPassword 使用重置密码令牌设置新密码.
+func (h *Handler) ResetPassword(ctx context.Context, rq *v1.ResetPasswordRequest) (*v1.ResetPasswordResponse, error) {
+	return h.biz.UserV1().ResetPassword(ctx, rq)
+}
+
 // GetUser 获取用户信息.
 func (h *Handler) GetUser(ctx context.Context, rq *v1.GetUserRequest) (*v1.GetUserResponse, error) {
 	return h.biz.UserV1().Get(ctx, rq)
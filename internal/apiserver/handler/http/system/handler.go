@@ -7,6 +7,7 @@ package system
 
 import (
 	"github.com/clin211/miniblog-v2/internal/apiserver/biz"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/tus"
 	uploadsvc "github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
 	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/validation"
 )
@@ -16,13 +17,16 @@ type Handler struct {
 	biz biz.IBiz
 	val *validation.Validator
 	upl uploadsvc.Uploader
+	// tus 为可续传上传的状态存储，未启用时为 nil
+	tus *tus.Store
 }
 
 // NewHandler 创建新的 Handler 实例.
-func NewHandler(biz biz.IBiz, val *validation.Validator, upl uploadsvc.Uploader) *Handler {
+func NewHandler(biz biz.IBiz, val *validation.Validator, upl uploadsvc.Uploader, tus *tus.Store) *Handler {
 	return &Handler{
 		biz: biz,
 		val: val,
 		upl: upl,
+		tus: tus,
 	}
 }
@@ -0,0 +1,33 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/core"
+)
+
+// ListMedia 列出媒体库文件，支持按 scene 与 mime（如 image/*）过滤.
+func (h *Handler) ListMedia(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.MediaV1().List, h.val.ValidateListMediaRequest)
+}
+
+// GetMedia 获取媒体文件详情及其引用方.
+func (h *Handler) GetMedia(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.MediaV1().Get, h.val.ValidateGetMediaRequest)
+}
+
+// DeleteMedia 删除媒体文件；文件仍被引用时需指定 force=true.
+func (h *Handler) DeleteMedia(c *gin.Context) {
+	binder := func(obj any) error {
+		if err := c.ShouldBindUri(obj); err != nil {
+			return err
+		}
+		return c.ShouldBindQuery(obj)
+	}
+	core.HandleRequest(c, binder, h.biz.MediaV1().Delete, h.val.ValidateDeleteMediaRequest)
+}
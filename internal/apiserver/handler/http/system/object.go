@@ -0,0 +1,44 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package system
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/core"
+)
+
+// ServeObject 在私有模式下提供本地存储对象的下载，替代公开的静态目录。
+// 私有对象需要有效的签名 URL；支持 Range 与 ETag/Last-Modified 条件请求，disposition=attachment 可强制下载。
+func (h *Handler) ServeObject(c *gin.Context) {
+	obj, err := h.biz.UploadV1().OpenObject(c.Request.Context(), c.Param("filepath"), c.Request.URL.Query())
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	defer obj.File.Close()
+
+	header := c.Writer.Header()
+	// 覆盖全局 NoCache 中间件设置的缓存头
+	header.Del("Expires")
+	if obj.Private {
+		header.Set("Cache-Control", "private, max-age=0, must-revalidate")
+	} else {
+		header.Set("Cache-Control", "public, max-age=3600")
+	}
+	header.Set("ETag", fmt.Sprintf(`"%x-%x"`, obj.ModTime.UnixNano(), obj.Size))
+	if obj.MIME != "" {
+		header.Set("Content-Type", obj.MIME)
+	}
+	header.Set("Content-Disposition", obj.Disposition)
+	// 对象与 API 同源，禁止其中的脚本执行
+	header.Set("Content-Security-Policy", "default-src 'none'; img-src 'self' data:; style-src 'unsafe-inline'; sandbox")
+
+	http.ServeContent(c.Writer, c.Request, "", obj.ModTime, obj.File)
+}
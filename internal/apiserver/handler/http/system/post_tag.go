@@ -0,0 +1,37 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package system
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/core"
+)
+
+// CreatePostTag 为文章关联标签.
+func (h *Handler) CreatePostTag(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PostTagV1().Create, h.val.ValidateCreatePostTagRequest)
+}
+
+// DeletePostTag 删除文章与标签的关联.
+func (h *Handler) DeletePostTag(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PostTagV1().Delete, h.val.ValidateDeletePostTagRequest)
+}
+
+// ListPostTags 列出文章标签关联.
+func (h *Handler) ListPostTags(c *gin.Context) {
+	core.HandleQueryRequest(c, h.biz.PostTagV1().List, h.val.ValidateListPostTagsRequest)
+}
+
+// BatchCreatePostTags 为文章批量关联标签.
+func (h *Handler) BatchCreatePostTags(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PostTagV1().BatchCreate, h.val.ValidateBatchCreatePostTagsRequest)
+}
+
+// BatchDeletePostTags 批量删除文章与标签的关联.
+func (h *Handler) BatchDeletePostTags(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.PostTagV1().BatchDelete, h.val.ValidateBatchDeletePostTagsRequest)
+}
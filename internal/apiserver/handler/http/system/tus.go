@@ -0,0 +1,260 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package system
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/tus"
+	uploadsvc "github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
+	"github.com/clin211/miniblog-v2/internal/pkg/core"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+const (
+	// tusVersion 为支持的 tus 协议版本.
+	tusVersion = "1.0.0"
+	// tusExtensions 为支持的 tus 扩展.
+	tusExtensions = "creation,creation-with-upload,termination,expiration"
+	// tusContentType 为续传数据的 Content-Type.
+	tusContentType = "application/offset+octet-stream"
+	// tusExposedHeaders 为浏览器中的 tus 客户端需要读取的响应头.
+	tusExposedHeaders = "Location, Tus-Resumable, Tus-Version, Tus-Extension, Tus-Max-Size, Upload-Offset, Upload-Length, Upload-Metadata, Upload-Expires"
+)
+
+// TusOptions 返回服务端支持的 tus 版本、扩展与最大上传大小。
+func (h *Handler) TusOptions(c *gin.Context) {
+	h.tusHeaders(c)
+	c.Header("Tus-Version", tusVersion)
+	c.Header("Tus-Extension", tusExtensions)
+	if size := h.maxUploadSize(); size > 0 {
+		c.Header("Tus-Max-Size", strconv.FormatInt(size, 10))
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TusCreate 创建可续传上传（creation 扩展）。
+// Upload-Metadata 支持 filename、filetype 与 scene，请求体为 application/offset+octet-stream 时同时写入首段数据。
+func (h *Handler) TusCreate(c *gin.Context) {
+	if !h.tusPrecondition(c) {
+		return
+	}
+
+	size, err := strconv.ParseInt(c.GetHeader("Upload-Length"), 10, 64)
+	if err != nil || size < 0 {
+		core.WriteResponse(c, nil, errno.ErrInvalidArgument.WithMessage("Upload-Length must be a non-negative integer"))
+		return
+	}
+	if limit := h.maxUploadSize(); limit > 0 && size > limit {
+		core.WriteResponse(c, nil, errno.ErrFileTooLarge)
+		return
+	}
+	metadata, err := parseTusMetadata(c.GetHeader("Upload-Metadata"))
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	ctx := c.Request.Context()
+	if err := h.val.ValidateUploadFileRequest(ctx, tusUploadRequest(size, metadata)); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	if err := h.biz.UploadV1().CheckUpload(ctx, size); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+
+	u, err := h.tus.Create(ctx, size, metadata)
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	c.Header("Location", strings.TrimSuffix(c.Request.URL.Path, "/")+"/"+u.ID)
+	c.Header("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+
+	// creation-with-upload：创建请求携带首段数据，长度为 0 的上传在创建时即完成
+	if c.ContentType() == tusContentType || size == 0 {
+		if u, err = h.tus.Write(ctx, u.ID, 0, c.Request.Body, h.finishTus); err != nil {
+			core.WriteResponse(c, nil, err)
+			return
+		}
+		c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	}
+	c.Status(http.StatusCreated)
+}
+
+// TusHead 查询可续传上传的偏移量，客户端据此从断点继续上传。
+func (h *Handler) TusHead(c *gin.Context) {
+	if !h.tusPrecondition(c) {
+		return
+	}
+
+	u, err := h.tus.Get(c.Request.Context(), c.Param("uploadID"))
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.Header("Upload-Length", strconv.FormatInt(u.Size, 10))
+	c.Header("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	if len(u.Metadata) > 0 {
+		c.Header("Upload-Metadata", formatTusMetadata(u.Metadata))
+	}
+	c.Status(http.StatusOK)
+}
+
+// TusPatch 从 Upload-Offset 开始追加数据。接收完全部字节后，文件按 scene 校验并交给 Uploader，
+// 与单文件上传共用配额、内容扫描与去重流程；处理失败时上传被删除，客户端需要重新创建。
+func (h *Handler) TusPatch(c *gin.Context) {
+	if !h.tusPrecondition(c) {
+		return
+	}
+	if c.ContentType() != tusContentType {
+		core.WriteResponse(c, nil, errno.ErrUploadContentType)
+		return
+	}
+	offset, err := strconv.ParseInt(c.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		core.WriteResponse(c, nil, errno.ErrInvalidArgument.WithMessage("Upload-Offset must be a non-negative integer"))
+		return
+	}
+
+	u, err := h.tus.Write(c.Request.Context(), c.Param("uploadID"), offset, c.Request.Body, h.finishTus)
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	c.Header("Upload-Offset", strconv.FormatInt(u.Offset, 10))
+	c.Header("Upload-Expires", u.ExpiresAt.UTC().Format(http.TimeFormat))
+	c.Status(http.StatusNoContent)
+}
+
+// TusDelete 终止并删除可续传上传（termination 扩展）。
+func (h *Handler) TusDelete(c *gin.Context) {
+	if !h.tusPrecondition(c) {
+		return
+	}
+
+	if err := h.tus.Delete(c.Request.Context(), c.Param("uploadID")); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// TusGet 返回已完成上传写入存储后的对象信息，供客户端获取文件 URL。
+func (h *Handler) TusGet(c *gin.Context) {
+	h.tusHeaders(c)
+
+	u, err := h.tus.Get(c.Request.Context(), c.Param("uploadID"))
+	if err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
+	if !u.Completed() {
+		core.WriteResponse(c, nil, errno.ErrUploadIncomplete)
+		return
+	}
+	core.WriteResponse(c, u.Object, nil)
+}
+
+// finishTus 将接收完成的文件交给 Uploader 并创建上传记录.
+func (h *Handler) finishTus(ctx context.Context, u *tus.Upload, r io.Reader) (*v1.UploadedObject, error) {
+	return h.biz.UploadV1().Upload(ctx, tusUploadRequest(u.Size, u.Metadata), r)
+}
+
+// tusPrecondition 设置通用响应头并校验 Tus-Resumable，版本不受支持时返回 412.
+func (h *Handler) tusPrecondition(c *gin.Context) bool {
+	h.tusHeaders(c)
+	if c.GetHeader("Tus-Resumable") != tusVersion {
+		c.Header("Tus-Version", tusVersion)
+		core.WriteResponse(c, nil, errno.ErrTusVersionUnsupported)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) tusHeaders(c *gin.Context) {
+	c.Header("Tus-Resumable", tusVersion)
+	c.Header("Access-Control-Expose-Headers", tusExposedHeaders)
+}
+
+// maxUploadSize 返回所有场景中最大的大小限制，0 表示不限制.
+func (h *Handler) maxUploadSize() int64 {
+	if limiter, ok := h.upl.(uploadsvc.SizeLimiter); ok {
+		return limiter.MaxUploadSize()
+	}
+	return 0
+}
+
+// tusUploadRequest 将 Upload-Metadata 转换为单文件上传请求，兼容 tus 客户端常用的 name/type 键.
+func tusUploadRequest(size int64, metadata map[string]string) *v1.UploadFileRequest {
+	return &v1.UploadFileRequest{
+		Scene:    metadata["scene"],
+		Filename: firstNonEmpty(metadata["filename"], metadata["name"]),
+		Mime:     firstNonEmpty(metadata["filetype"], metadata["type"]),
+		Size:     size,
+	}
+}
+
+// parseTusMetadata 解析 Upload-Metadata：以逗号分隔的 "key base64(value)"，value 可省略.
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		key, encoded, _ := strings.Cut(strings.TrimSpace(pair), " ")
+		if key == "" {
+			return nil, errno.ErrInvalidArgument.WithMessage("Upload-Metadata contains an empty key")
+		}
+		value, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, errno.ErrInvalidArgument.WithMessage("Upload-Metadata value of %s is not valid base64", key)
+		}
+		metadata[key] = string(value)
+	}
+	return metadata, nil
+}
+
+// formatTusMetadata 按键排序编码 Upload-Metadata.
+func formatTusMetadata(metadata map[string]string) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if metadata[key] == "" {
+			pairs = append(pairs, key)
+			continue
+		}
+		pairs = append(pairs, key+" "+base64.StdEncoding.EncodeToString([]byte(metadata[key])))
+	}
+	return strings.Join(pairs, ",")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
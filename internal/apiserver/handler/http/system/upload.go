@@ -6,16 +6,39 @@
 package system
 
 import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	uploadsvc "github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
 	"github.com/clin211/miniblog-v2/internal/pkg/core"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
 	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
-	"github.com/gin-gonic/gin"
 )
 
+// multipartFormOverhead 为 multipart/form-data 中表单字段与分隔符预留的字节数.
+const multipartFormOverhead = 1 << 20
+
 // UploadFile 单文件上传。
+// 文件大小与类型由 Uploader 按 scene 校验，类型以内容嗅探结果为准。
 func (h *Handler) UploadFile(c *gin.Context) {
+	// 在解析表单前限制请求体大小，超限请求尽早中止，避免整体落盘
+	if limiter, ok := h.upl.(uploadsvc.SizeLimiter); ok && limiter.MaxUploadSize() > 0 {
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limiter.MaxUploadSize()+multipartFormOverhead)
+	}
+
+	var rq v1.UploadFileRequest
+	if err := core.ShouldBindForm(c, &rq, h.val.ValidateUploadFileRequest); err != nil {
+		core.WriteResponse(c, nil, uploadError(err))
+		return
+	}
+
 	file, err := c.FormFile("file")
 	if err != nil {
-		core.WriteResponse(c, nil, err)
+		core.WriteResponse(c, nil, uploadError(err))
 		return
 	}
 	fh, err := file.Open()
@@ -25,48 +48,88 @@ func (h *Handler) UploadFile(c *gin.Context) {
 	}
 	defer fh.Close()
 
-	var mime string
-	if file.Header != nil {
-		mime = file.Header.Get("Content-Type")
+	// 未显式指定时，以表单文件的文件名、类型与大小作为声明值
+	if rq.GetFilename() == "" {
+		rq.Filename = file.Filename
+	}
+	if rq.GetMime() == "" && file.Header != nil {
+		rq.Mime = file.Header.Get("Content-Type")
 	}
-	obj, err := h.upl.Upload(c.Request.Context(), file.Filename, mime, fh)
+	rq.Size = file.Size
+
+	obj, err := h.biz.UploadV1().Upload(c.Request.Context(), &rq, fh)
 	if err != nil {
 		core.WriteResponse(c, nil, err)
 		return
 	}
-	core.WriteResponse(c, &v1.UploadedObject{
-		Provider: obj.Provider,
-		Key:      obj.Key,
-		Url:      obj.URL,
-		Size:     obj.Size,
-		Mime:     obj.MIME,
-		Hash:     obj.Hash,
-		Metadata: obj.Metadata,
-	}, nil)
+	core.WriteResponse(c, obj, nil)
 }
 
-// InitMultipart 初始化分片上传（占位）。
+// uploadError 将请求体超限错误转换为 ErrFileTooLarge，其余错误原样返回.
+func uploadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) || strings.Contains(err.Error(), "http: request body too large") {
+		return errno.ErrFileTooLarge
+	}
+	return err
+}
+
+// InitMultipart 初始化分片上传会话。
+// 声明的 sha256 命中已上传的文件时返回 duplicated=true 与已存在的对象信息，客户端无需再上传。
 func (h *Handler) InitMultipart(c *gin.Context) {
-	core.WriteResponse(c, &v1.InitMultipartResponse{}, nil)
+	core.HandleJSONRequest(c, h.biz.UploadV1().InitMultipart, h.val.ValidateInitMultipartRequest)
 }
 
-// PresignParts 预签名（占位）。
+// PresignParts 为直传模式的分片生成预签名 URL。
 func (h *Handler) PresignParts(c *gin.Context) {
-	core.WriteResponse(c, &v1.PresignPartsResponse{}, nil)
+	core.HandleJSONRequest(c, h.biz.UploadV1().PresignParts, h.val.ValidatePresignPartsRequest)
 }
 
-// UploadPart 上传分片（占位）。
-func (h *Handler) UploadPart(c *gin.Context) { core.WriteResponse(c, &v1.UploadPartResponse{}, nil) }
+// UploadPart 代理上传单个分片。
+// uploadID、partNumber、checksum 通过 Query 传递；分片内容既可以是 multipart/form-data 的 file 字段，
+// 也可以直接作为请求体（application/octet-stream）。checksum 缺省时回退到 Content-MD5 头。
+func (h *Handler) UploadPart(c *gin.Context) {
+	var rq v1.UploadPartRequest
+	if err := core.ShouldBindQuery(c, &rq, h.val.ValidateUploadPartRequest); err != nil {
+		core.WriteResponse(c, nil, err)
+		return
+	}
 
-// ListParts 查询分片（占位）。
-func (h *Handler) ListParts(c *gin.Context) { core.WriteResponse(c, &v1.ListPartsResponse{}, nil) }
+	body := io.Reader(c.Request.Body)
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		file, err := c.FormFile("file")
+		if err != nil {
+			core.WriteResponse(c, nil, errno.ErrBind.WithMessage("%s", err.Error()))
+			return
+		}
+		fh, err := file.Open()
+		if err != nil {
+			core.WriteResponse(c, nil, err)
+			return
+		}
+		defer fh.Close()
+		body = fh
+	}
+
+	if rq.GetChecksum() == "" && c.GetHeader("Content-MD5") != "" {
+		rq.Checksum = "md5:" + c.GetHeader("Content-MD5")
+	}
+
+	resp, err := h.biz.UploadV1().UploadPart(c.Request.Context(), &rq, body)
+	core.WriteResponse(c, resp, err)
+}
+
+// ListParts 查询会话中已上传的分片。
+func (h *Handler) ListParts(c *gin.Context) {
+	core.HandleUriRequest(c, h.biz.UploadV1().ListParts, h.val.ValidateListPartsRequest)
+}
 
-// CompleteMultipart 完成分片（占位）。
+// CompleteMultipart 合并分片并返回最终对象信息。
 func (h *Handler) CompleteMultipart(c *gin.Context) {
-	core.WriteResponse(c, &v1.CompleteMultipartResponse{}, nil)
+	core.HandleJSONRequest(c, h.biz.UploadV1().CompleteMultipart, h.val.ValidateCompleteMultipartRequest)
 }
 
-// AbortMultipart 中止分片（占位）。
+// AbortMultipart 中止分片上传并清理已上传分片。
 func (h *Handler) AbortMultipart(c *gin.Context) {
-	core.WriteResponse(c, &v1.AbortMultipartResponse{}, nil)
+	core.HandleJSONRequest(c, h.biz.UploadV1().AbortMultipart, h.val.ValidateAbortMultipartRequest)
 }
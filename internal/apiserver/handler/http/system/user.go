@@ -18,7 +18,12 @@ func (h *Handler) Login(c *gin.Context) {
 
 // RefreshToken 刷新 JWT Token.
 func (h *Handler) RefreshToken(c *gin.Context) {
-	core.HandleJSONRequest(c, h.biz.UserV1().RefreshToken)
+	core.HandleJSONRequest(c, h.biz.UserV1().RefreshToken, h.val.ValidateRefreshTokenRequest)
+}
+
+// Logout 退出登录并吊销当前令牌.
+func (h *Handler) Logout(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().Logout, h.val.ValidateLogoutRequest)
 }
 
 // ChangeUserPassword 修改用户密码.
@@ -41,6 +46,31 @@ func (h *Handler) DeleteUser(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.UserV1().Delete, h.val.ValidateDeleteUserRequest)
 }
 
+// RevokeUserTokens 吊销用户的全部令牌.
+func (h *Handler) RevokeUserTokens(c *gin.Context) {
+	core.HandleJSONWithURIRequest(c, h.biz.UserV1().RevokeTokens, h.val.ValidateRevokeUserTokensRequest)
+}
+
+// SendVerificationEmail 发送邮箱验证邮件.
+func (h *Handler) SendVerificationEmail(c *gin.Context) {
+	core.HandleJSONWithURIRequest(c, h.biz.UserV1().SendVerificationEmail, h.val.ValidateSendVerificationEmailRequest)
+}
+
+// ConfirmEmailVerification 确认邮箱验证.
+func (h *Handler) ConfirmEmailVerification(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ConfirmEmailVerification, h.val.ValidateConfirmEmailVerificationRequest)
+}
+
+// ForgotPassword 发送重置密码邮件.
+func (h *Handler) ForgotPassword(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ForgotPassword, h.val.ValidateForgotPasswordRequest)
+}
+
+// ResetPassword 使用重置密码令牌设置新密码.
+func (h *Handler) ResetPassword(c *gin.Context) {
+	core.HandleJSONRequest(c, h.biz.UserV1().ResetPassword, h.val.ValidateResetPasswordRequest)
+}
+
 // GetUser 获取用户信息.
 func (h *Handler) GetUser(c *gin.Context) {
 	core.HandleUriRequest(c, h.biz.UserV1().Get, h.val.ValidateGetUserRequest)
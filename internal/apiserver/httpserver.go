@@ -7,8 +7,8 @@ package apiserver
 
 import (
 	"context"
-	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gin-contrib/pprof"
 	"github.com/gin-gonic/gin"
@@ -32,18 +32,23 @@ var _ server.Server = (*ginServer)(nil)
 
 // NewGinServer 初始化一个新的 Gin 服务器实例.
 func (c *ServerConfig) NewGinServer() server.Server {
+	httpsrv := server.NewHTTPServer(c.cfg.HTTPOptions, c.cfg.TLSOptions, c.newGinEngine())
+
+	return &ginServer{srv: httpsrv}
+}
+
+// newGinEngine 创建注册了全局中间件与 REST API 路由的 Gin 引擎.
+func (c *ServerConfig) newGinEngine() *gin.Engine {
 	// 创建 Gin 引擎
 	engine := gin.New()
 
 	// 注册全局中间件，用于恢复 panic、设置 HTTP 头、添加请求 ID 等
-	engine.Use(gin.Recovery(), mw.RequestIDMiddleware(), mw.AccessLogger(), mw.NoCache, mw.Cors, mw.Secure)
+	engine.Use(gin.Recovery(), mw.RequestIDMiddleware(), mw.ClientIPMiddleware(c.clientIP, c.geo), mw.AccessLogger(), mw.NoCache, mw.Cors, mw.Secure)
 
 	// 注册 REST API 路由
 	c.InstallRESTAPI(engine)
 
-	httpsrv := server.NewHTTPServer(c.cfg.HTTPOptions, c.cfg.TLSOptions, engine)
-
-	return &ginServer{srv: httpsrv}
+	return engine
 }
 
 // 安装/注册 API 路由。路由的路径和 HTTP 方法，严格遵循 REST 规范.
@@ -51,7 +56,12 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 	// 注册业务无关的 API 接口
 	InstallGenericAPI(engine)
 
-	// 本地上传静态目录映射（优先使用 BasePath；兼容 BaseURL 的 path）
+	// 创建核心业务处理器
+	sys := systemHandler.NewHandler(c.biz, c.val, c.upl, c.tus)
+	app := appHandler.NewHandler(c.biz, c.val)
+
+	// 本地上传目录映射（优先使用 BasePath；兼容 BaseURL 的 path）
+	// 存在私有对象时由服务端校验签名后提供下载，否则直接作为静态目录公开
 	if c.cfg.UploadOptions != nil && c.cfg.UploadOptions.Provider == "local" && c.cfg.UploadOptions.Local != nil {
 		if c.cfg.UploadOptions.Local.BaseDir != "" {
 			path := c.cfg.UploadOptions.Local.BasePath
@@ -64,19 +74,25 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 			if path == "" {
 				path = "/static/uploads"
 			}
-			engine.StaticFS(path, http.Dir(c.cfg.UploadOptions.Local.BaseDir))
+			if srv, ok := c.upl.(uploader.ObjectServer); ok && srv.PrivateMode() {
+				objects := strings.TrimRight(path, "/") + "/*filepath"
+				engine.GET(objects, sys.ServeObject)
+				engine.HEAD(objects, sys.ServeObject)
+			} else {
+				engine.StaticFS(path, uploader.StaticDir(c.cfg.UploadOptions.Local.BaseDir))
+			}
 		}
 	}
 
-	// 创建核心业务处理器
-	upl := uploader.NewLocalOrFromConfig(c.cfg.UploadOptions)
-	sys := systemHandler.NewHandler(c.biz, c.val, upl)
-	app := appHandler.NewHandler(c.biz, c.val)
-
 	// 注册健康检查接口
 	engine.GET("/healthz", sys.Healthz)
 
-	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.retriever), mw.AuthzMiddleware(c.authz)}
+	authMiddlewares := []gin.HandlerFunc{mw.AuthnMiddleware(c.retriever, c.sessions), mw.AuthzMiddleware(c.authz)}
+
+	// tus 协议发现请求不携带认证信息
+	if c.tus != nil {
+		engine.OPTIONS("/v1/system/upload/tus", sys.TusOptions)
+	}
 
 	// 注册 v1 版本 API 路由分组
 	sysv1 := engine.Group("/v1/system")
@@ -86,7 +102,11 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 		authentication := sysv1.Group("/auth")
 		{
 			authentication.POST("/login", sys.Login)
-			authentication.PUT("/refresh-token", mw.AuthnMiddleware(c.retriever), sys.RefreshToken)
+			authentication.PUT("/refresh-token", sys.RefreshToken) // 由刷新令牌完成认证
+			authentication.POST("/logout", mw.AuthnMiddleware(c.retriever, c.sessions), mw.AuthzMiddleware(c.authz), sys.Logout)
+			authentication.POST("/confirm-email", sys.ConfirmEmailVerification) // 由验证令牌完成认证
+			authentication.POST("/forgot-password", sys.ForgotPassword)
+			authentication.POST("/reset-password", sys.ResetPassword) // 由重置令牌完成认证
 		}
 
 		// 用户相关路由
@@ -95,11 +115,13 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 			// 创建用户。这里要注意：创建用户是不用进行认证和授权的
 			user.POST("", sys.CreateUser)
 			user.Use(authMiddlewares...)
-			user.PUT(":userID/change-password", sys.ChangePassword) // 修改用户密码
-			user.PUT(":userID", sys.UpdateUser)                     // 更新用户信息
-			user.DELETE(":userID", sys.DeleteUser)                  // 删除用户
-			user.GET(":userID", sys.GetUser)                        // 查询用户详情
-			user.GET("", sys.ListUser)                              // 查询用户列表.
+			user.PUT(":userID/change-password", sys.ChangePassword)                 // 修改用户密码
+			user.POST(":userID/revoke-tokens", sys.RevokeUserTokens)                // 吊销用户的全部令牌
+			user.POST(":userID/send-verification-email", sys.SendVerificationEmail) // 发送邮箱验证邮件
+			user.PUT(":userID", sys.UpdateUser)                                     // 更新用户信息
+			user.DELETE(":userID", sys.DeleteUser)                                  // 删除用户
+			user.GET(":userID", sys.GetUser)                                        // 查询用户详情
+			user.GET("", sys.ListUser)                                              // 查询用户列表.
 		}
 
 		// 博客相关路由
@@ -122,6 +144,16 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 			tag.GET("", sys.ListTag)            // 查询标签列表
 		}
 
+		// 文章标签关联相关路由
+		postTag := sysv1.Group("/post-tags", authMiddlewares...)
+		{
+			postTag.POST("", sys.CreatePostTag)              // 为文章关联标签
+			postTag.DELETE("", sys.DeletePostTag)            // 删除文章标签关联
+			postTag.GET("", sys.ListPostTags)                // 查询文章标签关联列表
+			postTag.POST("batch", sys.BatchCreatePostTags)   // 批量关联标签
+			postTag.DELETE("batch", sys.BatchDeletePostTags) // 批量删除标签关联
+		}
+
 		// 分类相关路由
 		category := sysv1.Group("/categories", authMiddlewares...)
 		{
@@ -149,9 +181,26 @@ func (c *ServerConfig) InstallRESTAPI(engine *gin.Engine) {
 			upload.POST("/multipart/init", sys.InitMultipart)
 			upload.POST("/multipart/presign", sys.PresignParts)
 			upload.PUT("/multipart/part", sys.UploadPart)
-			upload.GET("/multipart/:uploadId/parts", sys.ListParts)
+			upload.GET("/multipart/:uploadID/parts", sys.ListParts)
 			upload.POST("/multipart/complete", sys.CompleteMultipart)
 			upload.DELETE("/multipart/abort", sys.AbortMultipart)
+
+			// tus 1.0 可续传上传
+			if c.tus != nil {
+				upload.POST("/tus", sys.TusCreate)             // 创建上传
+				upload.HEAD("/tus/:uploadID", sys.TusHead)     // 查询偏移量
+				upload.PATCH("/tus/:uploadID", sys.TusPatch)   // 追加数据
+				upload.DELETE("/tus/:uploadID", sys.TusDelete) // 终止上传
+				upload.GET("/tus/:uploadID", sys.TusGet)       // 查询完成后的对象
+			}
+		}
+
+		// 媒体库相关路由
+		media := sysv1.Group("/media", authMiddlewares...)
+		{
+			media.GET("", sys.ListMedia)             // 查询媒体文件列表
+			media.GET(":fileID", sys.GetMedia)       // 查询媒体文件详情与引用
+			media.DELETE(":fileID", sys.DeleteMedia) // 删除媒体文件
 		}
 	}
 
@@ -45,3 +45,9 @@ func (m *TagM) AfterCreate(tx *gorm.DB) error {
 	m.TagID = rid.TagID.New(uint64(m.ID))
 	return tx.Save(m).Error
 }
+
+// AfterCreate 在创建数据库记录之后生成 fileID
+func (m *UploadedFileM) AfterCreate(tx *gorm.DB) error {
+	m.FileID = rid.FileID.New(uint64(m.ID))
+	return tx.Save(m).Error
+}
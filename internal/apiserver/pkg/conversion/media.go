@@ -0,0 +1,38 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package conversion
+
+import (
+	"encoding/json"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// UploadedFileModelToMediaFileV1 将模型层的 UploadedFileM（上传文件记录）转换为 Protobuf 层的 MediaFile.
+func UploadedFileModelToMediaFileV1(fileModel *model.UploadedFileM) *v1.MediaFile {
+	metadata := map[string]string{}
+	if fileModel.ImageMetadata != nil {
+		_ = json.Unmarshal([]byte(*fileModel.ImageMetadata), &metadata)
+	}
+	file := &v1.MediaFile{
+		FileID:   fileModel.FileID,
+		UserID:   fileModel.UserID,
+		Provider: fileModel.Provider,
+		Key:      fileModel.ObjectKey,
+		Url:      fileModel.URL,
+		Size:     fileModel.Size,
+		Mime:     fileModel.MIME,
+		Hash:     fileModel.SHA256,
+		Scene:    fileModel.Scene,
+		Filename: fileModel.Filename,
+		Metadata: metadata,
+	}
+	if fileModel.CreatedAt != nil {
+		file.CreatedAt = fileModel.CreatedAt.Unix()
+	}
+	return file
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package conversion
+
+import (
+	"github.com/clin211/miniblog-v2/pkg/copier"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// PostTagModelToPostTagV1 将模型层的 PostTagM（文章标签关联模型对象）转换为 Protobuf 层的 PostTag（v1 文章标签关联对象）.
+func PostTagModelToPostTagV1(postTagModel *model.PostTagM) *v1.PostTag {
+	var protoPostTag v1.PostTag
+	_ = copier.CopyWithConverters(&protoPostTag, postTagModel)
+	return &protoPostTag
+}
@@ -0,0 +1,38 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package conversion
+
+import (
+	"encoding/json"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// UploadedFileModelToUploadedObjectV1 将模型层的 UploadedFileM（上传文件记录）转换为 Protobuf 层的 UploadedObject.
+func UploadedFileModelToUploadedObjectV1(fileModel *model.UploadedFileM) *v1.UploadedObject {
+	metadata := map[string]string{}
+	// 图片尺寸与衍生图 URL
+	if fileModel.ImageMetadata != nil {
+		_ = json.Unmarshal([]byte(*fileModel.ImageMetadata), &metadata)
+	}
+	if fileModel.Scene != "" {
+		metadata["scene"] = fileModel.Scene
+	}
+	if fileModel.Filename != "" {
+		metadata["filename"] = fileModel.Filename
+	}
+	return &v1.UploadedObject{
+		Provider: fileModel.Provider,
+		Key:      fileModel.ObjectKey,
+		Url:      fileModel.URL,
+		Size:     fileModel.Size,
+		Mime:     fileModel.MIME,
+		Hash:     fileModel.SHA256,
+		Metadata: metadata,
+		FileID:   fileModel.FileID,
+	}
+}
@@ -20,6 +20,10 @@ func UserModelToUserV1(userModel *model.UserM) *v1.User {
 
 	var protoUser v1.User
 	_ = copier.CopyWithConverters(&protoUser, userModel)
+	// 数据库中以 1/0 表示的标志位无法由 copier 转换
+	protoUser.EmailVerified = flag(userModel.EmailVerified)
+	protoUser.PhoneVerified = flag(userModel.PhoneVerified)
+	protoUser.IsRisk = flag(userModel.IsRisk)
 	return &protoUser
 }
 
@@ -33,3 +37,8 @@ func UserV1ToUserModel(protoUser *v1.User) *model.UserM {
 	_ = copier.CopyWithConverters(&userModel, protoUser)
 	return &userModel
 }
+
+// flag 将数据库中以 1/0 表示的标志位转换为布尔值.
+func flag(v *int32) bool {
+	return v != nil && *v == 1
+}
@@ -0,0 +1,142 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package pubsub 提供发布订阅，用于将业务事件推送给流式订阅者.
+// 默认只在进程内分发；配置 Redis 后经 Redis 发布订阅在多个副本之间转发.
+package pubsub
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// defaultBuffer 为每个订阅者的默认缓冲区大小.
+const defaultBuffer = 64
+
+// Broker 将发布的消息分发给所有匹配的订阅者. nil Broker 上的 Publish 为空操作.
+// 未调用 WithRedis 时只分发给本进程的订阅者，多副本部署时订阅者收不到其他副本发布的消息.
+//
+// Publish 不会阻塞：订阅者的缓冲区已满时该订阅被关闭并标记为落后（Lagged），
+// 由订阅者决定是重新订阅并补齐数据，还是直接返回错误，避免消息被静默丢弃.
+type Broker[T any] struct {
+	mu     sync.RWMutex
+	subs   map[*Subscription[T]]struct{}
+	buffer int
+
+	// relay 不为 nil 时消息经 Redis 转发，所有副本（包括自身）收到后再分发给本地订阅者
+	relay *redisRelay[T]
+}
+
+// Subscription 为一个订阅，调用方必须在不再需要时调用 Close.
+type Subscription[T any] struct {
+	broker *Broker[T]
+	ch     chan T
+	filter func(T) bool
+	lagged atomic.Bool
+	once   sync.Once
+}
+
+// NewBroker 创建 Broker，buffer 为每个订阅者的缓冲区大小，不大于 0 时使用默认值.
+func NewBroker[T any](buffer int) *Broker[T] {
+	if buffer <= 0 {
+		buffer = defaultBuffer
+	}
+	return &Broker[T]{subs: make(map[*Subscription[T]]struct{}), buffer: buffer}
+}
+
+// Subscribe 创建订阅，filter 为 nil 时接收全部消息.
+func (b *Broker[T]) Subscribe(filter func(T) bool) *Subscription[T] {
+	sub := &Subscription[T]{broker: b, ch: make(chan T, b.buffer), filter: filter}
+
+	b.mu.Lock()
+	b.subs[sub] = struct{}{}
+	b.mu.Unlock()
+	return sub
+}
+
+// Publish 将消息发送给所有匹配的订阅者.
+func (b *Broker[T]) Publish(msg T) {
+	if b == nil {
+		return
+	}
+	// 转发失败时退化为只分发给本地订阅者
+	if b.relay != nil && b.relay.publish(msg) {
+		return
+	}
+	b.deliver(msg)
+}
+
+// Active 返回是否可能存在订阅者：本地有订阅者，或消息经 Redis 转发给其他副本.
+// 发布方可据此跳过构造消息的开销.
+func (b *Broker[T]) Active() bool {
+	return b != nil && (b.relay != nil || b.Len() > 0)
+}
+
+// deliver 将消息分发给本地匹配的订阅者.
+func (b *Broker[T]) deliver(msg T) {
+	var lagged []*Subscription[T]
+	b.mu.RLock()
+	for sub := range b.subs {
+		if sub.filter != nil && !sub.filter(msg) {
+			continue
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+			lagged = append(lagged, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range lagged {
+		sub.lag()
+	}
+}
+
+// lagAll 关闭全部本地订阅并标记为落后，用于与 Redis 的连接中断后可能丢失了消息的场景.
+func (b *Broker[T]) lagAll() {
+	b.mu.RLock()
+	subs := make([]*Subscription[T], 0, len(b.subs))
+	for sub := range b.subs {
+		subs = append(subs, sub)
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range subs {
+		sub.lag()
+	}
+}
+
+// Len 返回当前的订阅者数量.
+func (b *Broker[T]) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subs)
+}
+
+// C 返回接收消息的 channel，订阅关闭后 channel 被关闭.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Lagged 返回订阅是否因缓冲区已满而被关闭.
+func (s *Subscription[T]) Lagged() bool {
+	return s.lagged.Load()
+}
+
+func (s *Subscription[T]) lag() {
+	s.lagged.Store(true)
+	s.Close()
+}
+
+// Close 取消订阅，可重复调用.
+func (s *Subscription[T]) Close() {
+	s.once.Do(func() {
+		s.broker.mu.Lock()
+		delete(s.broker.subs, s)
+		close(s.ch)
+		s.broker.mu.Unlock()
+	})
+}
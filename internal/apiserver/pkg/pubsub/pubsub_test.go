@@ -0,0 +1,44 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroker(t *testing.T) {
+	b := NewBroker[int](2)
+	all := b.Subscribe(nil)
+	even := b.Subscribe(func(v int) bool { return v%2 == 0 })
+	defer all.Close()
+	defer even.Close()
+
+	b.Publish(1)
+	b.Publish(2)
+	assert.Equal(t, 1, <-all.C())
+	assert.Equal(t, 2, <-all.C())
+	assert.Equal(t, 2, <-even.C())
+
+	// 缓冲区已满的订阅被关闭并标记为落后，其余订阅不受影响
+	b.Publish(4)
+	b.Publish(6)
+	b.Publish(8)
+	assert.True(t, even.Lagged())
+	assert.True(t, all.Lagged())
+	assert.Equal(t, 0, b.Len())
+
+	sub := b.Subscribe(nil)
+	sub.Close()
+	sub.Close()
+	_, ok := <-sub.C()
+	assert.False(t, ok)
+	assert.False(t, sub.Lagged())
+
+	var nilBroker *Broker[int]
+	nilBroker.Publish(1)
+}
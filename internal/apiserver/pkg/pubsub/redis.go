@@ -0,0 +1,102 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package pubsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+)
+
+// publishTimeout 为发布一条消息到 Redis 的超时时间.
+const publishTimeout = 3 * time.Second
+
+// Codec 负责消息与 Redis 中传输的字节之间的转换.
+type Codec[T any] struct {
+	Marshal   func(T) ([]byte, error)
+	Unmarshal func([]byte) (T, error)
+}
+
+// redisRelay 通过 Redis 发布订阅在多个副本之间转发消息.
+type redisRelay[T any] struct {
+	rdb     *redis.Client
+	channel string
+	codec   Codec[T]
+}
+
+// WithRedis 使 Broker 经 Redis 的 channel 转发消息，多副本部署时每个副本的订阅者都能收到全部消息.
+// rdb 为 nil 时不做任何事. 需要在服务启动时调用 Start 接收其他副本发布的消息.
+func (b *Broker[T]) WithRedis(rdb *redis.Client, channel string, codec Codec[T]) *Broker[T] {
+	if rdb != nil {
+		b.relay = &redisRelay[T]{rdb: rdb, channel: channel, codec: codec}
+	}
+	return b
+}
+
+// Start 启动后台协程，接收 Redis 中的消息并分发给本地订阅者，ctx 取消后停止.
+// 与 Redis 的连接中断后重新订阅时，中断期间的消息可能已丢失，此时关闭全部本地订阅并标记为落后，由订阅者重新订阅并补齐数据.
+// 未调用 WithRedis 时不做任何事.
+func (b *Broker[T]) Start(ctx context.Context) {
+	if b == nil || b.relay == nil {
+		return
+	}
+
+	ps := b.relay.rdb.Subscribe(ctx, b.relay.channel)
+	go func() {
+		defer ps.Close()
+
+		subscribed := false
+		ch := ps.ChannelWithSubscriptions()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-ch:
+				if !ok {
+					return
+				}
+				switch msg := msg.(type) {
+				case *redis.Subscription:
+					if msg.Kind != "subscribe" {
+						continue
+					}
+					if subscribed {
+						log.Warnw("Resubscribed to redis channel, closing lagged subscribers", "channel", b.relay.channel)
+						b.lagAll()
+					}
+					subscribed = true
+				case *redis.Message:
+					v, err := b.relay.codec.Unmarshal([]byte(msg.Payload))
+					if err != nil {
+						log.Errorw("Failed to decode message from redis", "channel", b.relay.channel, "err", err)
+						continue
+					}
+					b.deliver(v)
+				}
+			}
+		}
+	}()
+}
+
+// publish 将消息发布到 Redis，成功时返回 true.
+func (r *redisRelay[T]) publish(msg T) bool {
+	data, err := r.codec.Marshal(msg)
+	if err != nil {
+		log.Errorw("Failed to encode message for redis", "channel", r.channel, "err", err)
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := r.rdb.Publish(ctx, r.channel, data).Err(); err != nil {
+		log.Errorw("Failed to publish message to redis", "channel", r.channel, "err", err)
+		return false
+	}
+	return true
+}
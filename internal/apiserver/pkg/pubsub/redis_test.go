@@ -0,0 +1,63 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package pubsub
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var intCodec = Codec[int]{
+	Marshal:   func(v int) ([]byte, error) { return []byte(strconv.Itoa(v)), nil },
+	Unmarshal: func(data []byte) (int, error) { return strconv.Atoi(string(data)) },
+}
+
+func TestBrokerWithRedis(t *testing.T) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// 两个副本共用同一个 Redis
+	a := NewBroker[int](0).WithRedis(rdb, "events", intCodec)
+	b := NewBroker[int](0).WithRedis(rdb, "events", intCodec)
+	a.Start(ctx)
+	b.Start(ctx)
+	require.Eventually(t, func() bool { return mr.PubSubNumSub("events")["events"] == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.True(t, a.Active())
+
+	subA, subB := a.Subscribe(nil), b.Subscribe(nil)
+	defer subA.Close()
+	defer subB.Close()
+
+	// 任一副本发布的消息，所有副本（包括自身）的订阅者都只收到一次
+	a.Publish(1)
+	b.Publish(2)
+	for _, sub := range []*Subscription[int]{subA, subB} {
+		assert.Equal(t, 1, <-sub.C())
+		assert.Equal(t, 2, <-sub.C())
+	}
+	assert.Empty(t, subA.C())
+
+	// 与 Redis 的连接中断后可能丢失消息，重新订阅时关闭本地订阅并标记为落后
+	mr.Close()
+	require.NoError(t, mr.Restart())
+	assert.Eventually(t, func() bool { return subA.Lagged() && subB.Lagged() }, 5*time.Second, 10*time.Millisecond)
+
+	// 未配置 Redis 时只在进程内分发
+	local := NewBroker[int](0).WithRedis(nil, "events", intCodec)
+	assert.False(t, local.Active())
+	local.Start(ctx)
+}
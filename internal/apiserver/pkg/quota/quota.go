@@ -0,0 +1,386 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package quota 实现按用户统计的上传配额：按角色的总存储配额、每日上传字节数与并发分片上传会话数，用量记录在 Redis 中.
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	"github.com/clin211/miniblog-v2/pkg/auth"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// Redis 键.
+const (
+	keyStorage  = "miniblog:upload:quota:storage:%s"
+	keyDaily    = "miniblog:upload:quota:daily:%s:%s"
+	keySessions = "miniblog:upload:quota:sessions:%s"
+)
+
+// dailyKeyTTL 为每日用量键的过期时间，略长于一天以覆盖时钟偏差.
+const dailyKeyTTL = 48 * time.Hour
+
+// defaultUsageCacheTTL 为未配置 usageCacheTTL 时存储用量的缓存时间.
+const defaultUsageCacheTTL = 10 * time.Minute
+
+// errNotCached 表示存储用量尚未缓存.
+var errNotCached = errors.New("usage not cached")
+
+// reserveScript 原子地检查并累加用量：累加后超过上限（ARGV[2]，0 表示不限制）时回滚，
+// 返回 {是否计入, 计入前的用量}. ARGV[3] 为键不存在时新建键的过期秒数，为 0 时要求键已存在，
+// 不存在时返回 {-1, 0}.
+var reserveScript = redis.NewScript(`
+local exists = redis.call("EXISTS", KEYS[1]) == 1
+if not exists and ARGV[3] == "0" then
+	return {-1, 0}
+end
+local size = tonumber(ARGV[1])
+local used = redis.call("INCRBY", KEYS[1], size)
+if not exists then
+	redis.call("EXPIRE", KEYS[1], ARGV[3])
+end
+local limit = tonumber(ARGV[2])
+if limit > 0 and used > limit then
+	redis.call("DECRBY", KEYS[1], size)
+	return {0, used - size}
+end
+return {1, used - size}`)
+
+// decrIfExists 仅在用量已缓存时扣减，用于释放预留的用量.
+var decrIfExists = redis.NewScript(`
+if redis.call("EXISTS", KEYS[1]) == 1 then
+	return redis.call("DECRBY", KEYS[1], ARGV[1])
+end
+return 0`)
+
+// Limiter 检查并记录用户的上传用量. nil 表示不限制，所有方法均可在 nil 上调用.
+// Redis 不可用时只记录日志并放行，避免配额统计影响上传功能.
+type Limiter struct {
+	store store.IStore
+	// roles 返回用户的角色，用于选择存储配额
+	roles func(userID string) ([]string, error)
+
+	quotaEnabled   bool
+	storage        map[string]int64
+	defaultStorage int64
+	daily          int64
+	cacheTTL       time.Duration
+
+	sessions   int64
+	sessionTTL time.Duration
+}
+
+// New 根据上传配置创建 Limiter；未启用配额且未限制并发会话数时返回 nil.
+func New(cfg *opt.UploadOptions, store store.IStore, authz *auth.Authz) *Limiter {
+	if cfg == nil {
+		return nil
+	}
+
+	l := &Limiter{store: store}
+	if authz != nil {
+		l.roles = func(userID string) ([]string, error) { return authz.GetRolesForUser(userID) }
+	}
+	if q := cfg.Quota; q != nil && q.Enabled {
+		l.quotaEnabled = true
+		l.storage = make(map[string]int64, len(q.Storage))
+		for role, value := range q.Storage {
+			l.storage[role], _ = opt.ParseSize(value)
+		}
+		l.defaultStorage, _ = opt.ParseSize(q.DefaultStorage)
+		l.daily, _ = opt.ParseSize(q.DailyLimit)
+		l.cacheTTL, _ = opt.ParseDuration(q.UsageCacheTTL)
+		if l.cacheTTL <= 0 {
+			l.cacheTTL = defaultUsageCacheTTL
+		}
+	}
+	if m := cfg.Multipart; m != nil && m.Enabled && m.ConcurrencyLimitPerUser > 0 {
+		l.sessions = int64(m.ConcurrencyLimitPerUser)
+		l.sessionTTL, _ = opt.ParseDuration(m.UploadIDTTL)
+	}
+
+	if !l.quotaEnabled && l.sessions == 0 {
+		return nil
+	}
+	return l
+}
+
+// Enabled 返回是否启用了上传配额.
+func (l *Limiter) Enabled() bool {
+	return l != nil && l.quotaEnabled
+}
+
+// CheckUpload 检查当前用户能否再上传 size 字节；size 未知（<= 0）时只检查是否还有剩余额度.
+// 只用于尽早拒绝，不预留用量；写入对象前须调用 Reserve.
+func (l *Limiter) CheckUpload(ctx context.Context, size int64) error {
+	if l == nil || !l.quotaEnabled {
+		return nil
+	}
+	userID := contextx.UserID(ctx)
+	size = max(size, 1)
+
+	if limit := l.storageLimit(ctx, userID); limit > 0 {
+		used, err := l.storageUsage(ctx, userID)
+		if err != nil {
+			log.W(ctx).Warnw("Failed to get storage usage, skip storage quota", "err", err)
+		} else if used+size > limit {
+			return exceeded(errno.ErrStorageQuotaExceeded, limit-used, "bytes")
+		}
+	}
+
+	if l.daily > 0 {
+		used, err := l.rdb(ctx).Get(ctx, dailyKey(userID)).Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			log.W(ctx).Warnw("Failed to get daily upload usage, skip daily limit", "err", err)
+		} else if used+size > l.daily {
+			return exceeded(errno.ErrDailyUploadLimitExceeded, l.daily-used, "bytes")
+		}
+	}
+	return nil
+}
+
+// Reserve 在写入对象前原子地检查并计入当前用户 size 字节的用量，超过存储配额或每日上限时不计入并返回错误.
+// 上传失败、命中去重或实际保存的大小更小时，调用方通过 Release 释放多计入的用量.
+func (l *Limiter) Reserve(ctx context.Context, size int64) error {
+	if l == nil || !l.quotaEnabled || size <= 0 {
+		return nil
+	}
+	userID := contextx.UserID(ctx)
+	storageKey := fmt.Sprintf(keyStorage, userID)
+
+	reserved := false
+	limit := l.storageLimit(ctx, userID)
+	ok, used, err := l.reserveStorage(ctx, userID, size, limit)
+	switch {
+	case errors.Is(err, errNotCached):
+		// 不限制存储配额且用量未缓存时无需计入；缓存写入失败时同样跳过
+	case err != nil:
+		log.W(ctx).Warnw("Failed to reserve storage usage, skip storage quota", "err", err)
+	case !ok:
+		return exceeded(errno.ErrStorageQuotaExceeded, limit-used, "bytes")
+	default:
+		reserved = true
+	}
+
+	if l.daily > 0 {
+		ok, used, err := reserve(ctx, l.rdb(ctx), dailyKey(userID), size, l.daily, dailyKeyTTL)
+		if err != nil {
+			log.W(ctx).Warnw("Failed to reserve daily upload usage, skip daily limit", "err", err)
+		} else if !ok {
+			if reserved {
+				l.release(ctx, storageKey, size)
+			}
+			return exceeded(errno.ErrDailyUploadLimitExceeded, l.daily-used, "bytes")
+		}
+	}
+	return nil
+}
+
+// Release 释放当前用户通过 Reserve 计入的 size 字节用量.
+func (l *Limiter) Release(ctx context.Context, size int64) {
+	if l == nil || !l.quotaEnabled || size <= 0 {
+		return
+	}
+	userID := contextx.UserID(ctx)
+	l.release(ctx, fmt.Sprintf(keyStorage, userID), size)
+	if l.daily > 0 {
+		l.release(ctx, dailyKey(userID), size)
+	}
+}
+
+// reserveStorage 计入存储用量，未缓存时先按上传记录统计后重试一次.
+// limit 为 0 时只累加已缓存的用量，未缓存时返回 errNotCached.
+func (l *Limiter) reserveStorage(ctx context.Context, userID string, size int64, limit int64) (bool, int64, error) {
+	key := fmt.Sprintf(keyStorage, userID)
+	ok, used, err := reserve(ctx, l.rdb(ctx), key, size, limit, 0)
+	if !errors.Is(err, errNotCached) || limit == 0 {
+		return ok, used, err
+	}
+	if _, err := l.storageUsage(ctx, userID); err != nil {
+		return false, 0, err
+	}
+	return reserve(ctx, l.rdb(ctx), key, size, limit, 0)
+}
+
+func (l *Limiter) release(ctx context.Context, key string, size int64) {
+	if err := decrIfExists.Run(ctx, l.rdb(ctx), []string{key}, size).Err(); err != nil {
+		log.W(ctx).Warnw("Failed to release upload usage", "key", key, "err", err)
+	}
+}
+
+// Invalidate 清除用户缓存的存储用量，在删除上传记录后调用.
+func (l *Limiter) Invalidate(ctx context.Context, userIDs ...string) {
+	if l == nil || !l.quotaEnabled || len(userIDs) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(userIDs))
+	for _, userID := range userIDs {
+		keys = append(keys, fmt.Sprintf(keyStorage, userID))
+	}
+	if err := l.rdb(ctx).Del(ctx, keys...).Err(); err != nil {
+		log.W(ctx).Warnw("Failed to invalidate storage usage", "err", err)
+	}
+}
+
+// CheckSession 检查当前用户能否再创建分片上传会话，用于在创建会话前尽早拒绝.
+func (l *Limiter) CheckSession(ctx context.Context) error {
+	if l == nil || l.sessions == 0 {
+		return nil
+	}
+	key := fmt.Sprintf(keySessions, contextx.UserID(ctx))
+	rdb := l.rdb(ctx)
+
+	var card *redis.IntCmd
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+		card = pipe.ZCard(ctx, key)
+		return nil
+	})
+	if err != nil {
+		log.W(ctx).Warnw("Failed to count upload sessions, skip session limit", "err", err)
+		return nil
+	}
+	if card.Val() >= l.sessions {
+		return exceeded(errno.ErrTooManyUploadSessions, l.sessions-card.Val(), "sessions")
+	}
+	return nil
+}
+
+// AcquireSession 将 uploadID 计入当前用户进行中的会话；超过上限时不计入并返回 ErrTooManyUploadSessions.
+// 会话按 uploadIdTTL 过期，未完成也未取消的会话到期后自动释放.
+func (l *Limiter) AcquireSession(ctx context.Context, uploadID string) error {
+	if l == nil || l.sessions == 0 {
+		return nil
+	}
+	key := fmt.Sprintf(keySessions, contextx.UserID(ctx))
+	rdb := l.rdb(ctx)
+
+	expiresAt := time.Now().Add(l.sessionTTL)
+	if l.sessionTTL <= 0 {
+		expiresAt = time.Now().Add(dailyKeyTTL)
+	}
+	var card *redis.IntCmd
+	_, err := rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(expiresAt.Unix()), Member: uploadID})
+		card = pipe.ZCard(ctx, key)
+		pipe.ExpireAt(ctx, key, expiresAt)
+		return nil
+	})
+	if err != nil {
+		log.W(ctx).Warnw("Failed to record upload session, skip session limit", "err", err)
+		return nil
+	}
+	if card.Val() > l.sessions {
+		l.ReleaseSession(ctx, uploadID)
+		return exceeded(errno.ErrTooManyUploadSessions, 0, "sessions")
+	}
+	return nil
+}
+
+// ReleaseSession 在会话完成或取消后释放其占用的名额.
+func (l *Limiter) ReleaseSession(ctx context.Context, uploadID string) {
+	if l == nil || l.sessions == 0 {
+		return
+	}
+	if err := l.rdb(ctx).ZRem(ctx, fmt.Sprintf(keySessions, contextx.UserID(ctx)), uploadID).Err(); err != nil {
+		log.W(ctx).Warnw("Failed to release upload session", "uploadID", uploadID, "err", err)
+	}
+}
+
+// storageLimit 返回用户的总存储配额（0 表示不限制）. 用户拥有多个角色时取最宽松的配额，
+// 没有任何已配置的角色时使用 defaultStorage.
+func (l *Limiter) storageLimit(ctx context.Context, userID string) int64 {
+	var roles []string
+	if l.roles != nil {
+		var err error
+		if roles, err = l.roles(userID); err != nil {
+			log.W(ctx).Warnw("Failed to get user roles, use default storage quota", "err", err)
+		}
+	}
+	return resolveStorageLimit(l.storage, l.defaultStorage, roles)
+}
+
+// resolveStorageLimit 从 roles 中选择最宽松的存储配额.
+func resolveStorageLimit(storage map[string]int64, defaultLimit int64, roles []string) int64 {
+	limit, matched := int64(0), false
+	for _, role := range roles {
+		n, ok := storage[role]
+		if !ok {
+			continue
+		}
+		if n == 0 {
+			return 0
+		}
+		if !matched || n > limit {
+			limit, matched = n, true
+		}
+	}
+	if !matched {
+		return defaultLimit
+	}
+	return limit
+}
+
+// storageUsage 返回用户的存储用量，优先读取 Redis 缓存，未命中时按上传记录统计.
+func (l *Limiter) storageUsage(ctx context.Context, userID string) (int64, error) {
+	key := fmt.Sprintf(keyStorage, userID)
+	rdb := l.rdb(ctx)
+
+	used, err := rdb.Get(ctx, key).Int64()
+	if err == nil {
+		return used, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	used, err = l.store.UploadedFile().SumSize(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	_ = rdb.SetNX(ctx, key, used, l.cacheTTL).Err()
+	return used, nil
+}
+
+func (l *Limiter) rdb(ctx context.Context) *redis.Client {
+	return l.store.Redis(ctx)
+}
+
+// reserve 执行 reserveScript，ttl 为 0 时要求键已存在，否则返回 errNotCached.
+func reserve(ctx context.Context, rdb *redis.Client, key string, size int64, limit int64, ttl time.Duration) (bool, int64, error) {
+	result, err := reserveScript.Run(ctx, rdb, []string{key}, size, limit, int64(ttl.Seconds())).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(result) != 2 {
+		return false, 0, fmt.Errorf("unexpected reserve result: %v", result)
+	}
+	if result[0] < 0 {
+		return false, 0, errNotCached
+	}
+	return result[0] == 1, result[1], nil
+}
+
+// dailyKey 返回用户当天（UTC）的用量键.
+func dailyKey(userID string) string {
+	return fmt.Sprintf(keyDaily, userID, time.Now().UTC().Format("20060102"))
+}
+
+// exceeded 返回带剩余额度的配额错误.
+func exceeded(base *errno.ErrorX, remaining int64, unit string) error {
+	remaining = max(remaining, 0)
+	return base.Copy().WithMessage("%s Remaining: %d %s.", base.Message, remaining, unit).KV("remaining", strconv.FormatInt(remaining, 10))
+}
@@ -0,0 +1,174 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package quota
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/store"
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// fakeStore 只实现 Limiter 用到的 Redis 与 SumSize.
+type fakeStore struct {
+	store.IStore
+	rdb  *redis.Client
+	used int64
+}
+
+func (s *fakeStore) Redis(context.Context) *redis.Client { return s.rdb }
+
+func (s *fakeStore) UploadedFile() store.UploadedFileStore {
+	return &fakeUploadedFileStore{used: s.used}
+}
+
+type fakeUploadedFileStore struct {
+	store.UploadedFileStore
+	used int64
+}
+
+func (s *fakeUploadedFileStore) SumSize(context.Context, string) (int64, error) { return s.used, nil }
+
+// newTestLimiter 创建存储配额 100 字节、每日上限 150 字节、最多 2 个并发会话的 Limiter，used 为已有上传记录的用量.
+func newTestLimiter(t *testing.T, used int64) (*Limiter, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	cfg := opt.NewUploadOptions()
+	cfg.Quota.Enabled = true
+	cfg.Quota.Storage = map[string]string{}
+	cfg.Quota.DefaultStorage = "100B"
+	cfg.Quota.DailyLimit = "150B"
+	cfg.Multipart.ConcurrencyLimitPerUser = 2
+	cfg.Multipart.UploadIDTTL = "1h"
+	return New(cfg, &fakeStore{rdb: rdb, used: used}, nil), mr
+}
+
+func TestResolveStorageLimit(t *testing.T) {
+	storage := map[string]int64{known.RoleContributor: 100, known.RoleUser: 1000, known.RoleAdmin: 0}
+
+	assert.Equal(t, int64(100), resolveStorageLimit(storage, 50, []string{known.RoleContributor}))
+	// 多个角色取最宽松的配额
+	assert.Equal(t, int64(1000), resolveStorageLimit(storage, 50, []string{known.RoleContributor, known.RoleUser}))
+	assert.Equal(t, int64(0), resolveStorageLimit(storage, 50, []string{known.RoleUser, known.RoleAdmin}))
+	// 未配置的角色使用默认配额
+	assert.Equal(t, int64(50), resolveStorageLimit(storage, 50, []string{"role::guest"}))
+	assert.Equal(t, int64(50), resolveStorageLimit(storage, 50, nil))
+}
+
+func TestExceeded(t *testing.T) {
+	err := exceeded(errno.ErrStorageQuotaExceeded, -5, "bytes")
+	assert.ErrorIs(t, err, errno.ErrStorageQuotaExceeded)
+	assert.Equal(t, "0", errno.FromError(err).Metadata["remaining"])
+
+	err = exceeded(errno.ErrStorageQuotaExceeded, 1024, "bytes")
+	assert.Equal(t, "1024", errno.FromError(err).Metadata["remaining"])
+	assert.Contains(t, errno.FromError(err).Message, "Remaining: 1024 bytes")
+	// 不修改共享的错误变量
+	assert.Empty(t, errno.ErrStorageQuotaExceeded.Metadata)
+	assert.Equal(t, "Storage quota exceeded.", errno.ErrStorageQuotaExceeded.Message)
+}
+
+func TestNew(t *testing.T) {
+	cfg := opt.NewUploadOptions()
+	assert.NotNil(t, New(cfg, nil, nil), "multipart concurrency limit is enabled by default")
+
+	cfg.Multipart.ConcurrencyLimitPerUser = 0
+	assert.Nil(t, New(cfg, nil, nil))
+
+	cfg.Quota.Enabled = true
+	l := New(cfg, nil, nil)
+	assert.Equal(t, int64(200<<20), l.storage[known.RoleContributor])
+	assert.Equal(t, int64(500<<20), l.daily)
+}
+
+func TestReserve(t *testing.T) {
+	l, mr := newTestLimiter(t, 40)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	require.NoError(t, l.CheckUpload(ctx, 60))
+	require.NoError(t, l.Reserve(ctx, 60))
+
+	// 预留后立即计入用量，超出存储配额时不计入
+	err := l.CheckUpload(ctx, 1)
+	assert.ErrorIs(t, err, errno.ErrStorageQuotaExceeded)
+	err = l.Reserve(ctx, 1)
+	assert.ErrorIs(t, err, errno.ErrStorageQuotaExceeded)
+	assert.Equal(t, "0", errno.FromError(err).Metadata["remaining"])
+
+	// 释放后可以再次上传
+	l.Release(ctx, 60)
+	for range 3 {
+		// 删除文件后重新统计存储用量，每日用量不受影响
+		l.Invalidate(ctx, "user-000001")
+		require.NoError(t, l.Reserve(ctx, 50))
+	}
+
+	// 超出每日上限时同时回滚已计入的存储用量
+	l.Invalidate(ctx, "user-000001")
+	err = l.Reserve(ctx, 10)
+	assert.ErrorIs(t, err, errno.ErrDailyUploadLimitExceeded)
+	assert.Equal(t, "0", errno.FromError(err).Metadata["remaining"])
+	used, err := mr.Get("miniblog:upload:quota:storage:user-000001")
+	require.NoError(t, err)
+	assert.Equal(t, "40", used)
+
+	// 其他用户的用量互不影响
+	require.NoError(t, l.Reserve(contextx.WithUserID(context.Background(), "user-000002"), 60))
+}
+
+func TestReserve_Concurrent(t *testing.T) {
+	l, _ := newTestLimiter(t, 0)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	// 并发上传不会同时通过检查而超出配额
+	var wg sync.WaitGroup
+	var accepted atomic.Int64
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Reserve(ctx, 30) == nil {
+				accepted.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(3), accepted.Load())
+}
+
+func TestSessions(t *testing.T) {
+	l, mr := newTestLimiter(t, 0)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	require.NoError(t, l.CheckSession(ctx))
+	require.NoError(t, l.AcquireSession(ctx, "upload-1"))
+	require.NoError(t, l.AcquireSession(ctx, "upload-2"))
+	assert.ErrorIs(t, l.CheckSession(ctx), errno.ErrTooManyUploadSessions)
+	// 超出上限的会话不计入
+	assert.ErrorIs(t, l.AcquireSession(ctx, "upload-3"), errno.ErrTooManyUploadSessions)
+
+	l.ReleaseSession(ctx, "upload-1")
+	require.NoError(t, l.CheckSession(ctx))
+	require.NoError(t, l.AcquireSession(ctx, "upload-3"))
+
+	// 会话到期后自动释放
+	mr.FastForward(2 * time.Hour)
+	require.NoError(t, l.AcquireSession(ctx, "upload-4"))
+}
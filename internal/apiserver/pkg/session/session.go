@@ -0,0 +1,278 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package session 管理登录会话的刷新令牌与访问令牌的吊销.
+//
+// 刷新令牌是不透明的随机字符串，Redis 中只保存其 SHA-256 摘要。一次登录签发的刷新令牌
+// 属于同一个令牌族（family），每次刷新都会签发新的刷新令牌并使旧令牌失效；
+// 已失效的令牌被再次使用时，说明令牌可能已经泄露，整个令牌族随即被吊销.
+//
+// 访问令牌（JWT）无法在服务端删除，退出登录时将其 jti 加入黑名单，黑名单条目的有效期
+// 等于令牌的剩余有效期；吊销用户全部令牌时记录吊销时间，此前签发的访问令牌均视为失效.
+package session
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	"github.com/clin211/miniblog-v2/pkg/token"
+)
+
+const (
+	// keyPrefix 为会话相关键的统一前缀.
+	keyPrefix = "miniblog:session:"
+
+	// DefaultAccessExpiration 为未配置时访问令牌的有效期.
+	DefaultAccessExpiration = 2 * time.Hour
+
+	// DefaultRefreshExpiration 为未配置时刷新令牌的有效期.
+	DefaultRefreshExpiration = 7 * 24 * time.Hour
+)
+
+// errUnavailable 表示未配置 Redis，无法保存刷新令牌.
+var errUnavailable = errors.New("session store is not configured")
+
+// RefreshToken 为签发给客户端的刷新令牌.
+type RefreshToken struct {
+	Token    string
+	ExpireAt time.Time
+}
+
+// Manager 负责刷新令牌的签发、轮换与吊销，以及访问令牌的吊销.
+type Manager struct {
+	rdb              *redis.Client
+	accessExpiration time.Duration
+	expiration       time.Duration
+}
+
+// New 创建一个 Manager. accessExpiration 为访问令牌的有效期，决定吊销记录的保留时间；
+// refreshExpiration 为刷新令牌的有效期，每次轮换重新计时.
+func New(rdb *redis.Client, accessExpiration time.Duration, refreshExpiration time.Duration) *Manager {
+	if accessExpiration <= 0 {
+		accessExpiration = DefaultAccessExpiration
+	}
+	if refreshExpiration <= 0 {
+		refreshExpiration = DefaultRefreshExpiration
+	}
+	return &Manager{rdb: rdb, accessExpiration: accessExpiration, expiration: refreshExpiration}
+}
+
+// Issue 为一次新的登录创建令牌族并签发第一个刷新令牌.
+func (m *Manager) Issue(ctx context.Context, userID string) (*RefreshToken, error) {
+	if m == nil || m.rdb == nil {
+		return nil, errUnavailable
+	}
+	return m.issue(ctx, userID, uuid.New().String())
+}
+
+// Rotate 校验刷新令牌并签发同一令牌族中的新刷新令牌，返回令牌所属的用户 ID.
+// 令牌不存在、已过期或令牌族已被吊销时返回 errno.ErrRefreshTokenInvalid；
+// 令牌已被使用过时吊销整个令牌族并返回 errno.ErrRefreshTokenReused.
+func (m *Manager) Rotate(ctx context.Context, token string) (string, *RefreshToken, error) {
+	if m == nil || m.rdb == nil {
+		return "", nil, errUnavailable
+	}
+
+	key := refreshKey(token)
+	fields, err := m.rdb.HGetAll(ctx, key).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	userID, familyID := fields["user_id"], fields["family_id"]
+	if userID == "" || familyID == "" {
+		return "", nil, errno.ErrRefreshTokenInvalid
+	}
+
+	// 原子地将令牌标记为已使用，并发刷新时只有一个请求能够成功
+	used, err := m.rdb.HIncrBy(ctx, key, "used", 1).Result()
+	if err != nil {
+		return "", nil, err
+	}
+	if used > 1 {
+		log.W(ctx).Warnw("Refresh token reuse detected, revoking token family", "userID", userID, "familyID", familyID)
+		if err := m.RevokeFamily(ctx, familyID); err != nil {
+			return "", nil, err
+		}
+		return "", nil, errno.ErrRefreshTokenReused
+	}
+
+	// 令牌族已被吊销或已过期
+	owner, err := m.rdb.Get(ctx, familyKey(familyID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return "", nil, err
+	}
+	if owner != userID {
+		return "", nil, errno.ErrRefreshTokenInvalid
+	}
+
+	refresh, err := m.issue(ctx, userID, familyID)
+	if err != nil {
+		return "", nil, err
+	}
+	return userID, refresh, nil
+}
+
+// RevokeFamily 吊销令牌族，族中的刷新令牌都将无法再使用.
+func (m *Manager) RevokeFamily(ctx context.Context, familyID string) error {
+	if m == nil || m.rdb == nil {
+		return errUnavailable
+	}
+
+	userID, err := m.rdb.GetDel(ctx, familyKey(familyID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return m.rdb.SRem(ctx, userFamiliesKey(userID), familyID).Err()
+}
+
+// Revoke 吊销刷新令牌所在的令牌族，用于退出登录. 令牌不存在或不属于 userID 时忽略.
+func (m *Manager) Revoke(ctx context.Context, userID string, token string) error {
+	if m == nil || m.rdb == nil {
+		return errUnavailable
+	}
+
+	fields, err := m.rdb.HMGet(ctx, refreshKey(token), "user_id", "family_id").Result()
+	if err != nil {
+		return err
+	}
+	owner, _ := fields[0].(string)
+	familyID, _ := fields[1].(string)
+	if owner != userID || familyID == "" {
+		return nil
+	}
+	return m.RevokeFamily(ctx, familyID)
+}
+
+// Deny 将访问令牌加入黑名单，直到令牌过期. 早期签发的令牌没有 jti，无法单独吊销.
+func (m *Manager) Deny(ctx context.Context, claims *token.Claims) error {
+	if m == nil || m.rdb == nil {
+		return errUnavailable
+	}
+	if claims == nil || claims.ID == "" {
+		return nil
+	}
+
+	ttl := time.Until(claims.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+	return m.rdb.Set(ctx, denyKey(claims.ID), claims.Identity, ttl).Err()
+}
+
+// RevokeUser 吊销用户的全部令牌：此前签发的访问令牌均失效，全部令牌族被吊销.
+func (m *Manager) RevokeUser(ctx context.Context, userID string) error {
+	if m == nil || m.rdb == nil {
+		return errUnavailable
+	}
+
+	// 吊销时间保留到此前签发的访问令牌全部过期
+	now := time.Now()
+	if err := m.rdb.Set(ctx, revokedKey(userID), now.UnixMilli(), m.accessExpiration).Err(); err != nil {
+		return err
+	}
+
+	familyIDs, err := m.rdb.SMembers(ctx, userFamiliesKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(familyIDs)+1)
+	for _, familyID := range familyIDs {
+		keys = append(keys, familyKey(familyID))
+	}
+	keys = append(keys, userFamiliesKey(userID))
+	return m.rdb.Del(ctx, keys...).Err()
+}
+
+// Revoked 判断访问令牌是否已被吊销. 未配置 Redis 时不支持吊销，始终返回 false.
+func (m *Manager) Revoked(ctx context.Context, claims *token.Claims) (bool, error) {
+	if m == nil || m.rdb == nil || claims == nil {
+		return false, nil
+	}
+
+	var (
+		denied  *redis.IntCmd
+		revoked *redis.StringCmd
+	)
+	_, err := m.rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		if claims.ID != "" {
+			denied = pipe.Exists(ctx, denyKey(claims.ID))
+		}
+		revoked = pipe.Get(ctx, revokedKey(claims.Identity))
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return false, err
+	}
+
+	if denied != nil && denied.Val() > 0 {
+		return true, nil
+	}
+	if revokedAt, err := revoked.Int64(); err == nil && claims.IssuedAt.UnixMilli() <= revokedAt {
+		return true, nil
+	}
+	return false, nil
+}
+
+// issue 在令牌族中签发一个新的刷新令牌，并延长令牌族的有效期.
+func (m *Manager) issue(ctx context.Context, userID string, familyID string) (*RefreshToken, error) {
+	token, err := token.NewOpaque()
+	if err != nil {
+		return nil, err
+	}
+	expireAt := time.Now().Add(m.expiration)
+
+	// 已使用的令牌保留到过期，以便识别重复使用
+	_, err = m.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		key := refreshKey(token)
+		pipe.HSet(ctx, key, "user_id", userID, "family_id", familyID, "used", 0)
+		pipe.Expire(ctx, key, m.expiration)
+		pipe.Set(ctx, familyKey(familyID), userID, m.expiration)
+		pipe.SAdd(ctx, userFamiliesKey(userID), familyID)
+		pipe.Expire(ctx, userFamiliesKey(userID), m.expiration)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshToken{Token: token, ExpireAt: expireAt}, nil
+}
+
+// refreshKey 返回刷新令牌的键，键中只包含令牌的摘要.
+func refreshKey(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return keyPrefix + "refresh:" + hex.EncodeToString(sum[:])
+}
+
+// familyKey 返回令牌族的键，值为令牌族所属的用户 ID.
+func familyKey(familyID string) string {
+	return keyPrefix + "family:" + familyID
+}
+
+// userFamiliesKey 返回用户全部令牌族 ID 集合的键.
+func userFamiliesKey(userID string) string {
+	return keyPrefix + "user:" + userID
+}
+
+// denyKey 返回访问令牌黑名单的键.
+func denyKey(jti string) string {
+	return keyPrefix + "denylist:" + jti
+}
+
+// revokedKey 返回用户令牌吊销时间的键，值为 Unix 毫秒时间戳.
+func revokedKey(userID string) string {
+	return keyPrefix + "revoked:" + userID
+}
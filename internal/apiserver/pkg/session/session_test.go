@@ -0,0 +1,181 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/pkg/token"
+)
+
+func newTestManager(t *testing.T) (*Manager, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return New(rdb, time.Hour, time.Hour), mr
+}
+
+func TestRotate(t *testing.T) {
+	ctx := context.Background()
+	m, mr := newTestManager(t)
+
+	first, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), first.ExpireAt, time.Minute)
+
+	// Redis 中不保存明文令牌
+	for _, key := range mr.Keys() {
+		assert.NotContains(t, key, first.Token)
+	}
+
+	userID, second, err := m.Rotate(ctx, first.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+	assert.NotEqual(t, first.Token, second.Token)
+
+	userID, third, err := m.Rotate(ctx, second.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+	assert.NotEmpty(t, third.Token)
+}
+
+func TestRotateInvalid(t *testing.T) {
+	ctx := context.Background()
+	m, mr := newTestManager(t)
+
+	_, _, err := m.Rotate(ctx, "unknown")
+	assert.Equal(t, errno.ErrRefreshTokenInvalid, err)
+
+	refresh, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+	mr.FastForward(2 * time.Hour)
+	_, _, err = m.Rotate(ctx, refresh.Token)
+	assert.Equal(t, errno.ErrRefreshTokenInvalid, err)
+}
+
+func TestRotateReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+
+	stolen, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+	other, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+
+	_, current, err := m.Rotate(ctx, stolen.Token)
+	require.NoError(t, err)
+
+	// 旧令牌被再次使用，整个令牌族被吊销
+	_, _, err = m.Rotate(ctx, stolen.Token)
+	assert.Equal(t, errno.ErrRefreshTokenReused, err)
+	_, _, err = m.Rotate(ctx, current.Token)
+	assert.Equal(t, errno.ErrRefreshTokenInvalid, err)
+
+	// 其他登录会话不受影响
+	_, _, err = m.Rotate(ctx, other.Token)
+	assert.NoError(t, err)
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+
+	refresh, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+
+	// 不能吊销其他用户的刷新令牌
+	require.NoError(t, m.Revoke(ctx, "user-2", refresh.Token))
+	_, refresh, err = m.Rotate(ctx, refresh.Token)
+	require.NoError(t, err)
+
+	require.NoError(t, m.Revoke(ctx, "user-1", refresh.Token))
+	_, _, err = m.Rotate(ctx, refresh.Token)
+	assert.Equal(t, errno.ErrRefreshTokenInvalid, err)
+
+	assert.NoError(t, m.Revoke(ctx, "user-1", "unknown"))
+}
+
+func TestDeny(t *testing.T) {
+	ctx := context.Background()
+	m, mr := newTestManager(t)
+
+	claims := &token.Claims{Identity: "user-1", ID: "jti-1", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(10 * time.Minute)}
+	other := &token.Claims{Identity: "user-1", ID: "jti-2", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(10 * time.Minute)}
+
+	revoked, err := m.Revoked(ctx, claims)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.NoError(t, m.Deny(ctx, claims))
+	revoked, err = m.Revoked(ctx, claims)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	revoked, err = m.Revoked(ctx, other)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// 黑名单条目在令牌过期后自动删除
+	assert.InDelta(t, 10*time.Minute, mr.TTL(denyKey("jti-1")), float64(time.Second))
+	mr.FastForward(10 * time.Minute)
+	assert.False(t, mr.Exists(denyKey("jti-1")))
+
+	// 已过期的令牌无需加入黑名单
+	expired := &token.Claims{Identity: "user-1", ID: "jti-3", ExpiresAt: time.Now().Add(-time.Minute)}
+	require.NoError(t, m.Deny(ctx, expired))
+	assert.False(t, mr.Exists(denyKey("jti-3")))
+}
+
+func TestRevokeUser(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestManager(t)
+
+	issuedAt := time.Now().Add(-time.Millisecond)
+	before := &token.Claims{Identity: "user-1", IssuedAt: issuedAt}
+	others := &token.Claims{Identity: "user-2", IssuedAt: issuedAt}
+	first, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+	second, err := m.Issue(ctx, "user-1")
+	require.NoError(t, err)
+
+	require.NoError(t, m.RevokeUser(ctx, "user-1"))
+
+	// 吊销前签发的访问令牌与全部令牌族均失效
+	revoked, err := m.Revoked(ctx, before)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	for _, refresh := range []*RefreshToken{first, second} {
+		_, _, err = m.Rotate(ctx, refresh.Token)
+		assert.Equal(t, errno.ErrRefreshTokenInvalid, err)
+	}
+
+	// 其他用户与吊销后签发的令牌不受影响
+	revoked, err = m.Revoked(ctx, others)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	time.Sleep(2 * time.Millisecond)
+	revoked, err = m.Revoked(ctx, &token.Claims{Identity: "user-1", IssuedAt: time.Now()})
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
+
+func TestUnavailable(t *testing.T) {
+	m := New(nil, time.Hour, time.Hour)
+	_, err := m.Issue(context.Background(), "user-1")
+	assert.Error(t, err)
+
+	// 未配置 Redis 时不支持吊销，令牌始终有效
+	revoked, err := m.Revoked(context.Background(), &token.Claims{Identity: "user-1", ID: "jti-1"})
+	assert.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -0,0 +1,351 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package tus 保存 tus 1.0 可续传上传的状态：未完成的上传暂存在本地磁盘，
+// 接收完全部字节后交给调用方提供的 FinishFunc 写入最终存储.
+package tus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+const (
+	// infoFile 为上传元数据文件名，dataFile 为已接收的数据.
+	infoFile = "info.json"
+	dataFile = "data"
+
+	// defaultExpiration 为配置缺失或解析失败时的有效期.
+	defaultExpiration = 24 * time.Hour
+)
+
+// idRegex 限制上传 ID 只能为 32 位十六进制，防止路径穿越.
+var idRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Upload 为一次可续传上传的状态.
+type Upload struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"ownerID"`
+	// Size 为创建时声明的总字节数，Offset 为已接收的字节数
+	Size   int64 `json:"size"`
+	Offset int64 `json:"offset"`
+	// Metadata 为 Upload-Metadata 解码后的键值对，如 filename、filetype、scene
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	// Object 为上传完成后写入最终存储得到的对象，未完成时为 nil
+	Object *v1.UploadedObject `json:"object,omitempty"`
+}
+
+// Completed 返回上传是否已完成.
+func (u *Upload) Completed() bool {
+	return u.Object != nil
+}
+
+// FinishFunc 在接收完全部字节后调用，r 为完整的文件内容.
+type FinishFunc func(ctx context.Context, u *Upload, r io.Reader) (*v1.UploadedObject, error)
+
+// Store 基于本地磁盘保存可续传上传. nil 表示未启用.
+//
+// 目录布局：
+//
+//	{tempDir}/{id}/info.json  上传元数据（含过期时间与完成后的对象）
+//	{tempDir}/{id}/data       已接收的数据，完成后删除
+//
+// 状态全部落盘，服务重启后仍可继续上传；过期上传由后台协程定期清理.
+type Store struct {
+	tempDir    string
+	expiration time.Duration
+
+	// locks 为每个上传的互斥锁，同一上传的写入与完成串行执行
+	locks sync.Map
+}
+
+// New 根据配置创建 Store，未启用时返回 nil.
+func New(cfg *opt.TusOptions) (*Store, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	expiration, _ := opt.ParseDuration(cfg.Expiration)
+	if expiration <= 0 {
+		expiration = defaultExpiration
+	}
+	if err := os.MkdirAll(cfg.TempDir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Store{tempDir: cfg.TempDir, expiration: expiration}, nil
+}
+
+// Create 为当前用户创建一个新的上传.
+func (s *Store) Create(ctx context.Context, size int64, metadata map[string]string) (*Upload, error) {
+	now := time.Now()
+	u := &Upload{
+		ID:        strings.ReplaceAll(uuid.NewString(), "-", ""),
+		OwnerID:   contextx.UserID(ctx),
+		Size:      size,
+		Metadata:  metadata,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.expiration),
+	}
+	if err := os.MkdirAll(s.dir(u.ID), 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(s.dir(u.ID), dataFile), nil, 0o600); err != nil {
+		return nil, err
+	}
+	if err := s.save(u); err != nil {
+		_ = os.RemoveAll(s.dir(u.ID))
+		return nil, err
+	}
+	return u, nil
+}
+
+// Get 返回当前用户的上传. 上传不存在或属于其他用户时返回 ErrUploadSessionNotFound，过期时返回 ErrUploadExpired.
+func (s *Store) Get(ctx context.Context, id string) (*Upload, error) {
+	if !idRegex.MatchString(id) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+
+	u, err := s.read(id)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.W(ctx).Errorw("Failed to read tus upload", "id", id, "err", err)
+		}
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	if u.OwnerID != contextx.UserID(ctx) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	if time.Now().After(u.ExpiresAt) {
+		return nil, errno.ErrUploadExpired
+	}
+	return u, nil
+}
+
+// Write 从 offset 开始追加 r 中的数据，offset 必须等于已接收的字节数.
+// 接收完全部字节后调用 finish 写入最终存储；finish 失败时删除该上传，客户端需要重新创建.
+// 连接中断时已写入的数据会保留，客户端可通过 HEAD 获取偏移量后续传.
+func (s *Store) Write(ctx context.Context, id string, offset int64, r io.Reader, finish FinishFunc) (*Upload, error) {
+	mu := s.lock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	u, err := s.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// 完成响应丢失后客户端可能重发最后一次请求，其偏移量小于已接收的字节数，已完成时直接返回
+	if u.Completed() {
+		return u, nil
+	}
+	if offset != u.Offset {
+		return nil, errno.ErrUploadOffsetMismatch
+	}
+
+	written, werr := s.append(u, r)
+	u.Offset += written
+	if werr == nil || written > 0 {
+		if err := s.save(u); err != nil {
+			return nil, err
+		}
+	}
+	if werr != nil {
+		return nil, werr
+	}
+
+	if u.Offset < u.Size {
+		return u, nil
+	}
+	if err := s.finish(ctx, u, finish); err != nil {
+		s.remove(id)
+		return nil, err
+	}
+	return u, nil
+}
+
+// Delete 终止并删除当前用户的上传.
+func (s *Store) Delete(ctx context.Context, id string) error {
+	mu := s.lock(id)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := s.Get(ctx, id); err != nil && !errors.Is(err, errno.ErrUploadExpired) {
+		return err
+	}
+	s.remove(id)
+	return nil
+}
+
+// append 将 r 追加到数据文件，最多写入剩余字节数.
+// 数据超出声明的大小时本次写入的数据全部作废，数据文件截断回原偏移量，返回 0 与 ErrFileTooLarge.
+func (s *Store) append(u *Upload, r io.Reader) (int64, error) {
+	f, err := os.OpenFile(filepath.Join(s.dir(u.ID), dataFile), os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	remaining := u.Size - u.Offset
+	n, err := io.Copy(f, io.LimitReader(r, remaining))
+	if err == nil && n == remaining {
+		var probe [1]byte
+		if m, _ := r.Read(probe[:]); m > 0 {
+			if err := f.Truncate(u.Offset); err != nil {
+				return 0, err
+			}
+			return 0, errno.ErrFileTooLarge
+		}
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// finish 将完整的数据交给 finish，成功后记录对象并删除数据文件.
+func (s *Store) finish(ctx context.Context, u *Upload, finish FinishFunc) error {
+	path := filepath.Join(s.dir(u.ID), dataFile)
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	obj, err := finish(ctx, u, f)
+	if err != nil {
+		return err
+	}
+	u.Object = obj
+	if err := s.save(u); err != nil {
+		return err
+	}
+	_ = os.Remove(path)
+	return nil
+}
+
+// Expiration 返回上传的有效期.
+func (s *Store) Expiration() time.Duration {
+	return s.expiration
+}
+
+// dir 返回上传目录.
+func (s *Store) dir(id string) string {
+	return filepath.Join(s.tempDir, id)
+}
+
+// lock 返回上传对应的互斥锁.
+func (s *Store) lock(id string) *sync.Mutex {
+	mu, _ := s.locks.LoadOrStore(id, &sync.Mutex{})
+	return mu.(*sync.Mutex)
+}
+
+func (s *Store) read(id string) (*Upload, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir(id), infoFile))
+	if err != nil {
+		return nil, err
+	}
+	var u Upload
+	if err := json.Unmarshal(data, &u); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// save 先写临时文件再重命名，避免写入中断时留下不完整的元数据.
+func (s *Store) save(u *Upload) error {
+	data, err := json.Marshal(u)
+	if err != nil {
+		return err
+	}
+	tmp := filepath.Join(s.dir(u.ID), infoFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(s.dir(u.ID), infoFile))
+}
+
+func (s *Store) remove(id string) {
+	_ = os.RemoveAll(s.dir(id))
+	s.locks.Delete(id)
+}
+
+// StartJanitor 启动后台协程，立即并定期清理过期上传（包括服务重启前遗留的上传），ctx 取消后停止.
+// 在服务启动时调用；s 为 nil 时不做任何事.
+func (s *Store) StartJanitor(ctx context.Context) {
+	if s == nil {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(min(max(s.expiration/4, time.Minute), time.Hour))
+		defer ticker.Stop()
+		for {
+			if n, err := s.cleanupExpired(time.Now()); err != nil {
+				log.Errorw("Failed to cleanup expired tus uploads", "err", err)
+			} else if n > 0 {
+				log.Infow("Cleaned up expired tus uploads", "count", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// cleanupExpired 删除所有已过期的上传，返回删除的上传数.
+// 缺失或损坏 info.json 的目录按目录修改时间 + 有效期判断是否过期.
+func (s *Store) cleanupExpired(now time.Time) (int, error) {
+	entries, err := os.ReadDir(s.tempDir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var count int
+	for _, entry := range entries {
+		if !entry.IsDir() || !idRegex.MatchString(entry.Name()) {
+			continue
+		}
+
+		expiresAt := time.Time{}
+		if u, err := s.read(entry.Name()); err == nil {
+			expiresAt = u.ExpiresAt
+		} else if info, err := entry.Info(); err == nil {
+			expiresAt = info.ModTime().Add(s.expiration)
+		}
+		if expiresAt.IsZero() || now.Before(expiresAt) {
+			continue
+		}
+
+		mu := s.lock(entry.Name())
+		mu.Lock()
+		s.remove(entry.Name())
+		mu.Unlock()
+		count++
+	}
+	return count, nil
+}
@@ -0,0 +1,157 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package tus
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+func newTestStore(t *testing.T) *Store {
+	s, err := New(&opt.TusOptions{Enabled: true, TempDir: t.TempDir(), Expiration: "1h"})
+	require.NoError(t, err)
+	return s
+}
+
+func TestStoreResume(t *testing.T) {
+	s := newTestStore(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	var received string
+	finish := func(ctx context.Context, u *Upload, r io.Reader) (*v1.UploadedObject, error) {
+		data, err := io.ReadAll(r)
+		received = string(data)
+		return &v1.UploadedObject{FileID: "file-000001"}, err
+	}
+
+	u, err := s.Create(ctx, 11, map[string]string{"filename": "a.txt"})
+	require.NoError(t, err)
+
+	u, err = s.Write(ctx, u.ID, 0, strings.NewReader("hello"), finish)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), u.Offset)
+	assert.False(t, u.Completed())
+
+	// 偏移量不一致
+	_, err = s.Write(ctx, u.ID, 3, strings.NewReader("xx"), finish)
+	assert.ErrorIs(t, err, errno.ErrUploadOffsetMismatch)
+
+	// 其他用户无法访问该上传
+	_, err = s.Get(contextx.WithUserID(context.Background(), "user-000002"), u.ID)
+	assert.ErrorIs(t, err, errno.ErrUploadSessionNotFound)
+
+	u, err = s.Write(ctx, u.ID, 5, strings.NewReader(" world"), finish)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), u.Offset)
+	assert.True(t, u.Completed())
+	assert.Equal(t, "hello world", received)
+	assert.NoFileExists(t, s.dir(u.ID)+"/"+dataFile)
+
+	// 完成响应丢失后重发最后一次请求，返回已完成的上传
+	received = ""
+	u, err = s.Write(ctx, u.ID, 5, strings.NewReader(" world"), finish)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11), u.Offset)
+	assert.Equal(t, "file-000001", u.Object.FileID)
+	assert.Empty(t, received)
+
+	require.NoError(t, s.Delete(ctx, u.ID))
+	_, err = s.Get(ctx, u.ID)
+	assert.ErrorIs(t, err, errno.ErrUploadSessionNotFound)
+}
+
+func TestStoreOverflowAndFinishError(t *testing.T) {
+	s := newTestStore(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	u, err := s.Create(ctx, 3, nil)
+	require.NoError(t, err)
+	_, err = s.Write(ctx, u.ID, 0, strings.NewReader("ab"), nil)
+	require.NoError(t, err)
+	_, err = s.Write(ctx, u.ID, 2, strings.NewReader("cd"), nil)
+	assert.ErrorIs(t, err, errno.ErrFileTooLarge)
+
+	// 超出大小的请求不记录进度，重试时从原偏移量继续
+	u, err = s.Get(ctx, u.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), u.Offset)
+	_, err = s.Write(ctx, u.ID, 3, strings.NewReader(""), nil)
+	assert.ErrorIs(t, err, errno.ErrUploadOffsetMismatch)
+	var got string
+	_, err = s.Write(ctx, u.ID, 2, strings.NewReader("c"), func(_ context.Context, _ *Upload, r io.Reader) (*v1.UploadedObject, error) {
+		data, err := io.ReadAll(r)
+		got = string(data)
+		return &v1.UploadedObject{}, err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "abc", got)
+
+	// 处理失败时删除上传
+	failed := errors.New("scan failed")
+	u, err = s.Create(ctx, 3, nil)
+	require.NoError(t, err)
+	_, err = s.Write(ctx, u.ID, 0, strings.NewReader("abc"), func(context.Context, *Upload, io.Reader) (*v1.UploadedObject, error) {
+		return nil, failed
+	})
+	assert.ErrorIs(t, err, failed)
+	assert.NoDirExists(t, s.dir(u.ID))
+}
+
+func TestStoreCleanupExpired(t *testing.T) {
+	s := newTestStore(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	u, err := s.Create(ctx, 10, nil)
+	require.NoError(t, err)
+
+	n, err := s.cleanupExpired(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// 已过期但尚未清理的上传返回 ErrUploadExpired
+	u.ExpiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, s.save(u))
+	_, err = s.Get(ctx, u.ID)
+	assert.ErrorIs(t, err, errno.ErrUploadExpired)
+
+	n, err = s.cleanupExpired(time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	_, err = os.Stat(s.dir(u.ID))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestStoreJanitor(t *testing.T) {
+	s := newTestStore(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	// 服务重启前遗留的过期上传在启动时即被清理
+	u, err := s.Create(ctx, 10, nil)
+	require.NoError(t, err)
+	u.ExpiresAt = time.Now().Add(-time.Second)
+	require.NoError(t, s.save(u))
+
+	janitorCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s.StartJanitor(janitorCtx)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(s.dir(u.ID))
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,380 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/HugoSmits86/nativewebp"
+	"golang.org/x/image/draw"
+
+	// 注册解码器
+	_ "image/gif"
+
+	_ "golang.org/x/image/webp"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// 衍生图元数据键：variant.<name> 与 variant.<name>.webp 为衍生图 URL，width/height 为原图尺寸.
+const (
+	metadataWidth         = "width"
+	metadataHeight        = "height"
+	metadataVariantPrefix = "variant."
+	metadataWebPSuffix    = ".webp"
+)
+
+// derivativeSeparator 分隔原对象键与衍生图名称；对象键模板不允许 @，因此不会与普通对象冲突.
+const derivativeSeparator = "@"
+
+// derivedImage 为一张待写入存储的衍生图.
+type derivedImage struct {
+	// name 为元数据中的名称，如 thumb、thumb.webp
+	name string
+	key  string
+	mime string
+	data []byte
+}
+
+// imageProcessor 在图片写入存储前去除元数据，并在写入后生成衍生图.
+type imageProcessor struct {
+	strip     bool
+	variants  []*opt.ImageVariant
+	webp      bool
+	quality   int
+	maxPixels int64
+}
+
+// newImageProcessor 根据配置创建 imageProcessor，未启用时返回 nil.
+func newImageProcessor(cfg *opt.ImageOptions) *imageProcessor {
+	if cfg == nil || !cfg.Enabled {
+		return nil
+	}
+	p := &imageProcessor{
+		strip:     cfg.StripMetadata,
+		webp:      cfg.WebP,
+		quality:   cfg.Quality,
+		maxPixels: cfg.MaxPixels,
+	}
+	for _, v := range cfg.Variants {
+		if v != nil {
+			p.variants = append(p.variants, v)
+		}
+	}
+	if p.quality < 1 || p.quality > 100 {
+		p.quality = jpeg.DefaultQuality
+	}
+	return p
+}
+
+// handles 判断是否处理该类型的图片.
+func (p *imageProcessor) handles(mimeType string) bool {
+	if p == nil {
+		return false
+	}
+	switch mimeType {
+	case "image/jpeg", "image/png", "image/gif", "image/webp":
+		return true
+	}
+	return false
+}
+
+// sanitize 就地去除 filePath 对应图片的元数据；JPEG 带有 EXIF 方向时按方向摆正后重新编码，
+// 避免去除方向信息后图片显示为旋转状态. 返回文件是否被修改；图片结构损坏时返回 ErrInvalidImage.
+func (p *imageProcessor) sanitize(filePath string, mimeType string) (bool, error) {
+	if !p.handles(mimeType) || !p.strip {
+		return false, nil
+	}
+
+	src, err := os.Open(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer src.Close()
+
+	tmpPath := filePath + ".strip"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return false, err
+	}
+	orientation, ok, err := stripMetadata(dst, src, mimeType)
+	_ = dst.Close()
+	if err == nil && ok && orientation > 1 {
+		err = p.reorient(tmpPath, orientation)
+	}
+	if err != nil || !ok {
+		_ = os.Remove(tmpPath)
+		if errors.Is(err, errMalformedImage) {
+			return false, errno.ErrInvalidImage
+		}
+		return false, err
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return false, err
+	}
+	return true, nil
+}
+
+// reorient 将 JPEG 按 EXIF 方向摆正并重新编码；超过像素上限时不重新编码，写回只含方向的 EXIF.
+func (p *imageProcessor) reorient(filePath string, orientation int) error {
+	img, err := p.decode(filePath)
+	if err != nil {
+		return err
+	}
+	if img == nil {
+		return keepOrientation(filePath, orientation)
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, applyOrientation(img, orientation), &jpeg.Options{Quality: p.quality}); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, buf.Bytes(), 0o644)
+}
+
+// keepOrientation 为已去除元数据的 JPEG 写回方向信息.
+func keepOrientation(filePath string, orientation int) error {
+	src, err := os.Open(filePath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmpPath := filePath + ".orient"
+	dst, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	err = writeJPEGOrientation(dst, src, orientation)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, filePath)
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+	}
+	return err
+}
+
+// decode 解码图片；像素数超过上限时返回 nil, nil.
+func (p *imageProcessor) decode(filePath string) (image.Image, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	cfg, _, err := image.DecodeConfig(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedImage, err)
+	}
+	if p.maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > p.maxPixels {
+		return nil, nil
+	}
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errMalformedImage, err)
+	}
+	return img, nil
+}
+
+// derive 为 key 对应的图片生成衍生图，并返回需要合并到 UploadedObject.Metadata 的原图尺寸.
+// 原图不超过衍生图尺寸时不生成该衍生图，元数据中的 URL 由调用方指向原图.
+func (p *imageProcessor) derive(ctx context.Context, filePath string, key string, mimeType string) ([]*derivedImage, map[string]string) {
+	if !p.handles(mimeType) || (len(p.variants) == 0 && !p.webp) {
+		return nil, nil
+	}
+
+	img, err := p.decode(filePath)
+	if err != nil || img == nil {
+		log.W(ctx).Warnw("Skip image derivatives", "key", key, "err", err)
+		return nil, nil
+	}
+	// 未去除元数据时原图仍保留 EXIF 方向，衍生图不携带元数据，因此需要先摆正
+	if mimeType == "image/jpeg" && !p.strip {
+		if f, err := os.Open(filePath); err == nil {
+			img = applyOrientation(img, jpegOrientation(f))
+			_ = f.Close()
+		}
+	}
+
+	bounds := img.Bounds()
+	metadata := map[string]string{
+		metadataWidth:  strconv.Itoa(bounds.Dx()),
+		metadataHeight: strconv.Itoa(bounds.Dy()),
+	}
+
+	var derived []*derivedImage
+	for _, v := range p.variants {
+		w, h := fitSize(bounds.Dx(), bounds.Dy(), v.Width, v.Height)
+		variant := img
+		if w != bounds.Dx() || h != bounds.Dy() {
+			dst := image.NewRGBA(image.Rect(0, 0, w, h))
+			draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+			variant = dst
+
+			d, err := p.encode(v.Name, key, dst)
+			if err != nil {
+				log.W(ctx).Warnw("Failed to encode image variant", "key", key, "variant", v.Name, "err", err)
+				continue
+			}
+			derived = append(derived, d)
+		}
+
+		// 原图本身为 WebP 且无需缩放时直接复用原图
+		if p.webp && (variant != img || mimeType != "image/webp") {
+			d, err := encodeWebP(v.Name, key, variant)
+			if err != nil {
+				log.W(ctx).Warnw("Failed to encode webp variant", "key", key, "variant", v.Name, "err", err)
+				continue
+			}
+			derived = append(derived, d)
+		}
+	}
+	return derived, metadata
+}
+
+// encode 按原图是否透明选择 JPEG 或 PNG 编码衍生图.
+func (p *imageProcessor) encode(name string, key string, img *image.RGBA) (*derivedImage, error) {
+	var buf bytes.Buffer
+	if img.Opaque() {
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: p.quality}); err != nil {
+			return nil, err
+		}
+		return &derivedImage{name: name, key: derivativeKey(key, name, ".jpg"), mime: "image/jpeg", data: buf.Bytes()}, nil
+	}
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return &derivedImage{name: name, key: derivativeKey(key, name, ".png"), mime: "image/png", data: buf.Bytes()}, nil
+}
+
+// encodeWebP 使用纯 Go 的无损编码器生成 WebP.
+func encodeWebP(name string, key string, img image.Image) (*derivedImage, error) {
+	var buf bytes.Buffer
+	if err := nativewebp.Encode(&buf, img, nil); err != nil {
+		return nil, err
+	}
+	return &derivedImage{name: name + metadataWebPSuffix, key: derivativeKey(key, name, ".webp"), mime: "image/webp", data: buf.Bytes()}, nil
+}
+
+// variantMetadata 将衍生图 URL 写入元数据；未生成的衍生图（原图已足够小）指向原图.
+func (p *imageProcessor) variantMetadata(metadata map[string]string, derived []*derivedImage, originalURL string, urlOf func(key string) string) {
+	if len(metadata) == 0 {
+		return
+	}
+	generated := make(map[string]string, len(derived))
+	for _, d := range derived {
+		generated[d.name] = urlOf(d.key)
+	}
+	for _, v := range p.variants {
+		metadata[metadataVariantPrefix+v.Name] = originalURL
+		if u, ok := generated[v.Name]; ok {
+			metadata[metadataVariantPrefix+v.Name] = u
+		}
+		if p.webp {
+			name := v.Name + metadataWebPSuffix
+			metadata[metadataVariantPrefix+name] = originalURL
+			if u, ok := generated[name]; ok {
+				metadata[metadataVariantPrefix+name] = u
+			}
+		}
+	}
+}
+
+// derivativeKey 返回衍生图的对象键，如 2025/01/02/abcd.png 的 thumb 为 2025/01/02/abcd@thumb.jpg.
+func derivativeKey(key string, name string, ext string) string {
+	return DerivativePrefix(key) + name + ext
+}
+
+// DerivativePrefix 返回对象 key 所有衍生图共同的键前缀，删除对象与追踪引用时据此匹配衍生图.
+func DerivativePrefix(key string) string {
+	return strings.TrimSuffix(key, path.Ext(key)) + derivativeSeparator
+}
+
+// DerivativeOf 解析衍生图键，返回原对象键去掉扩展名后的部分与衍生图前缀（即原对象的 DerivativePrefix），
+// key 不是衍生图时返回 false.
+func DerivativeOf(key string) (stem string, prefix string, ok bool) {
+	i := strings.LastIndex(key, derivativeSeparator)
+	if i < 0 {
+		return "", "", false
+	}
+	return key[:i], key[:i+len(derivativeSeparator)], true
+}
+
+// fitSize 计算按比例缩放到不超过 maxW x maxH 的尺寸（0 表示不限制），不放大.
+func fitSize(w int, h int, maxW int, maxH int) (int, int) {
+	scale := 1.0
+	if maxW > 0 && w > maxW {
+		scale = float64(maxW) / float64(w)
+	}
+	if maxH > 0 && h > maxH {
+		scale = min(scale, float64(maxH)/float64(h))
+	}
+	if scale >= 1 {
+		return w, h
+	}
+	return max(1, int(float64(w)*scale+0.5)), max(1, int(float64(h)*scale+0.5))
+}
+
+// applyOrientation 按 EXIF 方向（1-8）变换图片，使其以正确方向显示.
+func applyOrientation(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	// 5-8 需要交换宽高
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // 水平翻转
+				dx, dy = w-1-x, y
+			case 3: // 旋转 180°
+				dx, dy = w-1-x, h-1-y
+			case 4: // 垂直翻转
+				dx, dy = x, h-1-y
+			case 5: // 沿左上-右下对角线翻转
+				dx, dy = y, x
+			case 6: // 顺时针旋转 90°
+				dx, dy = h-1-y, x
+			case 7: // 沿右上-左下对角线翻转
+				dx, dy = h-1-y, w-1-x
+			case 8: // 逆时针旋转 90°
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
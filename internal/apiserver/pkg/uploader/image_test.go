@@ -0,0 +1,191 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// gpsMarker 模拟 EXIF 中的位置信息，用于检查是否被去除.
+const gpsMarker = "GPS:31.2304N,121.4737E"
+
+func testImage(w, h int, alpha uint8) *image.NRGBA {
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: alpha})
+		}
+	}
+	return img
+}
+
+// jpegWithEXIF 生成带 EXIF（方向与位置信息）的 JPEG.
+func jpegWithEXIF(t *testing.T, w, h int, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, testImage(w, h, 255), nil))
+
+	// TIFF 头 + IFD0（仅 Orientation 一项）+ 附加数据
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	payload := append(append([]byte("Exif\x00\x00"), tiff...), gpsMarker...)
+
+	app1 := []byte{0xFF, jpegAPP1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(payload)+2))
+	app1 = append(app1, payload...)
+
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app1...), data[2:]...)
+}
+
+func newImageTestUploader(t *testing.T, webp bool) *localUploader {
+	cfg := opt.NewUploadOptions()
+	cfg.Local.BaseDir = t.TempDir()
+	cfg.Image.WebP = webp
+	cfg.Image.Variants = []*opt.ImageVariant{{Name: "thumb", Width: 16, Height: 16}, {Name: "medium", Width: 1280}}
+	l, err := newLocalUploader(cfg)
+	require.NoError(t, err)
+	return l
+}
+
+func TestLocalUploader_ImageJPEG(t *testing.T) {
+	l := newImageTestUploader(t, true)
+
+	obj, err := l.Upload(context.Background(), &UploadInput{Filename: "photo.jpg"}, bytes.NewReader(jpegWithEXIF(t, 40, 20, 6)))
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", obj.MIME)
+
+	// 元数据已去除，且按方向（顺时针 90°）摆正
+	stored, err := os.ReadFile(filepath.Join(l.cfg.Local.BaseDir, obj.Key))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), gpsMarker)
+	assert.NotContains(t, string(stored), "Exif")
+	assert.Equal(t, int64(len(stored)), obj.Size)
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, []int{20, 40}, []int{cfg.Width, cfg.Height})
+	assert.Equal(t, "20", obj.Metadata["width"])
+	assert.Equal(t, "40", obj.Metadata["height"])
+
+	// thumb 缩放到 8x16；medium 无需缩放，指向原图
+	thumbKey := derivativeKey(obj.Key, "thumb", ".jpg")
+	assert.Equal(t, l.publicURL(thumbKey), obj.Metadata["variant.thumb"])
+	thumb, err := os.ReadFile(filepath.Join(l.cfg.Local.BaseDir, thumbKey))
+	require.NoError(t, err)
+	cfg, err = jpeg.DecodeConfig(bytes.NewReader(thumb))
+	require.NoError(t, err)
+	assert.Equal(t, []int{8, 16}, []int{cfg.Width, cfg.Height})
+	assert.Equal(t, obj.URL, obj.Metadata["variant.medium"])
+	assert.Equal(t, l.publicURL(derivativeKey(obj.Key, "thumb", ".webp")), obj.Metadata["variant.thumb.webp"])
+	assert.Equal(t, l.publicURL(derivativeKey(obj.Key, "medium", ".webp")), obj.Metadata["variant.medium.webp"])
+
+	// 删除对象时一并删除衍生图
+	require.NoError(t, l.Remove(context.Background(), obj.Key))
+	matches, _ := filepath.Glob(filepath.Join(l.cfg.Local.BaseDir, filepath.Dir(obj.Key), "*"))
+	assert.Empty(t, matches)
+}
+
+func TestLocalUploader_ImageOverPixelLimit(t *testing.T) {
+	l := newImageTestUploader(t, false)
+	l.images.maxPixels = 100
+
+	obj, err := l.Upload(context.Background(), &UploadInput{Filename: "photo.jpg"}, bytes.NewReader(jpegWithEXIF(t, 40, 20, 6)))
+	require.NoError(t, err)
+
+	// 超过像素上限时不重新编码：元数据已去除，但保留方向信息
+	stored, err := os.ReadFile(filepath.Join(l.cfg.Local.BaseDir, obj.Key))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), gpsMarker)
+	assert.Equal(t, 6, jpegOrientation(bytes.NewReader(stored)))
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(stored))
+	require.NoError(t, err)
+	assert.Equal(t, []int{40, 20}, []int{cfg.Width, cfg.Height})
+}
+
+func TestLocalUploader_ImagePNG(t *testing.T) {
+	l := newImageTestUploader(t, false)
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(32, 32, 128)))
+	data := buf.Bytes()
+	// 在 IEND 之前插入 tEXt 块
+	text := []byte("Comment\x00" + gpsMarker)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(append(append(chunk, "tEXt"...), text...), 0, 0, 0, 0)
+	data = append(append(append([]byte{}, data[:len(data)-12]...), chunk...), data[len(data)-12:]...)
+
+	obj, err := l.Upload(context.Background(), &UploadInput{Filename: "a.png"}, bytes.NewReader(data))
+	require.NoError(t, err)
+
+	stored, err := os.ReadFile(filepath.Join(l.cfg.Local.BaseDir, obj.Key))
+	require.NoError(t, err)
+	assert.NotContains(t, string(stored), gpsMarker)
+	_, err = png.Decode(bytes.NewReader(stored))
+	require.NoError(t, err)
+
+	// 半透明图片的衍生图使用 PNG
+	assert.Equal(t, l.publicURL(derivativeKey(obj.Key, "thumb", ".png")), obj.Metadata["variant.thumb"])
+	_, ok := obj.Metadata["variant.thumb.webp"]
+	assert.False(t, ok)
+}
+
+func TestLocalUploader_ImageMalformed(t *testing.T) {
+	l := newImageTestUploader(t, false)
+
+	// 以 JPEG 文件头开头但结构损坏
+	_, err := l.Upload(context.Background(), &UploadInput{Filename: "a.jpg"}, bytes.NewReader([]byte("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00truncated")))
+	assert.ErrorIs(t, err, errno.ErrInvalidImage)
+}
+
+func TestFitSize(t *testing.T) {
+	for _, tc := range []struct{ w, h, maxW, maxH, wantW, wantH int }{
+		{4000, 3000, 320, 320, 320, 240},
+		{3000, 4000, 320, 320, 240, 320},
+		{200, 100, 320, 320, 200, 100},
+		{4000, 3000, 1280, 0, 1280, 960},
+		{4000, 1, 320, 320, 320, 1},
+	} {
+		w, h := fitSize(tc.w, tc.h, tc.maxW, tc.maxH)
+		assert.Equal(t, []int{tc.wantW, tc.wantH}, []int{w, h})
+	}
+}
+
+func TestS3Uploader_Image(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "proxy")
+	u.images = newImageProcessor(&opt.ImageOptions{Enabled: true, StripMetadata: true, Variants: []*opt.ImageVariant{{Name: "thumb", Width: 16}}})
+
+	obj, err := u.Upload(context.Background(), &UploadInput{Filename: "photo.jpg"}, bytes.NewReader(jpegWithEXIF(t, 40, 20, 1)))
+	require.NoError(t, err)
+	stored := fake.objects["miniblog/"+obj.Key]
+	assert.NotContains(t, string(stored), gpsMarker)
+	assert.Equal(t, int64(len(stored)), obj.Size)
+
+	thumbKey := derivativeKey(obj.Key, "thumb", ".jpg")
+	assert.Equal(t, u.publicURL(thumbKey), obj.Metadata["variant.thumb"])
+	assert.Contains(t, fake.objects, "miniblog/"+thumbKey)
+
+	require.NoError(t, u.Remove(context.Background(), obj.Key))
+	assert.Empty(t, fake.objects)
+}
@@ -0,0 +1,302 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// errMalformedImage 表示图片结构无法解析，调用方应拒绝该文件.
+var errMalformedImage = errors.New("malformed image")
+
+// JPEG 标记.
+const (
+	jpegSOI  = 0xD8
+	jpegEOI  = 0xD9
+	jpegSOS  = 0xDA
+	jpegAPP1 = 0xE1
+	// APP13 保存 Photoshop/IPTC 信息，可能包含作者与位置
+	jpegAPP13 = 0xED
+	jpegCOM   = 0xFE
+)
+
+// stripJPEG 去除 JPEG 中的 APP1（EXIF/XMP）、APP13（IPTC）与注释段，其余段（含 ICC 色彩配置）原样保留.
+// 返回被去除的 EXIF 中记录的方向（1-8），没有方向信息时返回 1.
+func stripJPEG(dst io.Writer, src io.Reader) (int, error) {
+	orientation := 1
+	br := bufio.NewReader(src)
+
+	var soi [2]byte
+	if _, err := io.ReadFull(br, soi[:]); err != nil || soi[0] != 0xFF || soi[1] != jpegSOI {
+		return 0, errMalformedImage
+	}
+	if _, err := dst.Write(soi[:]); err != nil {
+		return 0, err
+	}
+
+	for {
+		marker, err := readJPEGMarker(br)
+		if err != nil {
+			return 0, err
+		}
+		// 无负载的独立标记
+		if marker == jpegEOI || (marker >= 0xD0 && marker <= 0xD7) || marker == 0x01 {
+			if _, err := dst.Write([]byte{0xFF, marker}); err != nil {
+				return 0, err
+			}
+			if marker == jpegEOI {
+				return orientation, nil
+			}
+			continue
+		}
+
+		var size [2]byte
+		if _, err := io.ReadFull(br, size[:]); err != nil {
+			return 0, errMalformedImage
+		}
+		n := int(binary.BigEndian.Uint16(size[:]))
+		if n < 2 {
+			return 0, errMalformedImage
+		}
+		payload := make([]byte, n-2)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return 0, errMalformedImage
+		}
+
+		switch marker {
+		case jpegAPP1:
+			if o := exifOrientation(payload); o > 0 {
+				orientation = o
+			}
+			continue
+		case jpegAPP13, jpegCOM:
+			continue
+		}
+
+		if _, err := dst.Write(append([]byte{0xFF, marker}, size[:]...)); err != nil {
+			return 0, err
+		}
+		if _, err := dst.Write(payload); err != nil {
+			return 0, err
+		}
+		// SOS 之后为熵编码数据，直接复制到文件末尾
+		if marker == jpegSOS {
+			if _, err := io.Copy(dst, br); err != nil {
+				return 0, err
+			}
+			return orientation, nil
+		}
+	}
+}
+
+// writeJPEGOrientation 复制 JPEG，并在 SOI 之后写入只含 Orientation 标签的 APP1 段.
+// 用于无法重新编码的图片：去除元数据后仍保留方向信息.
+func writeJPEGOrientation(dst io.Writer, src io.Reader, orientation int) error {
+	var soi [2]byte
+	if _, err := io.ReadFull(src, soi[:]); err != nil || soi[0] != 0xFF || soi[1] != jpegSOI {
+		return errMalformedImage
+	}
+
+	// TIFF 头（大端）+ IFD0（仅 Orientation 一项，类型 SHORT）+ 下一个 IFD 偏移 0
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, uint16(orientation))
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+
+	segment := []byte{0xFF, jpegSOI, 0xFF, jpegAPP1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	if _, err := dst.Write(append(segment, payload...)); err != nil {
+		return err
+	}
+	_, err := io.Copy(dst, src)
+	return err
+}
+
+// readJPEGMarker 读取下一个标记，跳过填充字节 0xFF.
+func readJPEGMarker(br *bufio.Reader) (byte, error) {
+	b, err := br.ReadByte()
+	if err != nil || b != 0xFF {
+		return 0, errMalformedImage
+	}
+	for {
+		if b, err = br.ReadByte(); err != nil {
+			return 0, errMalformedImage
+		}
+		if b != 0xFF {
+			return b, nil
+		}
+	}
+}
+
+// jpegOrientation 返回 JPEG 的 EXIF 方向（1-8），不修改内容.
+func jpegOrientation(src io.Reader) int {
+	o, err := stripJPEG(io.Discard, src)
+	if err != nil {
+		return 1
+	}
+	return o
+}
+
+// exifOrientation 解析 APP1 负载中 IFD0 的 Orientation（0x0112）标签，非 EXIF 段或无该标签时返回 0.
+func exifOrientation(payload []byte) int {
+	tiff, ok := bytes.CutPrefix(payload, []byte("Exif\x00\x00"))
+	if !ok || len(tiff) < 8 {
+		return 0
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:8]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(tiff) {
+			return 0
+		}
+		// 类型 3 为 SHORT，值直接保存在值域的前 2 字节
+		if order.Uint16(tiff[entry:]) == 0x0112 && order.Uint16(tiff[entry+2:]) == 3 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 0
+		}
+	}
+	return 0
+}
+
+// pngSignature 为 PNG 文件头.
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks 为需要去除的 PNG 元数据块.
+var pngMetadataChunks = map[string]bool{"eXIf": true, "tEXt": true, "zTXt": true, "iTXt": true, "tIME": true}
+
+// stripPNG 去除 PNG 中的 EXIF 与文本块，其余块原样保留.
+func stripPNG(dst io.Writer, src io.Reader) error {
+	br := bufio.NewReader(src)
+
+	sig := make([]byte, len(pngSignature))
+	if _, err := io.ReadFull(br, sig); err != nil || !bytes.Equal(sig, pngSignature) {
+		return errMalformedImage
+	}
+	if _, err := dst.Write(sig); err != nil {
+		return err
+	}
+
+	for {
+		var header [8]byte
+		if _, err := io.ReadFull(br, header[:]); err != nil {
+			return errMalformedImage
+		}
+		n := int64(binary.BigEndian.Uint32(header[:4]))
+		typ := string(header[4:8])
+
+		// 数据与 4 字节 CRC
+		if pngMetadataChunks[typ] {
+			if _, err := io.CopyN(io.Discard, br, n+4); err != nil {
+				return errMalformedImage
+			}
+			continue
+		}
+		if _, err := dst.Write(header[:]); err != nil {
+			return err
+		}
+		if _, err := io.CopyN(dst, br, n+4); err != nil {
+			return errMalformedImage
+		}
+		if typ == "IEND" {
+			return nil
+		}
+	}
+}
+
+// VP8X 扩展头中的元数据标志位.
+const (
+	vp8xFlagXMP  = 0x04
+	vp8xFlagEXIF = 0x08
+)
+
+// stripWebP 去除 WebP 中的 EXIF 与 XMP 块，并同步更新 VP8X 标志与 RIFF 长度.
+// WebP 容器较小且需要回写长度，因此整体读入内存处理.
+func stripWebP(dst io.Writer, src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return errMalformedImage
+	}
+
+	out := bytes.NewBuffer(make([]byte, 0, len(data)))
+	out.Write(data[:12])
+	for pos := 12; pos < len(data); {
+		if pos+8 > len(data) {
+			return errMalformedImage
+		}
+		fourcc := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		end := pos + 8 + size + size%2
+		if size < 0 || end > len(data) {
+			return errMalformedImage
+		}
+
+		switch fourcc {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := bytes.Clone(data[pos:end])
+			if size > 0 {
+				chunk[8] &^= vp8xFlagEXIF | vp8xFlagXMP
+			}
+			out.Write(chunk)
+		default:
+			out.Write(data[pos:end])
+		}
+		pos = end
+	}
+
+	result := out.Bytes()
+	binary.LittleEndian.PutUint32(result[4:8], uint32(len(result)-8))
+	_, err = dst.Write(result)
+	return err
+}
+
+// stripMetadata 按类型去除图片元数据，返回 JPEG 的 EXIF 方向（其余类型为 1）.
+// 不支持的类型返回 ok=false，调用方应保留原文件.
+func stripMetadata(dst io.Writer, src io.Reader, mimeType string) (orientation int, ok bool, err error) {
+	switch mimeType {
+	case "image/jpeg":
+		orientation, err = stripJPEG(dst, src)
+	case "image/png":
+		orientation, err = 1, stripPNG(dst, src)
+	case "image/webp":
+		orientation, err = 1, stripWebP(dst, src)
+	default:
+		return 1, false, nil
+	}
+	if err != nil {
+		return 0, true, fmt.Errorf("strip %s metadata: %w", mimeType, err)
+	}
+	return orientation, true, nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+)
+
+// DefaultKeyTemplate 为未配置 keyTemplate 时使用的对象键模板.
+const DefaultKeyTemplate = "{date:2006/01/02}/{sha256:16}{ext}"
+
+// 对象键模板支持的占位符：
+//
+//	{date} / {date:LAYOUT}  上传时间，LAYOUT 为 Go 时间格式，默认 2006/01/02
+//	{sha256} / {sha256:N}   内容 sha256 的前 N 位（1..64），默认全部
+//	{user}                  上传者 ID（contextx.UserID），匿名上传为 anonymous
+//	{scene}                 上传场景，未指定时为 default
+//	{filename}              清理后的原始文件名（不含扩展名），为空时为 file
+//	{rand} / {rand:N}       N 位随机十六进制串（1..32），默认 16 位
+//	{ext}                   扩展名（含点），与内容类型不一致时以内容类型为准
+//
+// 模板必须包含 {sha256} 或 {rand}，且二者合计不少于 minUniqueLen 位，避免不同内容生成相同的对象键.
+const (
+	keyFieldDate     = "date"
+	keyFieldSHA256   = "sha256"
+	keyFieldUser     = "user"
+	keyFieldScene    = "scene"
+	keyFieldFilename = "filename"
+	keyFieldRand     = "rand"
+	keyFieldExt      = "ext"
+)
+
+const (
+	// maxFilenameLen 为 {filename} 保留的最大长度.
+	maxFilenameLen = 64
+	// minUniqueLen 为模板中 {sha256} 与 {rand} 合计的最少位数（48 bit）.
+	minUniqueLen = 12
+)
+
+// KeyValues 为渲染对象键所需的变量.
+type KeyValues struct {
+	Time     time.Time
+	SHA256   string
+	UserID   string
+	Scene    string
+	Filename string
+	MIME     string
+}
+
+// keySegment 为模板中的一段：字面量或占位符.
+type keySegment struct {
+	literal string
+	field   string
+	layout  string
+	n       int
+}
+
+// KeyTemplate 为解析后的对象键模板，可并发使用.
+type KeyTemplate struct {
+	raw      string
+	segments []keySegment
+}
+
+// ParseKeyTemplate 解析并校验对象键模板，tmpl 为空时使用 DefaultKeyTemplate.
+func ParseKeyTemplate(tmpl string) (*KeyTemplate, error) {
+	tmpl = strings.TrimSpace(tmpl)
+	if tmpl == "" {
+		tmpl = DefaultKeyTemplate
+	}
+
+	t := &KeyTemplate{raw: tmpl}
+	unique := 0
+	for rest := tmpl; rest != ""; {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			open = len(rest)
+		}
+		if literal := rest[:open]; literal != "" {
+			if err := validateKeyLiteral(literal); err != nil {
+				return nil, err
+			}
+			t.segments = append(t.segments, keySegment{literal: literal})
+		}
+		if open == len(rest) {
+			break
+		}
+
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed placeholder in key template %q", tmpl)
+		}
+		seg, err := parseKeyPlaceholder(rest[open+1 : open+end])
+		if err != nil {
+			return nil, err
+		}
+		if seg.field == keyFieldSHA256 || seg.field == keyFieldRand {
+			unique += seg.n
+		}
+		t.segments = append(t.segments, seg)
+		rest = rest[open+end+1:]
+	}
+
+	if unique == 0 {
+		return nil, fmt.Errorf("key template %q must contain {sha256} or {rand}", tmpl)
+	}
+	if unique < minUniqueLen {
+		return nil, fmt.Errorf("{sha256} and {rand} in key template %q must be at least %d characters in total", tmpl, minUniqueLen)
+	}
+	if strings.HasPrefix(tmpl, "/") {
+		return nil, fmt.Errorf("key template %q must not start with /", tmpl)
+	}
+	return t, nil
+}
+
+// validateKeyLiteral 限制模板中的字面量字符，并禁止 . 与 .. 路径段.
+func validateKeyLiteral(literal string) error {
+	for _, r := range literal {
+		if !isKeyChar(r) && r != '/' {
+			return fmt.Errorf("invalid character %q in key template", r)
+		}
+	}
+	for _, part := range strings.Split(literal, "/") {
+		if part == "." || part == ".." {
+			return fmt.Errorf("key template must not contain %q path segments", part)
+		}
+	}
+	return nil
+}
+
+func parseKeyPlaceholder(expr string) (keySegment, error) {
+	field, arg, hasArg := strings.Cut(expr, ":")
+	seg := keySegment{field: field}
+
+	switch field {
+	case keyFieldDate:
+		seg.layout = "2006/01/02"
+		if hasArg {
+			if arg == "" {
+				return seg, fmt.Errorf("empty layout in {date:}")
+			}
+			seg.layout = arg
+		}
+	case keyFieldSHA256, keyFieldRand:
+		maxLen := 64
+		seg.n = 64
+		if field == keyFieldRand {
+			seg.n, maxLen = 16, 32
+		}
+		if hasArg {
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 || n > maxLen {
+				return seg, fmt.Errorf("invalid length in {%s}, must be between 1 and %d", expr, maxLen)
+			}
+			seg.n = n
+		}
+	case keyFieldUser, keyFieldScene, keyFieldFilename, keyFieldExt:
+		if hasArg {
+			return seg, fmt.Errorf("placeholder {%s} does not take an argument", field)
+		}
+	default:
+		return seg, fmt.Errorf("unknown placeholder {%s} in key template", expr)
+	}
+	return seg, nil
+}
+
+// String 返回原始模板.
+func (t *KeyTemplate) String() string {
+	return t.raw
+}
+
+// Execute 渲染对象键；各变量均经过清理，结果不会以 / 开头，也不包含 .. 路径段.
+func (t *KeyTemplate) Execute(v *KeyValues) string {
+	var b strings.Builder
+	for _, seg := range t.segments {
+		switch seg.field {
+		case "":
+			b.WriteString(seg.literal)
+		case keyFieldDate:
+			ts := v.Time
+			if ts.IsZero() {
+				ts = time.Now()
+			}
+			b.WriteString(sanitizeKeyPart(ts.Format(seg.layout), true))
+		case keyFieldSHA256:
+			b.WriteString(truncate(v.SHA256, seg.n))
+		case keyFieldUser:
+			b.WriteString(orDefault(sanitizeKeyPart(v.UserID, false), "anonymous"))
+		case keyFieldScene:
+			b.WriteString(orDefault(sanitizeKeyPart(v.Scene, false), "default"))
+		case keyFieldFilename:
+			name := strings.TrimSuffix(filepath.Base(v.Filename), filepath.Ext(v.Filename))
+			b.WriteString(orDefault(truncate(sanitizeKeyPart(name, false), maxFilenameLen), "file"))
+		case keyFieldRand:
+			b.WriteString(randomHex(seg.n))
+		case keyFieldExt:
+			b.WriteString(fileExt(v.Filename, v.MIME))
+		}
+	}
+
+	// 清理多余的分隔符，如 {scene}/ 前缀在结果中产生的 //
+	key := path.Clean("/" + b.String())
+	return strings.TrimPrefix(key, "/")
+}
+
+// isKeyChar 判断字符是否可直接出现在对象键中（URL 与文件系统均安全）.
+func isKeyChar(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.'
+}
+
+// sanitizeKeyPart 将不安全字符替换为 -，allowSlash 为 false 时 / 同样被替换.
+// 结果中的 . 与 .. 路径段会被替换，避免越出存储目录.
+func sanitizeKeyPart(s string, allowSlash bool) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case isKeyChar(r), allowSlash && r == '/':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('-')
+		}
+	}
+
+	parts := strings.Split(b.String(), "/")
+	for i, part := range parts {
+		if part == "." || part == ".." {
+			parts[i] = "-"
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+func orDefault(s string, def string) string {
+	if strings.Trim(s, "-") == "" {
+		return def
+	}
+	return s
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, (n+1)/2)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)[:n]
+}
+
+// fileExt 返回对象键使用的扩展名.
+// 扩展名与内容类型不一致时（如 png 内容命名为 .html）以内容类型为准，避免静态服务按错误类型返回.
+func fileExt(filename string, mimeType string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if mimeType != "" && baseMIME(mime.TypeByExtension(ext)) != baseMIME(mimeType) {
+		ext = extensionByType(mimeType)
+	}
+	if ext != "" && sanitizeKeyPart(ext, false) != ext {
+		return ""
+	}
+	return ext
+}
+
+// objectKey 按模板为上传对象生成对象键，{user} 取自 contextx.UserID.
+// 内容哈希未知时（如 S3 分片上传未提供 sha256），以会话 ID 代替 sumHex.
+func (t *KeyTemplate) objectKey(ctx context.Context, filename string, mimeType string, scene string, sumHex string) string {
+	return t.Execute(&KeyValues{
+		Time:     time.Now(),
+		SHA256:   sumHex,
+		UserID:   contextx.UserID(ctx),
+		Scene:    scene,
+		Filename: filename,
+		MIME:     mimeType,
+	})
+}
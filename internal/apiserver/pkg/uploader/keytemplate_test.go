@@ -0,0 +1,91 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+)
+
+func TestParseKeyTemplate_Invalid(t *testing.T) {
+	for _, tmpl := range []string{
+		"{date}/{ext}",           // 缺少 {sha256} 或 {rand}
+		"{sha256:0}",             // 长度越界
+		"{sha256:65}",            // 长度越界
+		"{rand:33}",              // 长度越界
+		"{date}/{sha256:1}",      // 唯一部分过短
+		"{date}/{rand:1}",        // 唯一部分过短
+		"{sha256:6}-{rand:5}",    // 唯一部分过短
+		"{sha256",                // 未闭合
+		"{hash}",                 // 未知占位符
+		"{user:1}/{sha256}",      // 不接受参数
+		"/abs/{sha256}",          // 绝对路径
+		"../{sha256}",            // 越出存储目录
+		"a b/{sha256}",           // 非法字符
+		"{date:}/{sha256}{ext}",  // 空的日期格式
+		"uploads\\{sha256}{ext}", // 反斜杠
+	} {
+		_, err := ParseKeyTemplate(tmpl)
+		assert.Error(t, err, tmpl)
+	}
+}
+
+func TestKeyTemplate_Execute(t *testing.T) {
+	sum := strings.Repeat("a", 64)
+	ts := time.Date(2025, 3, 9, 10, 0, 0, 0, time.UTC)
+
+	tmpl, err := ParseKeyTemplate("")
+	require.NoError(t, err)
+	assert.Equal(t, "2025/03/09/aaaaaaaaaaaaaaaa.png", tmpl.Execute(&KeyValues{Time: ts, SHA256: sum, Filename: "x.html", MIME: "image/png"}))
+	assert.Equal(t, "2025/03/09/aaaaaaaaaaaaaaaa.jpg", tmpl.Execute(&KeyValues{Time: ts, SHA256: sum, Filename: "x.JPG", MIME: "image/jpeg"}))
+	assert.Equal(t, "2025/03/09/aaaaaaaaaaaaaaaa.txt", tmpl.Execute(&KeyValues{Time: ts, SHA256: sum, Filename: "x.txt"}))
+
+	tmpl, err = ParseKeyTemplate("{user}/{scene}/{date:200601}/{filename}-{sha256:8}-{rand:6}{ext}")
+	require.NoError(t, err)
+	key := tmpl.Execute(&KeyValues{Time: ts, SHA256: sum, UserID: "user-abc123", Scene: "avatar", Filename: "../../My Photo.png", MIME: "image/png"})
+	assert.Regexp(t, regexp.MustCompile(`^user-abc123/avatar/202503/My-Photo-aaaaaaaa-[0-9a-f]{6}\.png$`), key)
+
+	// 变量中的路径分隔符与 .. 被清理，匿名上传与未指定场景使用默认值
+	key = tmpl.Execute(&KeyValues{Time: ts, SHA256: sum, Scene: "a/../b", Filename: "..", MIME: "image/png"})
+	assert.Regexp(t, regexp.MustCompile(`^anonymous/a-..-b/202503/file-aaaaaaaa-[0-9a-f]{6}\.png$`), key)
+	assert.True(t, strings.HasPrefix(tmpl.Execute(&KeyValues{Time: ts, SHA256: sum}), "anonymous/default/"))
+}
+
+func TestLocalUpload_NoOverwrite(t *testing.T) {
+	l := newTestUploader(t)
+	ctx := context.Background()
+
+	obj, err := l.Upload(ctx, &UploadInput{Filename: "a.txt"}, strings.NewReader("hello"))
+	require.NoError(t, err)
+	absPath := filepath.Join(l.cfg.Local.BaseDir, obj.Key)
+
+	// 相同内容生成相同的对象键时复用已存在的对象
+	again, err := l.Upload(ctx, &UploadInput{Filename: "a.txt"}, strings.NewReader("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, obj.Key, again.Key)
+
+	// 对象键被其他内容占用时不覆盖
+	require.NoError(t, os.WriteFile(absPath, []byte("other"), 0o644))
+	_, err = l.Upload(ctx, &UploadInput{Filename: "a.txt"}, strings.NewReader("hello"))
+	assert.ErrorIs(t, err, errno.ErrObjectKeyConflict)
+	data, err := os.ReadFile(absPath)
+	require.NoError(t, err)
+	assert.Equal(t, "other", string(data))
+
+	tmp, err := os.ReadDir(filepath.Join(l.cfg.Local.BaseDir, ".tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tmp)
+}
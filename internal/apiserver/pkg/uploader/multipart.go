@@ -0,0 +1,667 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+const (
+	// sessionFile 为会话元数据文件名.
+	sessionFile = "session.json"
+	// partSuffix 为分片数据文件后缀，分片元数据使用 partMetaSuffix.
+	partSuffix     = ".part"
+	partMetaSuffix = ".json"
+
+	// 以下为配置缺失或解析失败时的兜底值.
+	defaultPartSizeMin = 5 * opt.MiB
+	defaultPartSizeMax = 128 * opt.MiB
+	defaultPartSize    = 8 * opt.MiB
+	defaultMaxParts    = 10000
+	defaultUploadIDTTL = 24 * time.Hour
+)
+
+// uploadIDRegex 限制 uploadID 只能为 32 位十六进制，防止路径穿越.
+var uploadIDRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// sessionMeta 为持久化到磁盘的会话元数据.
+type sessionMeta struct {
+	UploadID string `json:"uploadID"`
+	OwnerID  string `json:"ownerID"`
+	Filename string `json:"filename"`
+	MIME     string `json:"mime"`
+	Scene    string `json:"scene"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+	// Key 为初始化时确定的对象键，未知（如未声明 sha256 的本地会话）时为空
+	Key       string    `json:"key,omitempty"`
+	PartSize  int64     `json:"partSize"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// localMultipart 是基于本地磁盘的分片上传会话管理器.
+//
+// 目录布局：
+//
+//	{tempDir}/{uploadID}/session.json  会话元数据（含过期时间）
+//	{tempDir}/{uploadID}/00001.part    分片数据
+//	{tempDir}/{uploadID}/00001.json    分片元数据（ETag、大小、校验值）
+//
+// 会话信息全部落盘，服务重启后仍可继续上传；过期会话由后台协程定期清理.
+type localMultipart struct {
+	multipartLimits
+
+	l       *localUploader
+	tempDir string
+
+	// locks 为每个会话的读写锁：上传分片持读锁，合并与中止持写锁.
+	locks sync.Map
+}
+
+func newLocalMultipart(l *localUploader) *localMultipart {
+	m := &localMultipart{l: l, multipartLimits: newMultipartLimits(l.cfg.Multipart)}
+	if l.cfg.Multipart != nil {
+		m.tempDir = l.cfg.Multipart.TempDir
+	}
+	return m
+}
+
+// multipartLimits 为各存储后端共用的分片上传限制.
+type multipartLimits struct {
+	enabled     bool
+	partSizeMin int64
+	partSizeMax int64
+	partSize    int64
+	maxParts    int32
+	ttl         time.Duration
+	checksum    string
+}
+
+func newMultipartLimits(cfg *opt.MultipartConfig) multipartLimits {
+	m := multipartLimits{
+		partSizeMin: defaultPartSizeMin,
+		partSizeMax: defaultPartSizeMax,
+		partSize:    defaultPartSize,
+		maxParts:    defaultMaxParts,
+		ttl:         defaultUploadIDTTL,
+		checksum:    "sha256",
+	}
+	if cfg == nil {
+		return m
+	}
+
+	m.enabled = cfg.Enabled
+	if c := strings.ToLower(strings.TrimSpace(cfg.Checksum)); c != "" {
+		m.checksum = c
+	}
+	if n, err := opt.ParseSize(cfg.PartSizeMin); err == nil && n > 0 {
+		m.partSizeMin = n
+	}
+	if n, err := opt.ParseSize(cfg.PartSizeMax); err == nil && n > 0 {
+		m.partSizeMax = n
+	}
+	if n, err := opt.ParseSize(cfg.PartSizeDefault); err == nil && n > 0 {
+		m.partSize = n
+	}
+	if cfg.MaxParts > 0 {
+		m.maxParts = cfg.MaxParts
+	}
+	if d, err := opt.ParseDuration(cfg.UploadIDTTL); err == nil && d > 0 {
+		m.ttl = d
+	}
+	return m
+}
+
+// janitorInterval 返回过期会话的清理间隔：TTL 的 1/4，限制在 [1m, 1h].
+func (m *multipartLimits) janitorInterval() time.Duration {
+	return min(max(m.ttl/4, time.Minute), time.Hour)
+}
+
+// dir 返回会话目录.
+func (m *localMultipart) dir(uploadID string) string {
+	base := m.tempDir
+	if base == "" {
+		base = filepath.Join(m.l.cfg.Local.BaseDir, ".multipart")
+	}
+	return filepath.Join(base, uploadID)
+}
+
+func (m *localMultipart) perm() os.FileMode {
+	return os.FileMode(m.l.cfg.Local.MkdirPerm)
+}
+
+// lock 返回会话对应的读写锁.
+func (m *localMultipart) lock(uploadID string) *sync.RWMutex {
+	mu, _ := m.locks.LoadOrStore(uploadID, &sync.RWMutex{})
+	return mu.(*sync.RWMutex)
+}
+
+// StartJanitor 实现 Janitor 接口：启动后台协程，立即并定期清理过期会话（包括服务重启前遗留的会话），
+// ctx 取消后停止. 未启用分片上传时不做任何事.
+func (l *localUploader) StartJanitor(ctx context.Context) {
+	m := l.multipart
+	if !m.enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(m.janitorInterval())
+		defer ticker.Stop()
+		for {
+			if n, err := m.cleanupExpired(time.Now()); err != nil {
+				log.Errorw("Failed to cleanup expired multipart sessions", "err", err)
+			} else if n > 0 {
+				log.Infow("Cleaned up expired multipart sessions", "count", n)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// cleanupExpired 删除所有已过期的会话目录，返回删除的会话数.
+// 缺失或损坏 session.json 的目录按目录修改时间 + TTL 判断是否过期.
+func (m *localMultipart) cleanupExpired(now time.Time) (int, error) {
+	base := filepath.Dir(m.dir("x"))
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	var count int
+	for _, entry := range entries {
+		if !entry.IsDir() || !uploadIDRegex.MatchString(entry.Name()) {
+			continue
+		}
+
+		expiresAt := time.Time{}
+		if meta, err := m.readSession(entry.Name()); err == nil {
+			expiresAt = meta.ExpiresAt
+		} else if info, err := entry.Info(); err == nil {
+			expiresAt = info.ModTime().Add(m.ttl)
+		}
+		if expiresAt.IsZero() || now.Before(expiresAt) {
+			continue
+		}
+
+		mu := m.lock(entry.Name())
+		mu.Lock()
+		err := os.RemoveAll(filepath.Join(base, entry.Name()))
+		m.locks.Delete(entry.Name())
+		mu.Unlock()
+		if err != nil {
+			log.Errorw("Failed to remove expired multipart session", "uploadID", entry.Name(), "err", err)
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// readSession 从磁盘读取会话元数据.
+func (m *localMultipart) readSession(uploadID string) (*sessionMeta, error) {
+	data, err := os.ReadFile(filepath.Join(m.dir(uploadID), sessionFile))
+	if err != nil {
+		return nil, err
+	}
+	var meta sessionMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	return &meta, nil
+}
+
+// loadSession 读取会话并校验归属与有效期.
+// 会话不存在、已过期或不属于当前用户时统一返回 ErrUploadSessionNotFound，避免泄露会话是否存在.
+func (m *localMultipart) loadSession(ctx context.Context, uploadID string) (*sessionMeta, error) {
+	if !uploadIDRegex.MatchString(uploadID) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+
+	meta, err := m.readSession(uploadID)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.W(ctx).Errorw("Failed to read multipart session", "uploadID", uploadID, "err", err)
+		}
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	if meta.OwnerID != contextx.UserID(ctx) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	if time.Now().After(meta.ExpiresAt) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	return meta, nil
+}
+
+// resolvePartSize 根据文件大小与客户端期望值确定最终分片大小.
+// 期望值会被限制在 [partSizeMin, partSizeMax] 之间，并保证分片数不超过 maxParts.
+func (m *multipartLimits) resolvePartSize(size int64, want int64) (int64, error) {
+	partSize := m.partSize
+	if want > 0 {
+		partSize = want
+	}
+	partSize = max(partSize, m.partSizeMin)
+	partSize = min(partSize, m.partSizeMax)
+
+	if need := (size + int64(m.maxParts) - 1) / int64(m.maxParts); partSize < need {
+		partSize = need
+	}
+	if partSize > m.partSizeMax {
+		return 0, errno.ErrInvalidArgument.WithMessage("file is too large: size %d exceeds %d parts of at most %d bytes", size, m.maxParts, m.partSizeMax)
+	}
+	return partSize, nil
+}
+
+// InitMultipart 创建分片上传会话.
+func (l *localUploader) InitMultipart(ctx context.Context, in *MultipartInput) (*MultipartSession, error) {
+	m := l.multipart
+	if !m.enabled {
+		return nil, errno.ErrMultipartDisabled
+	}
+
+	if err := l.checkDeclared(in.Scene, in.Filename, in.MIME, in.Size); err != nil {
+		return nil, err
+	}
+	partSize, err := m.resolvePartSize(in.Size, in.PartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	meta := &sessionMeta{
+		UploadID:  strings.ReplaceAll(uuid.NewString(), "-", ""),
+		OwnerID:   contextx.UserID(ctx),
+		Filename:  filepath.Base(in.Filename),
+		MIME:      in.MIME,
+		Scene:     in.Scene,
+		Size:      in.Size,
+		SHA256:    strings.ToLower(strings.TrimPrefix(in.SHA256, "sha256:")),
+		PartSize:  partSize,
+		CreatedAt: now,
+		ExpiresAt: now.Add(m.ttl),
+	}
+
+	// 客户端提供了 sha256 时即可预先给出对象键
+	if len(meta.SHA256) == sha256.Size*2 {
+		meta.Key = l.keys.objectKey(ctx, meta.Filename, meta.MIME, meta.Scene, meta.SHA256)
+	}
+
+	dir := m.dir(meta.UploadID)
+	if err := os.MkdirAll(dir, m.perm()); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(meta)
+	if err := writeFileAtomic(filepath.Join(dir, sessionFile), data); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Multipart upload initiated", "uploadID", meta.UploadID, "size", meta.Size, "partSize", partSize)
+
+	return &MultipartSession{
+		UploadID: meta.UploadID,
+		Key:      meta.Key,
+		Mode:     "proxy",
+		PartSize: partSize,
+	}, nil
+}
+
+// UploadPart 保存单个分片，并校验客户端提供的 checksum.
+func (l *localUploader) UploadPart(ctx context.Context, uploadID string, partNumber int32, checksum string, r io.Reader) (*Part, error) {
+	m := l.multipart
+	if partNumber < 1 || partNumber > m.maxParts {
+		return nil, errno.ErrInvalidPart.WithMessage("part number must be between 1 and %d", m.maxParts)
+	}
+
+	mu := m.lock(uploadID)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	meta, err := m.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 先写入临时文件，校验通过后再原子替换，保证重传同一分片时不会读到半截数据
+	dir := m.dir(uploadID)
+	f, err := os.CreateTemp(dir, fmt.Sprintf("%05d-*.tmp", partNumber))
+	if err != nil {
+		return nil, err
+	}
+	tmpPath := f.Name()
+	defer os.Remove(tmpPath)
+
+	md5h, sha256h := md5.New(), sha256.New() //nolint:gosec
+	n, err := io.Copy(io.MultiWriter(f, md5h, sha256h), io.LimitReader(r, meta.PartSize+1))
+	_ = f.Close()
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errno.ErrInvalidPart.WithMessage("part %d is empty", partNumber)
+	}
+	if n > meta.PartSize {
+		return nil, errno.ErrInvalidPart.WithMessage("part %d exceeds the part size of %d bytes", partNumber, meta.PartSize)
+	}
+
+	if err := verifyChecksum(checksum, m.checksum, md5h.Sum(nil), sha256h.Sum(nil)); err != nil {
+		return nil, err
+	}
+
+	part := &Part{
+		PartNumber: partNumber,
+		ETag:       hex.EncodeToString(md5h.Sum(nil)),
+		Size:       n,
+		Checksum:   "sha256:" + hex.EncodeToString(sha256h.Sum(nil)),
+	}
+	if m.checksum == "md5" {
+		part.Checksum = "md5:" + part.ETag
+	}
+
+	if err := os.Rename(tmpPath, m.partPath(uploadID, partNumber)); err != nil {
+		return nil, err
+	}
+	data, _ := json.Marshal(part)
+	if err := writeFileAtomic(m.partMetaPath(uploadID, partNumber), data); err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+// ListParts 返回会话中已上传的分片，按分片号升序.
+func (l *localUploader) ListParts(ctx context.Context, uploadID string) ([]*Part, error) {
+	m := l.multipart
+	mu := m.lock(uploadID)
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if _, err := m.loadSession(ctx, uploadID); err != nil {
+		return nil, err
+	}
+	return m.listParts(uploadID)
+}
+
+func (m *localMultipart) partPath(uploadID string, partNumber int32) string {
+	return filepath.Join(m.dir(uploadID), fmt.Sprintf("%05d%s", partNumber, partSuffix))
+}
+
+func (m *localMultipart) partMetaPath(uploadID string, partNumber int32) string {
+	return filepath.Join(m.dir(uploadID), fmt.Sprintf("%05d%s", partNumber, partMetaSuffix))
+}
+
+// listParts 读取会话目录下所有分片元数据.
+func (m *localMultipart) listParts(uploadID string) ([]*Part, error) {
+	entries, err := os.ReadDir(m.dir(uploadID))
+	if err != nil {
+		return nil, err
+	}
+
+	parts := make([]*Part, 0, len(entries)/2)
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == sessionFile || !strings.HasSuffix(name, partMetaSuffix) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(m.dir(uploadID), name))
+		if err != nil {
+			return nil, err
+		}
+		var part Part
+		if err := json.Unmarshal(data, &part); err != nil {
+			return nil, err
+		}
+		parts = append(parts, &part)
+	}
+
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+// CompleteMultipart 校验分片列表后按序合并，并以与单文件上传相同的对象键规则落盘.
+func (l *localUploader) CompleteMultipart(ctx context.Context, uploadID string, parts []*Part) (*UploadedObject, error) {
+	m := l.multipart
+	mu := m.lock(uploadID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	meta, err := m.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	uploaded, err := m.listParts(uploadID)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := m.selectParts(meta, uploaded, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	// 按序拼接分片到临时文件，同时计算整体 sha256
+	tmpPath, f, err := l.createTemp()
+	if err != nil {
+		return nil, err
+	}
+	hasher := sha256.New()
+	var total int64
+	for _, part := range selected {
+		n, err := appendFile(io.MultiWriter(f, hasher), m.partPath(uploadID, part.PartNumber))
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+			return nil, err
+		}
+		total += n
+	}
+	_ = f.Close()
+
+	sumHex := hex.EncodeToString(hasher.Sum(nil))
+	if meta.Size > 0 && total != meta.Size {
+		_ = os.Remove(tmpPath)
+		return nil, errno.ErrInvalidPart.WithMessage("assembled size %d does not match declared size %d", total, meta.Size)
+	}
+	if meta.SHA256 != "" && meta.SHA256 != sumHex {
+		_ = os.Remove(tmpPath)
+		return nil, errno.ErrChecksumMismatch.WithMessage("sha256 of the assembled file does not match")
+	}
+
+	// 以合并后内容的嗅探结果校验类型
+	mimeType, err := l.sniffFile(meta.Scene, tmpPath, meta.MIME)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+
+	// 预先给出的对象键按声明的类型生成，与嗅探结果不一致时重新生成，保证扩展名正确
+	key := meta.Key
+	if key == "" || baseMIME(meta.MIME) != mimeType {
+		key = l.keys.objectKey(ctx, meta.Filename, mimeType, meta.Scene, sumHex)
+	}
+	obj, err := l.commit(ctx, tmpPath, key, mimeType, sumHex, total, objectMetadata(meta.Scene, meta.Filename))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.RemoveAll(m.dir(uploadID)); err != nil {
+		log.W(ctx).Errorw("Failed to remove multipart session", "uploadID", uploadID, "err", err)
+	}
+	m.locks.Delete(uploadID)
+
+	log.W(ctx).Infow("Multipart upload completed", "uploadID", uploadID, "key", obj.Key, "parts", len(selected), "size", total)
+
+	return obj, nil
+}
+
+// selectParts 根据客户端提交的分片列表确定要合并的分片.
+// 分片号必须严格递增且已上传；若提供了 ETag 必须匹配；除最后一片外每片不得小于 partSizeMin.
+func (m *multipartLimits) selectParts(meta *sessionMeta, uploaded []*Part, requested []*Part) ([]*Part, error) {
+	if len(uploaded) == 0 {
+		return nil, errno.ErrInvalidPart.WithMessage("no parts have been uploaded")
+	}
+	if len(requested) == 0 {
+		requested = uploaded
+	}
+
+	index := make(map[int32]*Part, len(uploaded))
+	for _, part := range uploaded {
+		index[part.PartNumber] = part
+	}
+
+	selected := make([]*Part, 0, len(requested))
+	var last int32
+	for i, rq := range requested {
+		if rq.PartNumber <= last {
+			return nil, errno.ErrInvalidPart.WithMessage("part numbers must be in ascending order without duplicates")
+		}
+		last = rq.PartNumber
+
+		part, ok := index[rq.PartNumber]
+		if !ok {
+			return nil, errno.ErrInvalidPart.WithMessage("part %d has not been uploaded", rq.PartNumber)
+		}
+		if rq.ETag != "" && !strings.EqualFold(strings.Trim(rq.ETag, `"`), part.ETag) {
+			return nil, errno.ErrInvalidPart.WithMessage("etag of part %d does not match", rq.PartNumber)
+		}
+		if i < len(requested)-1 && part.Size < min(m.partSizeMin, meta.PartSize) {
+			return nil, errno.ErrInvalidPart.WithMessage("part %d is smaller than the minimum part size", rq.PartNumber)
+		}
+		selected = append(selected, part)
+	}
+
+	return selected, nil
+}
+
+// AbortMultipart 中止会话并删除所有已上传分片.
+func (l *localUploader) AbortMultipart(ctx context.Context, uploadID string) error {
+	m := l.multipart
+	mu := m.lock(uploadID)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, err := m.loadSession(ctx, uploadID); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(m.dir(uploadID)); err != nil {
+		return err
+	}
+	m.locks.Delete(uploadID)
+
+	log.W(ctx).Infow("Multipart upload aborted", "uploadID", uploadID)
+	return nil
+}
+
+// sniffFile 读取文件头部并校验内容类型.
+func (l *localUploader) sniffFile(scene string, path string, claimed string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(f, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+	return l.checkContent(scene, head[:n], claimed)
+}
+
+// verifyChecksum 校验客户端提供的 checksum.
+// 支持 "sha256:<hex>"、"md5:<hex|base64>"，以及不带前缀的值（按配置的算法解释，Content-MD5 为 base64）.
+func verifyChecksum(checksum string, algorithm string, md5Sum, sha256Sum []byte) error {
+	checksum = strings.TrimSpace(checksum)
+	if checksum == "" {
+		return nil
+	}
+
+	algo, value, ok := strings.Cut(checksum, ":")
+	if !ok {
+		algo, value = algorithm, checksum
+	}
+
+	var sum []byte
+	switch strings.ToLower(algo) {
+	case "sha256":
+		sum = sha256Sum
+	case "md5":
+		sum = md5Sum
+	default:
+		return errno.ErrInvalidArgument.WithMessage("unsupported checksum algorithm: %s", algo)
+	}
+
+	if strings.EqualFold(value, hex.EncodeToString(sum)) || value == base64.StdEncoding.EncodeToString(sum) {
+		return nil
+	}
+	return errno.ErrChecksumMismatch
+}
+
+// appendFile 将 path 的内容追加写入 w.
+func appendFile(w io.Writer, path string) (int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	return io.Copy(w, f)
+}
+
+// writeFileAtomic 先写临时文件再重命名，避免读到不完整的内容.
+// 临时文件名随机生成，并发写入同一路径时互不覆盖.
+func writeFileAtomic(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(0o644)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+	}
+	return err
+}
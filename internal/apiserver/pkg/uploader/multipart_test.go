@@ -0,0 +1,154 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+func newTestUploader(t *testing.T) *localUploader {
+	cfg := opt.NewUploadOptions()
+	cfg.Local.BaseDir = t.TempDir()
+	cfg.AllowedMIMEs = append(cfg.AllowedMIMEs, "text/plain")
+	cfg.Multipart.TempDir = filepath.Join(cfg.Local.BaseDir, ".multipart")
+	cfg.Multipart.PartSizeMin = "4B"
+	cfg.Multipart.PartSizeDefault = "4B"
+	l, err := newLocalUploader(cfg)
+	require.NoError(t, err)
+	return l
+}
+
+func TestLocalMultipart(t *testing.T) {
+	l := newTestUploader(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	content := []byte("hello multipart")
+	sum := sha256.Sum256(content)
+
+	sess, err := l.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:])})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), sess.PartSize)
+	assert.Equal(t, "proxy", sess.Mode)
+
+	// 乱序上传，并覆盖重传第 1 片
+	for _, n := range []int32{2, 1, 4, 3, 1} {
+		start := int64(n-1) * sess.PartSize
+		end := min(start+sess.PartSize, int64(len(content)))
+		_, err := l.UploadPart(ctx, sess.UploadID, n, "", bytes.NewReader(content[start:end]))
+		require.NoError(t, err)
+	}
+
+	// 其他用户无法访问该会话
+	_, err = l.ListParts(contextx.WithUserID(context.Background(), "user-000002"), sess.UploadID)
+	assert.Equal(t, errno.ErrUploadSessionNotFound, err)
+
+	parts, err := l.ListParts(ctx, sess.UploadID)
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+
+	obj, err := l.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(sum[:]), obj.Hash)
+	assert.Equal(t, sess.Key, obj.Key)
+
+	assert.Equal(t, "a.txt", obj.Metadata["filename"])
+
+	data, err := os.ReadFile(filepath.Join(l.cfg.Local.BaseDir, obj.Key))
+	require.NoError(t, err)
+	assert.Equal(t, content, data)
+
+	// 去重时删除重复对象，重复删除不报错
+	require.NoError(t, l.Remove(ctx, obj.Key))
+	require.NoError(t, l.Remove(ctx, obj.Key))
+	_, err = os.Stat(filepath.Join(l.cfg.Local.BaseDir, obj.Key))
+	assert.True(t, os.IsNotExist(err))
+
+	// 会话完成后即被清理
+	_, err = l.ListParts(ctx, sess.UploadID)
+	assert.Equal(t, errno.ErrUploadSessionNotFound, err)
+}
+
+func TestLocalMultipart_Checksum(t *testing.T) {
+	l := newTestUploader(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	sess, err := l.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: 8})
+	require.NoError(t, err)
+
+	_, err = l.UploadPart(ctx, sess.UploadID, 1, "sha256:deadbeef", bytes.NewReader([]byte("abcd")))
+	assert.Equal(t, errno.ErrChecksumMismatch, err)
+
+	// Content-MD5 of "abcd"
+	_, err = l.UploadPart(ctx, sess.UploadID, 1, "md5:4vxxTEcn7pOV8yTNLn8zHw==", bytes.NewReader([]byte("abcd")))
+	assert.NoError(t, err)
+
+	_, err = l.UploadPart(ctx, sess.UploadID, 2, "", bytes.NewReader([]byte("too large")))
+	assert.ErrorIs(t, err, errno.ErrInvalidPart)
+
+	require.NoError(t, l.AbortMultipart(ctx, sess.UploadID))
+	_, err = os.Stat(l.multipart.dir(sess.UploadID))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestWriteFileAtomic_Concurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+
+	var wg sync.WaitGroup
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, writeFileAtomic(path, bytes.Repeat([]byte{byte('a' + i)}, 4096)))
+		}()
+	}
+	wg.Wait()
+
+	// 最终内容来自某一次完整的写入，且不残留临时文件
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat(data[:1], 4096), data)
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestLocalMultipart_Janitor(t *testing.T) {
+	l := newTestUploader(t)
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	// 服务重启前遗留的过期会话在启动时即被清理
+	sess, err := l.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: 8})
+	require.NoError(t, err)
+	meta, err := l.multipart.readSession(sess.UploadID)
+	require.NoError(t, err)
+	meta.ExpiresAt = time.Now().Add(-time.Second)
+	data, _ := json.Marshal(meta)
+	require.NoError(t, writeFileAtomic(filepath.Join(l.multipart.dir(sess.UploadID), sessionFile), data))
+
+	janitorCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	l.StartJanitor(janitorCtx)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(l.multipart.dir(sess.UploadID))
+		return os.IsNotExist(err)
+	}, 5*time.Second, 10*time.Millisecond)
+}
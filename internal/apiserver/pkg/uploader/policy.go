@@ -0,0 +1,205 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// sniffLen 为内容嗅探读取的字节数，与 http.DetectContentType 一致.
+const sniffLen = 512
+
+// rule 为单个场景生效的上传限制.
+type rule struct {
+	// maxSize 为 0 表示不限制大小
+	maxSize int64
+	// allowed 为空表示不限制类型，支持 image/* 形式的通配
+	allowed []string
+}
+
+// policy 负责在服务端强制执行 MaxSize 与 AllowedMIMEs，各存储后端共用.
+type policy struct {
+	def    rule
+	scenes map[string]rule
+	dedup  bool
+}
+
+func newPolicy(cfg *opt.UploadOptions) *policy {
+	p := &policy{scenes: map[string]rule{}, dedup: cfg.Deduplicate}
+	p.def.maxSize, _ = opt.ParseSize(cfg.MaxSize)
+	p.def.allowed = normalizeMIMEs(cfg.AllowedMIMEs)
+
+	for name, scene := range cfg.Scenes {
+		if scene == nil {
+			continue
+		}
+		r := p.def
+		if n, err := opt.ParseSize(scene.MaxSize); err == nil && n > 0 {
+			r.maxSize = n
+		}
+		if len(scene.AllowedMIMEs) > 0 {
+			r.allowed = normalizeMIMEs(scene.AllowedMIMEs)
+		}
+		p.scenes[name] = r
+	}
+	return p
+}
+
+func normalizeMIMEs(mimes []string) []string {
+	out := make([]string, 0, len(mimes))
+	for _, m := range mimes {
+		if m = baseMIME(m); m != "" {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// baseMIME 去掉 MIME 中的参数（如 charset）并转为小写.
+func baseMIME(m string) string {
+	m, _, _ = strings.Cut(m, ";")
+	return strings.ToLower(strings.TrimSpace(m))
+}
+
+// rule 返回场景对应的限制，未配置的场景使用全局限制.
+func (p *policy) rule(scene string) rule {
+	if r, ok := p.scenes[scene]; ok {
+		return r
+	}
+	return p.def
+}
+
+// MaxUploadSize 返回所有场景中最大的大小限制，供 HTTP 层在解析请求体前做粗粒度拦截；0 表示不限制.
+func (p *policy) MaxUploadSize() int64 {
+	size := p.def.maxSize
+	for _, r := range p.scenes {
+		if r.maxSize == 0 {
+			return 0
+		}
+		size = max(size, r.maxSize)
+	}
+	return size
+}
+
+// Deduplicate 返回是否启用内容去重.
+func (p *policy) Deduplicate() bool {
+	return p.dedup
+}
+
+// allows 判断 MIME 是否在允许列表中.
+func (r rule) allows(mimeType string) bool {
+	if len(r.allowed) == 0 {
+		return true
+	}
+	mimeType = baseMIME(mimeType)
+	for _, a := range r.allowed {
+		if a == mimeType || a == "*/*" {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(a, "/*"); ok && strings.HasPrefix(mimeType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// checkDeclared 在分片上传初始化时，根据客户端声明的大小与类型做预检.
+// 声明的类型为空时按文件扩展名推断；最终类型仍以合并后的内容嗅探为准.
+func (p *policy) checkDeclared(scene string, filename string, mimeType string, size int64) error {
+	r := p.rule(scene)
+	if r.maxSize > 0 && size > r.maxSize {
+		return errno.ErrFileTooLarge.WithMessage("file size %d exceeds the limit of %d bytes", size, r.maxSize)
+	}
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(filename))
+	}
+	if mimeType != "" && !r.allows(mimeType) {
+		return errno.ErrMIMENotAllowed.WithMessage("file type %s is not allowed", baseMIME(mimeType))
+	}
+	return nil
+}
+
+// checkContent 根据内容头部嗅探 MIME 并校验，返回嗅探得到的类型.
+func (p *policy) checkContent(scene string, head []byte, claimed string) (string, error) {
+	detected := sniffMIME(head, claimed)
+	if !p.rule(scene).allows(detected) {
+		return "", errno.ErrMIMENotAllowed.WithMessage("file type %s is not allowed", detected)
+	}
+	return detected, nil
+}
+
+// guard 校验上传流：先读取头部做内容嗅探，再返回一个超过大小限制时立即报错的 Reader.
+// 返回的 MIME 为嗅探结果，调用方应以它替代客户端声明的类型.
+func (p *policy) guard(scene string, claimed string, size int64, r io.Reader) (io.Reader, string, error) {
+	rl := p.rule(scene)
+	if rl.maxSize > 0 && size > rl.maxSize {
+		return nil, "", errno.ErrFileTooLarge.WithMessage("file size %d exceeds the limit of %d bytes", size, rl.maxSize)
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(r, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, "", err
+	}
+	head = head[:n]
+
+	detected, err := p.checkContent(scene, head, claimed)
+	if err != nil {
+		return nil, "", err
+	}
+
+	body := io.MultiReader(bytes.NewReader(head), r)
+	if rl.maxSize > 0 {
+		body = &limitedReader{r: body, remaining: rl.maxSize, limit: rl.maxSize}
+	}
+	return body, detected, nil
+}
+
+// limitedReader 在读取超过 limit 字节时返回 ErrFileTooLarge，使上传尽早中止.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.remaining < 0 {
+		return 0, errno.ErrFileTooLarge.WithMessage("file exceeds the limit of %d bytes", l.limit)
+	}
+	// 多读 1 字节用于判断是否超限
+	if int64(len(p)) > l.remaining+1 {
+		p = p[:l.remaining+1]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errno.ErrFileTooLarge.WithMessage("file exceeds the limit of %d bytes", l.limit)
+	}
+	return n, err
+}
+
+// sniffMIME 根据魔数识别内容类型.
+// 嗅探结果为具体类型时总是覆盖客户端声明；仅当内容为纯文本时，才采用客户端声明的文本类（如 text/markdown、application/json）.
+func sniffMIME(head []byte, claimed string) string {
+	detected := baseMIME(http.DetectContentType(head))
+	claimed = baseMIME(claimed)
+
+	if detected == "text/plain" && (strings.HasPrefix(claimed, "text/") || claimed == "application/json") {
+		// text/html 等可执行内容仍以嗅探为准
+		if claimed != "text/html" && claimed != "text/xml" {
+			return claimed
+		}
+	}
+	return detected
+}
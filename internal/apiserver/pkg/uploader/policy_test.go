@@ -0,0 +1,71 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// pngHeader 为最小的 PNG 魔数.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func newTestPolicy() *policy {
+	cfg := opt.NewUploadOptions()
+	cfg.MaxSize = "64B"
+	cfg.AllowedMIMEs = []string{"image/*", "application/pdf"}
+	cfg.Scenes = map[string]*opt.SceneOptions{
+		"doc": {MaxSize: "1KB", AllowedMIMEs: []string{"application/pdf"}},
+	}
+	return newPolicy(cfg)
+}
+
+func TestPolicy_Guard(t *testing.T) {
+	p := newTestPolicy()
+	assert.Equal(t, int64(1024), p.MaxUploadSize())
+
+	// 嗅探结果覆盖客户端声明的类型
+	r, mimeType, err := p.guard("", "text/html", 0, bytes.NewReader(pngHeader))
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", mimeType)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, pngHeader, data)
+
+	// 声明为图片但实际是 HTML
+	_, _, err = p.guard("", "image/png", 0, strings.NewReader("<html><script>alert(1)</script></html>"))
+	assert.ErrorIs(t, err, errno.ErrMIMENotAllowed)
+
+	// 场景级允许列表
+	_, _, err = p.guard("doc", "", 0, bytes.NewReader(pngHeader))
+	assert.ErrorIs(t, err, errno.ErrMIMENotAllowed)
+
+	// 声明的大小超限时直接拒绝
+	_, _, err = p.guard("", "", 65, bytes.NewReader(pngHeader))
+	assert.ErrorIs(t, err, errno.ErrFileTooLarge)
+
+	// 未声明大小时在读取过程中中止
+	big := append(append([]byte{}, pngHeader...), make([]byte, 600)...)
+	r, _, err = p.guard("", "", 0, bytes.NewReader(big))
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, errno.ErrFileTooLarge)
+
+	// 恰好等于上限时允许
+	r, _, err = p.guard("", "", 0, bytes.NewReader(big[:64]))
+	require.NoError(t, err)
+	data, err = io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Len(t, data, 64)
+}
@@ -0,0 +1,823 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+const (
+	// sessionPrefix 为分片会话元数据在 bucket 中的存放前缀.
+	sessionPrefix = ".multipart/"
+
+	defaultPresignExpires = 15 * time.Minute
+	defaultUploadTimeout  = 30 * time.Second
+
+	// minTransferRate 为估算整个对象上传、下载超时时按的最低传输速度.
+	minTransferRate = 1 * opt.MiB
+)
+
+// s3Session 为保存在 bucket 中的分片会话元数据.
+// 会话信息不依赖本地磁盘，多副本部署时任意实例都可以继续处理同一会话.
+type s3Session struct {
+	sessionMeta
+	S3UploadID string `json:"s3UploadID"`
+	// Completed 为已在对象存储上合并、但尚未处理完成的分片. 处理因网络等原因失败时
+	// 会话与对象均保留，客户端可以重新合并（只重新处理对象）或中止（删除对象）
+	Completed []*Part `json:"completed,omitempty"`
+}
+
+// s3Uploader 将对象保存到 S3 兼容的对象存储，同时实现了 MultipartUploader 与 PartPresigner.
+type s3Uploader struct {
+	*policy
+	multipartLimits
+
+	provider string
+	cfg      *opt.UploadOptions
+	s3       *opt.S3Options
+	keys     *KeyTemplate
+	images   *imageProcessor
+	scanner  *contentScanner
+	core     *minio.Core
+
+	// direct 为 true 时 InitMultipart 返回 direct 模式，客户端通过预签名 URL 直传分片
+	direct         bool
+	presignExpires time.Duration
+	timeout        time.Duration
+}
+
+// 确保 s3Uploader 实现了相关接口.
+var (
+	_ Uploader          = (*s3Uploader)(nil)
+	_ MultipartUploader = (*s3Uploader)(nil)
+	_ PartPresigner     = (*s3Uploader)(nil)
+	_ Remover           = (*s3Uploader)(nil)
+	_ Janitor           = (*s3Uploader)(nil)
+)
+
+func newS3Uploader(provider string, cfg *opt.UploadOptions, s3cfg *opt.S3Options) (*s3Uploader, error) {
+	if s3cfg == nil || s3cfg.Endpoint == "" || s3cfg.Bucket == "" {
+		return nil, fmt.Errorf("%s: endpoint and bucket are required", provider)
+	}
+
+	// Endpoint 允许携带协议前缀，此时以前缀决定是否启用 TLS
+	host, secure := s3cfg.Endpoint, s3cfg.UseSSL
+	if strings.Contains(host, "://") {
+		u, err := url.Parse(host)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid endpoint: %w", provider, err)
+		}
+		host, secure = u.Host, u.Scheme == "https"
+	}
+
+	keys, err := ParseKeyTemplate(cfg.KeyTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner, err := newContentScanner(cfg.Scan)
+	if err != nil {
+		return nil, err
+	}
+
+	lookup := minio.BucketLookupDNS
+	if s3cfg.PathStyleAccess {
+		lookup = minio.BucketLookupPath
+	}
+
+	core, err := minio.NewCore(host, &minio.Options{
+		Creds:        credentials.NewStaticV4(s3cfg.AccessKeyID, s3cfg.SecretAccessKey, s3cfg.SessionToken),
+		Secure:       secure,
+		Region:       s3cfg.Region,
+		BucketLookup: lookup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", provider, err)
+	}
+
+	u := &s3Uploader{
+		policy:          newPolicy(cfg),
+		multipartLimits: newMultipartLimits(cfg.Multipart),
+		provider:        provider,
+		cfg:             cfg,
+		s3:              s3cfg,
+		keys:            keys,
+		images:          newImageProcessor(cfg.Image),
+		scanner:         scanner,
+		core:            core,
+		presignExpires:  defaultPresignExpires,
+		timeout:         defaultUploadTimeout,
+	}
+	if d, err := opt.ParseDuration(s3cfg.UploadTimeout); err == nil && d > 0 {
+		u.timeout = d
+	}
+	if m := cfg.Multipart; m != nil {
+		u.direct = m.PresignEnabled && strings.EqualFold(m.PresignMode, "direct")
+		if d, err := opt.ParseDuration(m.PresignExpires); err == nil && d > 0 {
+			u.presignExpires = d
+		}
+	}
+	return u, nil
+}
+
+// s3OptionsFromAliOSS 将 AliOSS 配置转换为 S3 兼容配置.
+// OSS 的 S3 兼容接口以 endpoint 的第一段（如 oss-cn-hangzhou）作为 region.
+func s3OptionsFromAliOSS(o *opt.AliOSSOptions) *opt.S3Options {
+	if o == nil {
+		return nil
+	}
+
+	host := o.Endpoint
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	region, _, _ := strings.Cut(host, ".")
+
+	return &opt.S3Options{
+		Endpoint:        o.Endpoint,
+		Region:          region,
+		Bucket:          o.Bucket,
+		AccessKeyID:     o.AccessKeyID,
+		SecretAccessKey: o.AccessKeySecret,
+		SessionToken:    o.SecurityToken,
+		UseSSL:          true,
+		PathStyleAccess: o.PathStyleAccess,
+		ACL:             o.ACL,
+		UploadTimeout:   o.UploadTimeout,
+	}
+}
+
+// withTimeout 为单次对象存储请求设置超时.
+func (u *s3Uploader) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, u.timeout)
+}
+
+// withTransferTimeout 为上传或下载整个对象的请求设置超时：在 withTimeout 的基础上按最低传输速度
+// 为 size 字节留出传输时间，大文件不会因固定的超时而必然失败.
+func (u *s3Uploader) withTransferTimeout(ctx context.Context, size int64) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, u.timeout+time.Duration(size/minTransferRate)*time.Second)
+}
+
+// putOptions 返回写入对象时的公共选项.
+// 所有写入均预先计算了 sha256，因此关闭流式签名，直接以内容哈希签名.
+func (u *s3Uploader) putOptions(mimeType string) minio.PutObjectOptions {
+	opts := minio.PutObjectOptions{ContentType: mimeType, DisableContentSha256: true}
+	if u.s3.ACL != "" {
+		opts.UserMetadata = map[string]string{"x-amz-acl": u.s3.ACL}
+	}
+	return opts
+}
+
+// fullKey 为对象键加上公共前缀.
+func (u *s3Uploader) fullKey(key string) string {
+	if u.s3.Prefix == "" {
+		return key
+	}
+	return strings.Trim(u.s3.Prefix, "/") + "/" + key
+}
+
+// publicURL 构造对象的访问 URL：优先使用 BaseURL（如 CDN），否则由 Endpoint 与寻址方式拼接.
+func (u *s3Uploader) publicURL(key string) string {
+	if strings.TrimSpace(u.s3.BaseURL) != "" {
+		if uu, err := url.Parse(u.s3.BaseURL); err == nil {
+			uu.Path = strings.TrimRight(uu.Path, "/") + "/" + key
+			return uu.String()
+		}
+	}
+
+	uu := *u.core.EndpointURL()
+	if u.s3.PathStyleAccess {
+		uu.Path = "/" + u.s3.Bucket + "/" + key
+	} else {
+		uu.Host = u.s3.Bucket + "." + uu.Host
+		uu.Path = "/" + key
+	}
+	return uu.String()
+}
+
+// spool 将 r 写入临时文件并计算 md5 与 sha256，limit 大于 0 时最多读取 limit+1 字节.
+// 对象存储要求预先知道内容长度，因此需要先落盘.
+func spool(r io.Reader, limit int64) (f *os.File, n int64, md5Sum []byte, sha256Sum []byte, err error) {
+	f, err = os.CreateTemp("", "miniblog-upload-*")
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+
+	if limit > 0 {
+		r = io.LimitReader(r, limit+1)
+	}
+	md5h, sha256h := md5.New(), sha256.New() //nolint:gosec
+	n, err = io.Copy(io.MultiWriter(f, md5h, sha256h), r)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		closeAndRemove(f)
+		return nil, 0, nil, nil, err
+	}
+	return f, n, md5h.Sum(nil), sha256h.Sum(nil), nil
+}
+
+func closeAndRemove(f *os.File) {
+	_ = f.Close()
+	_ = os.Remove(f.Name())
+}
+
+func (u *s3Uploader) Provider() string {
+	return u.provider
+}
+
+func (u *s3Uploader) Upload(ctx context.Context, in *UploadInput, r io.Reader) (*UploadedObject, error) {
+	r, mimeType, err := u.guard(in.Scene, in.MIME, in.Size, r)
+	if err != nil {
+		return nil, err
+	}
+
+	f, n, md5Sum, sha256Sum, err := spool(r, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { closeAndRemove(f) }()
+
+	// 对象按去除图片元数据后的内容写入，Hash 仍为上传内容的哈希，用于去重
+	sumHex := hex.EncodeToString(sha256Sum)
+	metadata := objectMetadata(in.Scene, in.Filename)
+	if err := u.scanner.check(ctx, f.Name(), &ScanInput{Filename: metadata["filename"], MIME: mimeType, Size: n}, sumHex); err != nil {
+		return nil, err
+	}
+	key := u.fullKey(u.keys.objectKey(ctx, in.Filename, mimeType, in.Scene, sumHex))
+	sf, sn, smd5, ssha, err := u.sanitizeSpool(f, mimeType)
+	if err != nil {
+		return nil, err
+	}
+	if sf != nil {
+		closeAndRemove(f)
+		f, n, md5Sum, sha256Sum = sf, sn, smd5, ssha
+	}
+
+	putCtx, cancel := u.withTransferTimeout(ctx, n)
+	defer cancel()
+	if _, err := u.core.PutObject(putCtx, u.s3.Bucket, key, f, n, base64.StdEncoding.EncodeToString(md5Sum), hex.EncodeToString(sha256Sum), u.putOptions(mimeType)); err != nil {
+		return nil, err
+	}
+
+	obj := &UploadedObject{
+		Provider: u.provider,
+		Key:      key,
+		URL:      u.publicURL(key),
+		Size:     n,
+		MIME:     mimeType,
+		Hash:     sumHex,
+		Metadata: metadata,
+	}
+	maps.Copy(obj.Metadata, u.putDerivatives(ctx, f.Name(), key, mimeType))
+	return obj, nil
+}
+
+// sanitizeSpool 去除暂存图片的元数据；内容被修改时重新暂存并返回新文件，否则返回 nil.
+func (u *s3Uploader) sanitizeSpool(f *os.File, mimeType string) (*os.File, int64, []byte, []byte, error) {
+	changed, err := u.images.sanitize(f.Name(), mimeType)
+	if err != nil || !changed {
+		return nil, 0, nil, nil, err
+	}
+
+	src, err := os.Open(f.Name())
+	if err != nil {
+		return nil, 0, nil, nil, err
+	}
+	defer src.Close()
+	return spool(src, 0)
+}
+
+// putDerivatives 为暂存文件 path 中的图片生成衍生图并写入 bucket，返回需要合并到 Metadata 的图片信息.
+// 衍生图写入失败不影响原图上传.
+func (u *s3Uploader) putDerivatives(ctx context.Context, path string, key string, mimeType string) map[string]string {
+	derived, metadata := u.images.derive(ctx, path, key, mimeType)
+	written := make([]*derivedImage, 0, len(derived))
+	for _, d := range derived {
+		md5Sum := md5.Sum(d.data) //nolint:gosec
+		sha256Sum := sha256.Sum256(d.data)
+		putCtx, cancel := u.withTimeout(ctx)
+		_, err := u.core.PutObject(putCtx, u.s3.Bucket, d.key, bytes.NewReader(d.data), int64(len(d.data)),
+			base64.StdEncoding.EncodeToString(md5Sum[:]), hex.EncodeToString(sha256Sum[:]), u.putOptions(d.mime))
+		cancel()
+		if err != nil {
+			log.W(ctx).Warnw("Failed to put image variant", "key", d.key, "err", err)
+			continue
+		}
+		written = append(written, d)
+	}
+	u.images.variantMetadata(metadata, written, u.publicURL(key), u.publicURL)
+	return metadata
+}
+
+// processObject 下载合并后的对象，在服务端计算 sha256 并与客户端声明的值比对，随后扫描内容；
+// 图片去除元数据后覆盖原对象并生成衍生图，返回对象的实际大小与内容哈希. size 为对象的大小，用于估算下载超时.
+func (u *s3Uploader) processObject(ctx context.Context, key string, size int64, mimeType string, in *ScanInput, claimed string) (int64, string, map[string]string, error) {
+	getCtx, cancel := u.withTransferTimeout(ctx, size)
+	rc, _, _, err := u.core.GetObject(getCtx, u.s3.Bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		cancel()
+		return 0, "", nil, err
+	}
+	f, size, _, sha256Sum, err := spool(rc, 0)
+	_ = rc.Close()
+	cancel()
+	if err != nil {
+		return 0, "", nil, err
+	}
+	defer func() { closeAndRemove(f) }()
+
+	sumHex := hex.EncodeToString(sha256Sum)
+	if claimed != "" && claimed != sumHex {
+		return 0, "", nil, errno.ErrChecksumMismatch.WithMessage("sha256 of the assembled file does not match")
+	}
+	in.Size = size
+	if err := u.scanner.check(ctx, f.Name(), in, sumHex); err != nil {
+		return 0, "", nil, err
+	}
+	if !u.images.handles(mimeType) {
+		return size, sumHex, nil, nil
+	}
+
+	sf, n, md5Sum, sanitizedSum, err := u.sanitizeSpool(f, mimeType)
+	if err != nil {
+		return 0, "", nil, err
+	}
+	if sf != nil {
+		closeAndRemove(f)
+		f = sf
+		putCtx, cancel := u.withTransferTimeout(ctx, n)
+		defer cancel()
+		if _, err := u.core.PutObject(putCtx, u.s3.Bucket, key, f, n, base64.StdEncoding.EncodeToString(md5Sum), hex.EncodeToString(sanitizedSum), u.putOptions(mimeType)); err != nil {
+			return 0, "", nil, err
+		}
+		size = n
+	}
+	return size, sumHex, u.putDerivatives(ctx, f.Name(), key, mimeType), nil
+}
+
+// Remove 删除 bucket 中的对象及其衍生图，key 已包含公共前缀.
+func (u *s3Uploader) Remove(ctx context.Context, key string) error {
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+	if err := u.core.RemoveObject(ctx, u.s3.Bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return err
+	}
+
+	for info := range u.core.Client.ListObjects(ctx, u.s3.Bucket, minio.ListObjectsOptions{Prefix: DerivativePrefix(key), Recursive: true}) {
+		if info.Err != nil {
+			return info.Err
+		}
+		if err := u.core.RemoveObject(ctx, u.s3.Bucket, info.Key, minio.RemoveObjectOptions{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sessionKey 返回会话元数据的对象键.
+func (u *s3Uploader) sessionKey(uploadID string) string {
+	return u.fullKey(sessionPrefix + uploadID + ".json")
+}
+
+// saveSession 将会话元数据写入 bucket.
+func (u *s3Uploader) saveSession(ctx context.Context, sess *s3Session) error {
+	data, _ := json.Marshal(sess)
+	md5Sum, sha256Sum := md5.Sum(data), sha256.Sum256(data) //nolint:gosec
+	_, err := u.core.PutObject(ctx, u.s3.Bucket, u.sessionKey(sess.UploadID), bytes.NewReader(data), int64(len(data)),
+		base64.StdEncoding.EncodeToString(md5Sum[:]), hex.EncodeToString(sha256Sum[:]),
+		minio.PutObjectOptions{ContentType: "application/json", DisableContentSha256: true})
+	return err
+}
+
+// readSession 从 bucket 读取会话元数据.
+func (u *s3Uploader) readSession(ctx context.Context, uploadID string) (*s3Session, error) {
+	rc, _, _, err := u.core.GetObject(ctx, u.s3.Bucket, u.sessionKey(uploadID), minio.GetObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	var sess s3Session
+	if err := json.NewDecoder(rc).Decode(&sess); err != nil {
+		return nil, err
+	}
+	return &sess, nil
+}
+
+// loadSession 读取会话并校验归属与有效期，语义与本地实现一致.
+func (u *s3Uploader) loadSession(ctx context.Context, uploadID string) (*s3Session, error) {
+	if !uploadIDRegex.MatchString(uploadID) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+
+	sess, err := u.readSession(ctx, uploadID)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+			log.W(ctx).Errorw("Failed to read multipart session", "uploadID", uploadID, "err", err)
+		}
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	if sess.OwnerID != contextx.UserID(ctx) || time.Now().After(sess.ExpiresAt) {
+		return nil, errno.ErrUploadSessionNotFound
+	}
+	return sess, nil
+}
+
+// deleteSession 删除会话元数据，失败只记录日志（过期后由 janitor 兜底清理）.
+func (u *s3Uploader) deleteSession(ctx context.Context, uploadID string) {
+	if err := u.core.RemoveObject(ctx, u.s3.Bucket, u.sessionKey(uploadID), minio.RemoveObjectOptions{}); err != nil {
+		log.W(ctx).Errorw("Failed to remove multipart session", "uploadID", uploadID, "err", err)
+	}
+}
+
+// InitMultipart 在对象存储上创建分片上传，并保存会话元数据.
+// 此时内容尚未上传，客户端声明的 sha256 无法验证，因此以会话 ID 代替内容哈希生成对象键，
+// 避免声明他人文件的哈希而覆盖其对象.
+func (u *s3Uploader) InitMultipart(ctx context.Context, in *MultipartInput) (*MultipartSession, error) {
+	if !u.enabled {
+		return nil, errno.ErrMultipartDisabled
+	}
+
+	if err := u.checkDeclared(in.Scene, in.Filename, in.MIME, in.Size); err != nil {
+		return nil, err
+	}
+	partSize, err := u.resolvePartSize(in.Size, in.PartSize)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	sess := &s3Session{sessionMeta: sessionMeta{
+		UploadID:  strings.ReplaceAll(uuid.NewString(), "-", ""),
+		OwnerID:   contextx.UserID(ctx),
+		Filename:  path.Base(in.Filename),
+		MIME:      in.MIME,
+		Scene:     in.Scene,
+		Size:      in.Size,
+		SHA256:    strings.ToLower(strings.TrimPrefix(in.SHA256, "sha256:")),
+		PartSize:  partSize,
+		CreatedAt: now,
+		ExpiresAt: now.Add(u.ttl),
+	}}
+	// 对象的 Content-Type 在创建分片上传时即已确定，未声明时按扩展名推断
+	if sess.MIME == "" {
+		sess.MIME = mime.TypeByExtension(path.Ext(sess.Filename))
+	}
+	sess.Key = u.fullKey(u.keys.objectKey(ctx, sess.Filename, sess.MIME, sess.Scene, sess.UploadID))
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+
+	sess.S3UploadID, err = u.core.NewMultipartUpload(ctx, u.s3.Bucket, sess.Key, u.putOptions(sess.MIME))
+	if err != nil {
+		return nil, err
+	}
+	if err := u.saveSession(ctx, sess); err != nil {
+		_ = u.core.AbortMultipartUpload(ctx, u.s3.Bucket, sess.Key, sess.S3UploadID)
+		return nil, err
+	}
+
+	log.W(ctx).Infow("Multipart upload initiated", "provider", u.provider, "uploadID", sess.UploadID, "key", sess.Key, "partSize", partSize)
+
+	out := &MultipartSession{UploadID: sess.UploadID, Key: sess.Key, Mode: "proxy", PartSize: partSize}
+	if u.direct {
+		out.Mode, out.PresignExpires = "direct", u.presignExpires
+	}
+	return out, nil
+}
+
+// PresignParts 为指定分片生成直传 URL，客户端以 PUT 方式上传分片内容并保存响应中的 ETag.
+func (u *s3Uploader) PresignParts(ctx context.Context, uploadID string, partNumbers []int32) ([]*PresignedPart, error) {
+	if !u.direct {
+		return nil, errno.ErrPresignDisabled
+	}
+
+	sess, err := u.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(u.presignExpires)
+	parts := make([]*PresignedPart, 0, len(partNumbers))
+	for _, n := range partNumbers {
+		if n < 1 || n > u.maxParts {
+			return nil, errno.ErrInvalidPart.WithMessage("part number must be between 1 and %d", u.maxParts)
+		}
+		params := url.Values{}
+		params.Set("partNumber", strconv.Itoa(int(n)))
+		params.Set("uploadId", sess.S3UploadID)
+		presigned, err := u.core.Presign(ctx, http.MethodPut, u.s3.Bucket, sess.Key, u.presignExpires, params)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, &PresignedPart{PartNumber: n, URL: presigned.String(), Headers: map[string]string{}, ExpiresAt: expiresAt})
+	}
+	return parts, nil
+}
+
+// UploadPart 代理上传单个分片，校验 checksum 后转存到对象存储.
+func (u *s3Uploader) UploadPart(ctx context.Context, uploadID string, partNumber int32, checksum string, r io.Reader) (*Part, error) {
+	if partNumber < 1 || partNumber > u.maxParts {
+		return nil, errno.ErrInvalidPart.WithMessage("part number must be between 1 and %d", u.maxParts)
+	}
+
+	sess, err := u.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	f, n, md5Sum, sha256Sum, err := spool(r, sess.PartSize)
+	if err != nil {
+		return nil, err
+	}
+	defer closeAndRemove(f)
+
+	if n == 0 {
+		return nil, errno.ErrInvalidPart.WithMessage("part %d is empty", partNumber)
+	}
+	if n > sess.PartSize {
+		return nil, errno.ErrInvalidPart.WithMessage("part %d exceeds the part size of %d bytes", partNumber, sess.PartSize)
+	}
+	if err := verifyChecksum(checksum, u.checksum, md5Sum, sha256Sum); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+	objPart, err := u.core.PutObjectPart(ctx, u.s3.Bucket, sess.Key, sess.S3UploadID, int(partNumber), f, n, minio.PutObjectPartOptions{
+		Md5Base64:            base64.StdEncoding.EncodeToString(md5Sum),
+		Sha256Hex:            hex.EncodeToString(sha256Sum),
+		DisableContentSha256: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	part := &Part{
+		PartNumber: partNumber,
+		ETag:       strings.Trim(objPart.ETag, `"`),
+		Size:       n,
+		Checksum:   "sha256:" + hex.EncodeToString(sha256Sum),
+	}
+	if u.checksum == "md5" {
+		part.Checksum = "md5:" + hex.EncodeToString(md5Sum)
+	}
+	return part, nil
+}
+
+// ListParts 返回对象存储上已上传的分片，按分片号升序.
+func (u *s3Uploader) ListParts(ctx context.Context, uploadID string) ([]*Part, error) {
+	sess, err := u.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(sess.Completed) > 0 {
+		return sess.Completed, nil
+	}
+	return u.listParts(ctx, sess)
+}
+
+func (u *s3Uploader) listParts(ctx context.Context, sess *s3Session) ([]*Part, error) {
+	var parts []*Part
+	marker := 0
+	for {
+		result, err := u.core.ListObjectParts(ctx, u.s3.Bucket, sess.Key, sess.S3UploadID, marker, 1000)
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchUpload" {
+				return nil, errno.ErrUploadSessionNotFound
+			}
+			return nil, err
+		}
+		for _, p := range result.ObjectParts {
+			parts = append(parts, &Part{PartNumber: int32(p.PartNumber), ETag: strings.Trim(p.ETag, `"`), Size: p.Size})
+		}
+		if !result.IsTruncated {
+			return parts, nil
+		}
+		marker = result.NextPartNumberMarker
+	}
+}
+
+// CompleteMultipart 校验分片列表并在对象存储上完成合并.
+// 合并后下载对象在服务端计算 sha256 作为 Hash，与 Init 时客户端声明的值不一致时删除对象.
+// 每次对象存储请求单独计时；内容被拒绝时删除对象与会话，其他错误（如网络超时）保留二者，
+// 客户端可以重试合并或中止上传.
+func (u *s3Uploader) CompleteMultipart(ctx context.Context, uploadID string, parts []*Part) (*UploadedObject, error) {
+	sess, err := u.loadSession(ctx, uploadID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 此前已合并但处理失败时，跳过合并直接重新处理对象
+	selected := sess.Completed
+	if len(selected) == 0 {
+		if selected, err = u.complete(ctx, sess, parts); err != nil {
+			return nil, err
+		}
+	}
+	var total int64
+	for _, p := range selected {
+		total += p.Size
+	}
+
+	// 以合并后内容的嗅探结果校验类型；对象的 Content-Type 已在创建时确定，
+	// 因此嗅探结果必须与声明一致，否则删除对象
+	mimeType, err := u.sniffObject(ctx, sess.Scene, sess.Key, sess.MIME)
+	if err == nil && sess.MIME != "" && mimeType != baseMIME(sess.MIME) {
+		err = errno.ErrMIMENotAllowed.WithMessage("file content (%s) does not match the declared type %s", mimeType, baseMIME(sess.MIME))
+	}
+	var (
+		sumHex        string
+		imageMetadata map[string]string
+	)
+	if err == nil {
+		scanInput := &ScanInput{Filename: objectMetadata(sess.Scene, sess.Filename)["filename"], MIME: mimeType}
+		total, sumHex, imageMetadata, err = u.processObject(ctx, sess.Key, total, mimeType, scanInput, sess.SHA256)
+	}
+	if err != nil {
+		if errx := new(errno.ErrorX); errors.As(err, &errx) {
+			// 请求可能已超时或被取消，清理使用独立的上下文
+			cleanupCtx := context.WithoutCancel(ctx)
+			if rmErr := u.Remove(cleanupCtx, sess.Key); rmErr != nil {
+				log.W(ctx).Errorw("Failed to remove rejected object", "key", sess.Key, "err", rmErr)
+			}
+			u.deleteSession(cleanupCtx, uploadID)
+		}
+		return nil, err
+	}
+	u.deleteSession(ctx, uploadID)
+
+	log.W(ctx).Infow("Multipart upload completed", "provider", u.provider, "uploadID", uploadID, "key", sess.Key, "parts", len(selected), "size", total)
+
+	obj := &UploadedObject{
+		Provider: u.provider,
+		Key:      sess.Key,
+		URL:      u.publicURL(sess.Key),
+		Size:     total,
+		MIME:     mimeType,
+		Hash:     sumHex,
+		Metadata: objectMetadata(sess.Scene, sess.Filename),
+	}
+	maps.Copy(obj.Metadata, imageMetadata)
+	return obj, nil
+}
+
+// complete 校验分片列表并在对象存储上完成合并，随后在会话中记录已合并的分片.
+func (u *s3Uploader) complete(ctx context.Context, sess *s3Session, parts []*Part) ([]*Part, error) {
+	uploaded, err := u.listParts(ctx, sess)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := u.selectParts(&sess.sessionMeta, uploaded, parts)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	completed := make([]minio.CompletePart, 0, len(selected))
+	for _, p := range selected {
+		total += p.Size
+		completed = append(completed, minio.CompletePart{PartNumber: int(p.PartNumber), ETag: p.ETag})
+	}
+	if sess.Size > 0 && total != sess.Size {
+		return nil, errno.ErrInvalidPart.WithMessage("assembled size %d does not match declared size %d", total, sess.Size)
+	}
+
+	completeCtx, cancel := u.withTimeout(ctx)
+	defer cancel()
+	if _, err := u.core.CompleteMultipartUpload(completeCtx, u.s3.Bucket, sess.Key, sess.S3UploadID, completed, minio.PutObjectOptions{}); err != nil {
+		return nil, err
+	}
+
+	sess.Completed = selected
+	saveCtx, cancel := u.withTimeout(context.WithoutCancel(ctx))
+	defer cancel()
+	if err := u.saveSession(saveCtx, sess); err != nil {
+		log.W(ctx).Errorw("Failed to save completed multipart session", "uploadID", sess.UploadID, "err", err)
+	}
+	return selected, nil
+}
+
+// sniffObject 读取对象头部并校验内容类型.
+func (u *s3Uploader) sniffObject(ctx context.Context, scene string, key string, claimed string) (string, error) {
+	opts := minio.GetObjectOptions{}
+	_ = opts.SetRange(0, sniffLen-1)
+	ctx, cancel := u.withTimeout(ctx)
+	defer cancel()
+	rc, _, _, err := u.core.GetObject(ctx, u.s3.Bucket, key, opts)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	head, err := io.ReadAll(io.LimitReader(rc, sniffLen))
+	if err != nil {
+		return "", err
+	}
+	return u.checkContent(scene, head, claimed)
+}
+
+// AbortMultipart 中止对象存储上的分片上传并删除会话.
+func (u *s3Uploader) AbortMultipart(ctx context.Context, uploadID string) error {
+	sess, err := u.loadSession(ctx, uploadID)
+	if err != nil {
+		return err
+	}
+
+	if err := u.abort(ctx, sess); err != nil {
+		return err
+	}
+
+	log.W(ctx).Infow("Multipart upload aborted", "provider", u.provider, "uploadID", uploadID)
+	return nil
+}
+
+// abort 中止分片上传并删除会话；分片已合并时删除合并后的对象.
+func (u *s3Uploader) abort(ctx context.Context, sess *s3Session) error {
+	if len(sess.Completed) > 0 {
+		if err := u.Remove(ctx, sess.Key); err != nil {
+			return err
+		}
+	} else {
+		abortCtx, cancel := u.withTimeout(ctx)
+		defer cancel()
+		if err := u.core.AbortMultipartUpload(abortCtx, u.s3.Bucket, sess.Key, sess.S3UploadID); err != nil && minio.ToErrorResponse(err).Code != "NoSuchUpload" {
+			return err
+		}
+	}
+	u.deleteSession(ctx, sess.UploadID)
+	return nil
+}
+
+// StartJanitor 实现 Janitor 接口：启动后台协程，立即并定期中止过期会话，ctx 取消后停止.
+// 多个副本会同时执行清理，中止与删除操作均是幂等的. 未启用分片上传时不做任何事.
+func (u *s3Uploader) StartJanitor(ctx context.Context) {
+	if !u.enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(u.janitorInterval())
+		defer ticker.Stop()
+		for {
+			u.cleanupExpired(ctx, time.Now())
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// cleanupExpired 中止所有已过期的会话.
+func (u *s3Uploader) cleanupExpired(ctx context.Context, now time.Time) {
+	for obj := range u.core.Client.ListObjects(ctx, u.s3.Bucket, minio.ListObjectsOptions{Prefix: u.fullKey(sessionPrefix), Recursive: true}) {
+		if obj.Err != nil {
+			log.Errorw("Failed to list multipart sessions", "err", obj.Err)
+			return
+		}
+
+		uploadID := strings.TrimSuffix(path.Base(obj.Key), ".json")
+		sess, err := u.readSession(ctx, uploadID)
+		if err != nil || now.Before(sess.ExpiresAt) {
+			continue
+		}
+		if err := u.abort(ctx, sess); err != nil {
+			log.Errorw("Failed to abort expired multipart upload", "uploadID", uploadID, "err", err)
+		}
+	}
+}
@@ -0,0 +1,391 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// fakeS3 是一个进程内的 S3 兼容服务，仅支持 path-style 寻址，不校验签名.
+// 覆盖 Uploader 用到的对象读写与分片上传接口.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+	uploads map[string]*fakeUpload
+	seq     int
+	// denyGets 为接下来拒绝读取（会话元数据除外）的次数，用于模拟网络等非内容原因的失败
+	denyGets int
+}
+
+type fakeUpload struct {
+	key   string
+	parts map[int][]byte
+}
+
+type fakePart struct {
+	PartNumber int
+	ETag       string
+	Size       int64
+}
+
+func newFakeS3(t *testing.T) *httptest.Server {
+	srv := httptest.NewServer(&fakeS3{objects: map[string][]byte{}, uploads: map[string]*fakeUpload{}})
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+func writeXML(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(xml.Header))
+	_ = xml.NewEncoder(w).Encode(v)
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	writeXML(w, status, struct {
+		XMLName xml.Name `xml:"Error"`
+		Code    string
+		Message string
+	}{Code: code, Message: code})
+}
+
+func (s *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	id := bucket + "/" + key
+	q := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPost && q.Has("uploads"):
+		s.seq++
+		uploadID := "upload-" + strconv.Itoa(s.seq)
+		s.uploads[uploadID] = &fakeUpload{key: id, parts: map[int][]byte{}}
+		writeXML(w, http.StatusOK, struct {
+			XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+			Bucket   string
+			Key      string
+			UploadId string
+		}{Bucket: bucket, Key: key, UploadId: uploadID})
+
+	case q.Has("uploadId"):
+		up, ok := s.uploads[q.Get("uploadId")]
+		if !ok || up.key != id {
+			writeS3Error(w, http.StatusNotFound, "NoSuchUpload")
+			return
+		}
+		s.serveUpload(w, r, q.Get("uploadId"), up, body)
+
+	case r.Method == http.MethodGet && q.Get("list-type") == "2":
+		s.listObjects(w, bucket, q.Get("prefix"))
+
+	case r.Method == http.MethodPut:
+		s.objects[id] = body
+		w.Header().Set("ETag", etag(body))
+
+	case r.Method == http.MethodGet && s.denyGets > 0 && !strings.Contains(key, sessionPrefix):
+		s.denyGets--
+		writeS3Error(w, http.StatusForbidden, "AccessDenied")
+
+	case r.Method == http.MethodGet, r.Method == http.MethodHead:
+		data, ok := s.objects[id]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", etag(data))
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+
+	case r.Method == http.MethodDelete:
+		delete(s.objects, id)
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeS3Error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+func (s *fakeS3) listObjects(w http.ResponseWriter, bucket string, prefix string) {
+	type content struct {
+		Key  string
+		Size int64
+		ETag string
+	}
+	contents := []content{}
+	for id, data := range s.objects {
+		if key, ok := strings.CutPrefix(id, bucket+"/"); ok && strings.HasPrefix(key, prefix) {
+			contents = append(contents, content{Key: key, Size: int64(len(data)), ETag: etag(data)})
+		}
+	}
+	sort.Slice(contents, func(i, j int) bool { return contents[i].Key < contents[j].Key })
+	writeXML(w, http.StatusOK, struct {
+		XMLName     xml.Name `xml:"ListBucketResult"`
+		Name        string
+		Prefix      string
+		KeyCount    int
+		IsTruncated bool
+		Contents    []content
+	}{Name: bucket, Prefix: prefix, KeyCount: len(contents), Contents: contents})
+}
+
+func (s *fakeS3) serveUpload(w http.ResponseWriter, r *http.Request, uploadID string, up *fakeUpload, body []byte) {
+	switch r.Method {
+	case http.MethodPut:
+		n, _ := strconv.Atoi(r.URL.Query().Get("partNumber"))
+		up.parts[n] = body
+		w.Header().Set("ETag", etag(body))
+
+	case http.MethodGet:
+		parts := make([]fakePart, 0, len(up.parts))
+		for n, data := range up.parts {
+			parts = append(parts, fakePart{PartNumber: n, ETag: etag(data), Size: int64(len(data))})
+		}
+		sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+		writeXML(w, http.StatusOK, struct {
+			XMLName     xml.Name `xml:"ListPartsResult"`
+			UploadId    string
+			IsTruncated bool
+			Part        []fakePart
+		}{UploadId: uploadID, Part: parts})
+
+	case http.MethodPost:
+		var complete struct {
+			Part []struct {
+				PartNumber int
+				ETag       string
+			}
+		}
+		if err := xml.Unmarshal(body, &complete); err != nil {
+			writeS3Error(w, http.StatusBadRequest, "MalformedXML")
+			return
+		}
+		var buf bytes.Buffer
+		for _, p := range complete.Part {
+			data, ok := up.parts[p.PartNumber]
+			if !ok || strings.Trim(p.ETag, `"`) != strings.Trim(etag(data), `"`) {
+				writeS3Error(w, http.StatusBadRequest, "InvalidPart")
+				return
+			}
+			buf.Write(data)
+		}
+		s.objects[up.key] = buf.Bytes()
+		delete(s.uploads, uploadID)
+		bucket, key, _ := strings.Cut(up.key, "/")
+		writeXML(w, http.StatusOK, struct {
+			XMLName xml.Name `xml:"CompleteMultipartUploadResult"`
+			Bucket  string
+			Key     string
+			ETag    string
+		}{Bucket: bucket, Key: key, ETag: etag(buf.Bytes())})
+
+	case http.MethodDelete:
+		delete(s.uploads, uploadID)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func newTestS3Uploader(t *testing.T, mode string) (*s3Uploader, *fakeS3) {
+	srv := newFakeS3(t)
+
+	cfg := opt.NewUploadOptions()
+	cfg.Provider = "s3"
+	cfg.S3.Endpoint = srv.URL
+	cfg.S3.Bucket = "miniblog"
+	cfg.S3.AccessKeyID = "test"
+	cfg.S3.SecretAccessKey = "test"
+	cfg.AllowedMIMEs = append(cfg.AllowedMIMEs, "text/plain")
+	cfg.Multipart.PartSizeMin = "4B"
+	cfg.Multipart.PartSizeDefault = "4B"
+	cfg.Multipart.PresignMode = mode
+
+	upl, err := NewFromConfig(cfg)
+	require.NoError(t, err)
+	return upl.(*s3Uploader), srv.Config.Handler.(*fakeS3)
+}
+
+func TestS3Uploader_Upload(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "proxy")
+
+	obj, err := u.Upload(context.Background(), &UploadInput{Filename: "a.txt", MIME: "text/plain"}, strings.NewReader("hello s3"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3", obj.Provider)
+	assert.Equal(t, int64(8), obj.Size)
+	assert.Contains(t, obj.URL, "/miniblog/"+obj.Key)
+	assert.Equal(t, []byte("hello s3"), fake.objects["miniblog/"+obj.Key])
+}
+
+func TestS3Uploader_Multipart(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "proxy")
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	content := []byte("hello multipart")
+	sum := sha256.Sum256(content)
+	sess, err := u.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: int64(len(content)), SHA256: hex.EncodeToString(sum[:])})
+	require.NoError(t, err)
+	assert.Equal(t, "proxy", sess.Mode)
+
+	_, err = u.PresignParts(ctx, sess.UploadID, []int32{1})
+	assert.Equal(t, errno.ErrPresignDisabled, err)
+
+	for n := int32(1); n <= 4; n++ {
+		start := int64(n-1) * sess.PartSize
+		end := min(start+sess.PartSize, int64(len(content)))
+		_, err := u.UploadPart(ctx, sess.UploadID, n, "", bytes.NewReader(content[start:end]))
+		require.NoError(t, err)
+	}
+
+	// 会话元数据保存在 bucket 中，其他用户无法访问
+	_, err = u.ListParts(contextx.WithUserID(context.Background(), "user-000002"), sess.UploadID)
+	assert.Equal(t, errno.ErrUploadSessionNotFound, err)
+
+	parts, err := u.ListParts(ctx, sess.UploadID)
+	require.NoError(t, err)
+	require.Len(t, parts, 4)
+
+	obj, err := u.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sess.Key, obj.Key)
+	assert.Equal(t, hex.EncodeToString(sum[:]), obj.Hash)
+	assert.Equal(t, content, fake.objects["miniblog/"+obj.Key])
+	assert.NotContains(t, fake.objects, "miniblog/"+u.sessionKey(sess.UploadID))
+}
+
+func TestS3Uploader_MultipartClaimedHash(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "direct")
+	ctx := contextx.WithUserID(context.Background(), "user-000002")
+
+	// 声明他人文件的哈希：对象键不由声明的哈希生成，合并后内容不符时删除对象
+	victim := sha256.Sum256([]byte("hello multipart"))
+	claimed := hex.EncodeToString(victim[:])
+	sess, err := u.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: 4, SHA256: claimed})
+	require.NoError(t, err)
+	assert.NotContains(t, sess.Key, claimed)
+
+	_, err = u.UploadPart(ctx, sess.UploadID, 1, "", strings.NewReader("evil"))
+	require.NoError(t, err)
+	_, err = u.CompleteMultipart(ctx, sess.UploadID, nil)
+	assert.ErrorIs(t, err, errno.ErrChecksumMismatch)
+	assert.NotContains(t, fake.objects, "miniblog/"+sess.Key)
+
+	// 未声明哈希时由服务端计算
+	sess, err = u.InitMultipart(ctx, &MultipartInput{Filename: "b.txt", Size: 4})
+	require.NoError(t, err)
+	_, err = u.UploadPart(ctx, sess.UploadID, 1, "", strings.NewReader("abcd"))
+	require.NoError(t, err)
+	obj, err := u.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.NoError(t, err)
+	sum := sha256.Sum256([]byte("abcd"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), obj.Hash)
+}
+
+func TestS3Uploader_MultipartRetry(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "proxy")
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	upload := func() *MultipartSession {
+		sess, err := u.InitMultipart(ctx, &MultipartInput{Filename: "a.txt", Size: 4})
+		require.NoError(t, err)
+		_, err = u.UploadPart(ctx, sess.UploadID, 1, "", strings.NewReader("abcd"))
+		require.NoError(t, err)
+		return sess
+	}
+
+	// 合并后处理失败时保留会话与对象，重试只重新处理对象
+	sess := upload()
+	fake.denyGets = 1
+	_, err := u.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.Error(t, err)
+	assert.Contains(t, fake.objects, "miniblog/"+sess.Key)
+	parts, err := u.ListParts(ctx, sess.UploadID)
+	require.NoError(t, err)
+	assert.Len(t, parts, 1)
+
+	obj, err := u.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), obj.Size)
+	assert.NotContains(t, fake.objects, "miniblog/"+u.sessionKey(sess.UploadID))
+
+	// 中止已合并的会话时删除对象
+	sess = upload()
+	fake.denyGets = 1
+	_, err = u.CompleteMultipart(ctx, sess.UploadID, nil)
+	require.Error(t, err)
+	require.NoError(t, u.AbortMultipart(ctx, sess.UploadID))
+	assert.NotContains(t, fake.objects, "miniblog/"+sess.Key)
+	assert.NotContains(t, fake.objects, "miniblog/"+u.sessionKey(sess.UploadID))
+}
+
+func TestS3Uploader_PresignDirect(t *testing.T) {
+	u, fake := newTestS3Uploader(t, "direct")
+	ctx := contextx.WithUserID(context.Background(), "user-000001")
+
+	sess, err := u.InitMultipart(ctx, &MultipartInput{Filename: "b.txt", Size: 6})
+	require.NoError(t, err)
+	assert.Equal(t, "direct", sess.Mode)
+
+	presigned, err := u.PresignParts(ctx, sess.UploadID, []int32{1, 2})
+	require.NoError(t, err)
+	require.Len(t, presigned, 2)
+
+	// 客户端使用预签名 URL 直传分片，并记录 ETag
+	var completed []*Part
+	for i, chunk := range []string{"abcd", "ef"} {
+		assert.Contains(t, presigned[i].URL, "X-Amz-Signature=")
+		req, _ := http.NewRequest(http.MethodPut, presigned[i].URL, strings.NewReader(chunk))
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		completed = append(completed, &Part{PartNumber: presigned[i].PartNumber, ETag: resp.Header.Get("ETag")})
+	}
+
+	obj, err := u.CompleteMultipart(ctx, sess.UploadID, completed)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("abcdef"), fake.objects["miniblog/"+obj.Key])
+
+	// ETag 不匹配时拒绝合并
+	sess, err = u.InitMultipart(ctx, &MultipartInput{Filename: "c.txt", Size: 4})
+	require.NoError(t, err)
+	_, err = u.UploadPart(ctx, sess.UploadID, 1, "", strings.NewReader("abcd"))
+	require.NoError(t, err)
+	_, err = u.CompleteMultipart(ctx, sess.UploadID, []*Part{{PartNumber: 1, ETag: fmt.Sprintf("%032d", 0)}})
+	assert.ErrorIs(t, err, errno.ErrInvalidPart)
+
+	require.NoError(t, u.AbortMultipart(ctx, sess.UploadID))
+	assert.Empty(t, fake.uploads)
+}
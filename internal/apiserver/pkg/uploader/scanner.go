@@ -0,0 +1,190 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// Scanner 检查上传内容，在文件写入最终对象键之前调用.
+type Scanner interface {
+	// Name 返回扫描器名称，出现在隔离记录与错误信息中
+	Name() string
+	// Scan 扫描 r 中的内容；发现威胁时返回威胁名称，内容安全时返回空串，扫描器不可用时返回 error.
+	Scan(ctx context.Context, r io.Reader, in *ScanInput) (string, error)
+}
+
+// ScanInput 为扫描时可参考的文件信息.
+type ScanInput struct {
+	// Filename 为客户端提供的原始文件名
+	Filename string
+	// MIME 为内容嗅探结果
+	MIME string
+	Size int64
+}
+
+// QuarantineRecord 为隔离文件的说明，以 JSON 保存在隔离文件旁.
+type QuarantineRecord struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userID"`
+	Filename  string    `json:"filename"`
+	MIME      string    `json:"mime"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Scanner   string    `json:"scanner"`
+	Threat    string    `json:"threat"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// contentScanner 依次执行扫描器，命中的文件移入隔离目录.
+type contentScanner struct {
+	scanners      []Scanner
+	failOpen      bool
+	quarantineDir string
+}
+
+// newContentScanner 根据配置创建 contentScanner，未启用时返回 nil.
+func newContentScanner(cfg *opt.ScanOptions) (*contentScanner, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	s := &contentScanner{failOpen: cfg.FailOpen, quarantineDir: cfg.QuarantineDir}
+	for _, name := range cfg.Scanners {
+		switch name {
+		case "rules":
+			s.scanners = append(s.scanners, NewRuleScanner(cfg.BlockedExtensions))
+		case "clamd":
+			if cfg.ClamAV == nil {
+				return nil, fmt.Errorf("scan: clamav options are required by the clamd scanner")
+			}
+			timeout, _ := opt.ParseDuration(cfg.ClamAV.Timeout)
+			clamd, err := NewClamdScanner(cfg.ClamAV.Address, timeout)
+			if err != nil {
+				return nil, err
+			}
+			s.scanners = append(s.scanners, clamd)
+		default:
+			return nil, fmt.Errorf("scan: unsupported scanner: %s", name)
+		}
+	}
+	if len(s.scanners) == 0 {
+		return nil, nil
+	}
+	return s, nil
+}
+
+// check 扫描 filePath 中的内容. 命中时将文件移入隔离目录并返回 ErrFileQuarantined，
+// 此时 filePath 已不存在；扫描器不可用且未配置失败放行时返回 ErrScanFailed.
+func (s *contentScanner) check(ctx context.Context, filePath string, in *ScanInput, sumHex string) error {
+	if s == nil {
+		return nil
+	}
+
+	for _, scanner := range s.scanners {
+		threat, err := s.scan(ctx, scanner, filePath, in)
+		if err != nil {
+			if s.failOpen {
+				log.W(ctx).Warnw("Content scanner unavailable, skip", "scanner", scanner.Name(), "err", err)
+				continue
+			}
+			log.W(ctx).Errorw("Content scanner unavailable", "scanner", scanner.Name(), "err", err)
+			return errno.ErrScanFailed
+		}
+		if threat == "" {
+			continue
+		}
+
+		record := &QuarantineRecord{
+			ID:        quarantineID(),
+			UserID:    contextx.UserID(ctx),
+			Filename:  in.Filename,
+			MIME:      in.MIME,
+			Size:      in.Size,
+			SHA256:    sumHex,
+			Scanner:   scanner.Name(),
+			Threat:    threat,
+			CreatedAt: time.Now(),
+		}
+		if err := s.quarantine(filePath, record); err != nil {
+			log.W(ctx).Errorw("Failed to quarantine file", "id", record.ID, "err", err)
+			_ = os.Remove(filePath)
+		}
+		log.W(ctx).Warnw("Upload quarantined", "id", record.ID, "scanner", record.Scanner, "threat", threat, "filename", in.Filename)
+
+		return errno.ErrFileQuarantined.Copy().KV("quarantineID", record.ID, "scanner", record.Scanner, "threat", threat)
+	}
+	return nil
+}
+
+// scan 使用单个扫描器扫描文件.
+func (s *contentScanner) scan(ctx context.Context, scanner Scanner, filePath string, in *ScanInput) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return scanner.Scan(ctx, f, in)
+}
+
+// quarantine 将文件移入 QuarantineDir/<日期>/<id>，并在旁边写入 <id>.json 说明.
+func (s *contentScanner) quarantine(filePath string, record *QuarantineRecord) error {
+	dir := filepath.Join(s.quarantineDir, record.CreatedAt.Format("20060102"))
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+
+	dst := filepath.Join(dir, record.ID)
+	if err := os.Rename(filePath, dst); err != nil {
+		// 临时文件与隔离目录可能不在同一文件系统
+		if err := copyFile(filePath, dst); err != nil {
+			return err
+		}
+		_ = os.Remove(filePath)
+	}
+
+	data, _ := json.MarshalIndent(record, "", "  ")
+	return os.WriteFile(dst+".json", data, 0o600)
+}
+
+// copyFile 复制文件内容，权限仅限当前用户.
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// quarantineID 生成按时间排序的隔离 ID.
+func quarantineID() string {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("%s-%s", time.Now().UTC().Format("150405.000000"), hex.EncodeToString(b))
+}
@@ -0,0 +1,136 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// clamdChunkSize 为 INSTREAM 每个数据块的大小
+	clamdChunkSize = 64 << 10
+	// defaultClamdTimeout 为未配置超时时单次扫描的超时时间
+	defaultClamdTimeout = 30 * time.Second
+)
+
+// ClamdScanner 通过 clamd 的 INSTREAM 命令扫描内容，兼容 TCP 与 Unix socket.
+type ClamdScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// 确保 ClamdScanner 实现了 Scanner 接口.
+var _ Scanner = (*ClamdScanner)(nil)
+
+// NewClamdScanner 创建 ClamdScanner，address 形如 tcp://127.0.0.1:3310 或 unix:///var/run/clamav/clamd.ctl，
+// 不带协议前缀时按 TCP 地址处理.
+func NewClamdScanner(address string, timeout time.Duration) (*ClamdScanner, error) {
+	if timeout <= 0 {
+		timeout = defaultClamdTimeout
+	}
+
+	s := &ClamdScanner{network: "tcp", address: address, timeout: timeout}
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return nil, fmt.Errorf("clamd: invalid address: %w", err)
+		}
+		switch u.Scheme {
+		case "tcp":
+			s.address = u.Host
+		case "unix":
+			s.network, s.address = "unix", u.Path
+		default:
+			return nil, fmt.Errorf("clamd: unsupported address scheme: %s", u.Scheme)
+		}
+	}
+	if s.address == "" {
+		return nil, fmt.Errorf("clamd: address is required")
+	}
+	return s, nil
+}
+
+// Name 实现 Scanner 接口.
+func (s *ClamdScanner) Name() string {
+	return "clamd"
+}
+
+// Scan 实现 Scanner 接口. 协议：发送 zINSTREAM，随后是若干 <4 字节大端长度><数据> 块，以长度 0 结束；
+// clamd 返回 "stream: OK"、"stream: <病毒名> FOUND" 或 "<原因> ERROR".
+func (s *ClamdScanner) Scan(ctx context.Context, r io.Reader, in *ScanInput) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, s.network, s.address)
+	if err != nil {
+		return "", fmt.Errorf("clamd: %w", err)
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("clamd: %w", err)
+	}
+
+	w := bufio.NewWriterSize(conn, clamdChunkSize+4)
+	buf := make([]byte, clamdChunkSize)
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			_ = binary.Write(w, binary.BigEndian, uint32(n))
+			if _, werr := w.Write(buf[:n]); werr != nil {
+				// clamd 超出 StreamMaxLength 时会提前返回错误并关闭连接
+				return s.readReply(conn, werr)
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	_ = binary.Write(w, binary.BigEndian, uint32(0))
+	if err := w.Flush(); err != nil {
+		return s.readReply(conn, err)
+	}
+	return s.readReply(conn, nil)
+}
+
+// readReply 读取并解析 clamd 的应答；writeErr 为发送数据时的错误，读不到应答时返回该错误.
+func (s *ClamdScanner) readReply(conn net.Conn, writeErr error) (string, error) {
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		if writeErr != nil {
+			return "", fmt.Errorf("clamd: %w", writeErr)
+		}
+		return "", fmt.Errorf("clamd: %w", err)
+	}
+
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	reply = strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case reply == "OK":
+		return "", nil
+	case strings.HasSuffix(reply, " FOUND"):
+		return strings.TrimSuffix(reply, " FOUND"), nil
+	default:
+		return "", fmt.Errorf("clamd: %s", reply)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	// ruleChunkSize 为规则扫描每次读取的字节数
+	ruleChunkSize = 64 << 10
+	// ruleOverlap 为相邻块之间保留的字节数，避免特征跨块时漏报
+	ruleOverlap = 64
+	// zipTailSize 为查找 ZIP 目录结束标记的尾部范围（22 字节记录 + 最长 64KB 注释）
+	zipTailSize = 22 + 64<<10
+)
+
+// executableMagics 为常见可执行文件的魔数，仅用于检查二进制或未知类型的文件.
+var executableMagics = []struct {
+	magic  []byte
+	threat string
+}{
+	{[]byte("MZ"), "Executable.PE"},
+	{[]byte("\x7fELF"), "Executable.ELF"},
+	{[]byte{0xFE, 0xED, 0xFA, 0xCE}, "Executable.MachO"},
+	{[]byte{0xFE, 0xED, 0xFA, 0xCF}, "Executable.MachO"},
+	{[]byte{0xCE, 0xFA, 0xED, 0xFE}, "Executable.MachO"},
+	{[]byte{0xCF, 0xFA, 0xED, 0xFE}, "Executable.MachO"},
+	{[]byte{0xCA, 0xFE, 0xBA, 0xBE}, "Executable.MachO"},
+	{[]byte("#!"), "Executable.Script"},
+}
+
+// markupScriptRegex 匹配 HTML/SVG 中可执行脚本的写法.
+var markupScriptRegex = regexp.MustCompile(`<script[\s>/]|javascript:|\son[a-z]+\s*=`)
+
+// polyglotMarkers 为不应出现在二进制文件（图片、PDF 等）中的标记，出现时通常是伪装成图片的页面或脚本.
+var polyglotMarkers = [][]byte{[]byte("<script"), []byte("<?php"), []byte("<html"), []byte("<iframe")}
+
+// zipEOCD 为 ZIP 目录结束标记；非压缩包类型的文件末尾带有该标记时，通常是拼接了压缩包的多格式文件.
+var zipEOCD = []byte("PK\x05\x06")
+
+// markupMIMEs 为会被浏览器解析执行脚本的文本类型.
+var markupMIMEs = map[string]bool{
+	"text/html":             true,
+	"application/xhtml+xml": true,
+	"image/svg+xml":         true,
+	"text/xml":              true,
+	"application/xml":       true,
+}
+
+// archiveMIMEs 为本身基于 ZIP 的类型，末尾带有目录结束标记是正常的.
+var archiveMIMEs = map[string]bool{
+	"application/zip":              true,
+	"application/x-zip-compressed": true,
+	"application/java-archive":     true,
+	"application/epub+zip":         true,
+}
+
+// RuleScanner 是内置的规则扫描器：拒绝可执行文件、带脚本的 HTML/SVG 以及多格式混合文件.
+type RuleScanner struct {
+	blockedExts map[string]bool
+}
+
+// 确保 RuleScanner 实现了 Scanner 接口.
+var _ Scanner = (*RuleScanner)(nil)
+
+// NewRuleScanner 创建 RuleScanner，blockedExts 为按文件名拒绝的扩展名（如 .exe）.
+func NewRuleScanner(blockedExts []string) *RuleScanner {
+	s := &RuleScanner{blockedExts: make(map[string]bool, len(blockedExts))}
+	for _, ext := range blockedExts {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext != "" && !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		s.blockedExts[ext] = true
+	}
+	return s
+}
+
+// Name 实现 Scanner 接口.
+func (s *RuleScanner) Name() string {
+	return "rules"
+}
+
+// Scan 实现 Scanner 接口.
+func (s *RuleScanner) Scan(ctx context.Context, r io.Reader, in *ScanInput) (string, error) {
+	if ext := strings.ToLower(filepath.Ext(in.Filename)); ext != "" && s.blockedExts[ext] {
+		return "Blocked.Extension" + ext, nil
+	}
+
+	mimeType := baseMIME(in.MIME)
+	markup := markupMIMEs[mimeType]
+	// 纯文本（如 Markdown、JSON）中出现 HTML 片段是正常的，不作为多格式文件处理
+	binary := !markup && !strings.HasPrefix(mimeType, "text/") && mimeType != "application/json"
+
+	var (
+		buf   = make([]byte, ruleOverlap+ruleChunkSize)
+		lower = make([]byte, len(buf))
+		carry int
+		first = true
+		tail  []byte
+	)
+	for {
+		n, err := io.ReadFull(r, buf[carry:])
+		if n > 0 {
+			window := buf[:carry+n]
+			// 文本内容以 #! 或 MZ 开头是正常的，只检查二进制或未知类型
+			if first && binary {
+				for _, m := range executableMagics {
+					if bytes.HasPrefix(window, m.magic) {
+						return m.threat, nil
+					}
+				}
+			}
+			first = false
+
+			lower := asciiLower(lower[:len(window)], window)
+			if markup && markupScriptRegex.Match(lower) {
+				return "HTML.Script", nil
+			}
+			if binary {
+				for _, marker := range polyglotMarkers {
+					if bytes.Contains(lower, marker) {
+						return "Polyglot.Markup", nil
+					}
+				}
+			}
+
+			tail = append(tail, buf[carry:carry+n]...)
+			if len(tail) > zipTailSize {
+				tail = tail[len(tail)-zipTailSize:]
+			}
+
+			carry = min(ruleOverlap, len(window))
+			copy(buf, window[len(window)-carry:])
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+	}
+
+	if binary && !archiveMIMEs[mimeType] && !strings.HasPrefix(mimeType, "application/vnd.") && bytes.Contains(tail, zipEOCD) {
+		return "Polyglot.Archive", nil
+	}
+	return "", nil
+}
+
+// asciiLower 将 src 中的 ASCII 大写字母转为小写写入 dst；二进制内容不按 UTF-8 解码.
+func asciiLower(dst []byte, src []byte) []byte {
+	for i, c := range src {
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		dst[i] = c
+	}
+	return dst
+}
@@ -0,0 +1,205 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"image/png"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// eicar 为标准的反病毒测试字符串.
+const eicar = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+func testPNG(t *testing.T) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, testImage(8, 8, 255)))
+	return buf.Bytes()
+}
+
+func TestRuleScanner(t *testing.T) {
+	s := NewRuleScanner([]string{".exe", "bat"})
+	pngData := testPNG(t)
+	// 标记跨越两个读取块
+	padded := append(append(append([]byte{}, pngData...), bytes.Repeat([]byte{0}, ruleOverlap+ruleChunkSize-len(pngData)-3)...), "<?php system($_GET['c']);"...)
+
+	for _, tc := range []struct {
+		name     string
+		filename string
+		mime     string
+		data     []byte
+		want     string
+	}{
+		{"blocked extension", "setup.EXE", "application/octet-stream", []byte("hello"), "Blocked.Extension.exe"},
+		{"blocked extension without dot", "run.bat", "text/plain", []byte("echo"), "Blocked.Extension.bat"},
+		{"pe", "a.bin", "application/octet-stream", []byte("MZ\x90\x00\x03"), "Executable.PE"},
+		{"elf", "a.bin", "application/octet-stream", []byte("\x7fELF\x02\x01"), "Executable.ELF"},
+		{"shebang", "a.bin", "application/octet-stream", []byte("#!/bin/sh\nrm -rf /"), "Executable.Script"},
+		{"shebang unknown type", "a", "", []byte("#!/bin/sh\nrm -rf /"), "Executable.Script"},
+		{"text with shebang", "a.txt", "text/plain", []byte("#!/bin/sh is the first line of a script"), ""},
+		{"csv starting with MZ", "a.csv", "text/csv", []byte("MZ,Mozambique\nZA,South Africa\n"), ""},
+		{"html script", "a.html", "text/html", []byte("<html><SCRIPT>alert(1)</SCRIPT></html>"), "HTML.Script"},
+		{"svg handler", "a.svg", "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg" onload="alert(1)"/>`), "HTML.Script"},
+		{"svg clean", "a.svg", "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><rect width="1"/></svg>`), ""},
+		{"png with php", "a.png", "image/png", padded, "Polyglot.Markup"},
+		{"png with zip", "a.png", "image/png", append(append([]byte{}, pngData...), "PK\x03\x04payloadPK\x05\x06\x00\x00"...), "Polyglot.Archive"},
+		{"zip", "a.zip", "application/zip", []byte("PK\x03\x04payloadPK\x05\x06\x00\x00"), ""},
+		{"markdown with html", "a.md", "text/markdown", []byte("```html\n<script>alert(1)</script>\n```"), ""},
+		{"png", "a.png", "image/png", pngData, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			threat, err := s.Scan(context.Background(), bytes.NewReader(tc.data), &ScanInput{Filename: tc.filename, MIME: tc.mime, Size: int64(len(tc.data))})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, threat)
+		})
+	}
+}
+
+// startFakeClamd 启动一个实现 INSTREAM 的 clamd，内容包含 EICAR 时报告病毒.
+func startFakeClamd(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				if cmd, err := r.ReadString(0); err != nil || cmd != "zINSTREAM\x00" {
+					_, _ = conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+
+				var data []byte
+				for {
+					var n uint32
+					if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+						return
+					}
+					if n == 0 {
+						break
+					}
+					chunk := make([]byte, n)
+					if _, err := io.ReadFull(r, chunk); err != nil {
+						return
+					}
+					data = append(data, chunk...)
+				}
+
+				reply := "stream: OK\x00"
+				if bytes.Contains(data, []byte(eicar)) {
+					reply = "stream: Win.Test.EICAR_HDB-1 FOUND\x00"
+				}
+				_, _ = conn.Write([]byte(reply))
+			}(conn)
+		}
+	}()
+	return "tcp://" + ln.Addr().String()
+}
+
+func TestClamdScanner(t *testing.T) {
+	s, err := NewClamdScanner(startFakeClamd(t), time.Second)
+	require.NoError(t, err)
+
+	// 超过一个数据块的内容
+	threat, err := s.Scan(context.Background(), strings.NewReader(strings.Repeat("a", clamdChunkSize+10)+eicar), &ScanInput{})
+	require.NoError(t, err)
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", threat)
+
+	threat, err = s.Scan(context.Background(), strings.NewReader("hello"), &ScanInput{})
+	require.NoError(t, err)
+	assert.Empty(t, threat)
+
+	// clamd 不可用
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	_ = ln.Close()
+	down, err := NewClamdScanner(addr, time.Second)
+	require.NoError(t, err)
+	_, err = down.Scan(context.Background(), strings.NewReader("hello"), &ScanInput{})
+	assert.Error(t, err)
+}
+
+func newScanTestUploader(t *testing.T, scan *opt.ScanOptions) *localUploader {
+	cfg := opt.NewUploadOptions()
+	cfg.Local.BaseDir = t.TempDir()
+	cfg.AllowedMIMEs = append(cfg.AllowedMIMEs, "text/plain")
+	cfg.Image.Enabled = false
+	cfg.Scan = scan
+	l, err := newLocalUploader(cfg)
+	require.NoError(t, err)
+	return l
+}
+
+func TestLocalUploader_Quarantine(t *testing.T) {
+	quarantineDir := t.TempDir()
+	l := newScanTestUploader(t, &opt.ScanOptions{
+		Enabled:       true,
+		Scanners:      []string{"rules", "clamd"},
+		QuarantineDir: quarantineDir,
+		ClamAV:        &opt.ClamAVOptions{Address: startFakeClamd(t), Timeout: "1s"},
+	})
+
+	_, err := l.Upload(context.Background(), &UploadInput{Filename: "a.png"}, bytes.NewReader(testPNG(t)))
+	require.NoError(t, err)
+
+	_, err = l.Upload(context.Background(), &UploadInput{Filename: "eicar.txt"}, strings.NewReader(eicar))
+	require.ErrorIs(t, err, errno.ErrFileQuarantined)
+	md := errno.FromError(err).Metadata
+	assert.Equal(t, "clamd", md["scanner"])
+	assert.Equal(t, "Win.Test.EICAR_HDB-1", md["threat"])
+
+	// 隔离文件与说明保存在隔离目录中，未写入存储
+	matches, _ := filepath.Glob(filepath.Join(quarantineDir, "*", md["quarantineID"]+".json"))
+	require.Len(t, matches, 1)
+	var record QuarantineRecord
+	data, _ := os.ReadFile(matches[0])
+	require.NoError(t, json.Unmarshal(data, &record))
+	assert.Equal(t, "eicar.txt", record.Filename)
+	stored, err := os.ReadFile(strings.TrimSuffix(matches[0], ".json"))
+	require.NoError(t, err)
+	assert.Equal(t, eicar, string(stored))
+
+	tmp, _ := os.ReadDir(filepath.Join(l.cfg.Local.BaseDir, ".tmp"))
+	assert.Empty(t, tmp)
+}
+
+func TestLocalUploader_ScanFailure(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	_ = ln.Close()
+
+	scan := &opt.ScanOptions{Enabled: true, Scanners: []string{"clamd"}, QuarantineDir: t.TempDir(), ClamAV: &opt.ClamAVOptions{Address: addr, Timeout: "1s"}}
+	_, err = newScanTestUploader(t, scan).Upload(context.Background(), &UploadInput{Filename: "a.txt"}, strings.NewReader("hello"))
+	assert.ErrorIs(t, err, errno.ErrScanFailed)
+
+	scan.FailOpen = true
+	_, err = newScanTestUploader(t, scan).Upload(context.Background(), &UploadInput{Filename: "a.txt"}, strings.NewReader("hello"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,180 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// 对象访问权限，语义与 OSS 的 ACL 一致.
+const (
+	ACLPrivate    = "private"
+	ACLPublicRead = "public-read"
+)
+
+// 签名 URL 的查询参数.
+const (
+	queryExpires   = "expires"
+	querySignature = "signature"
+)
+
+// defaultURLExpires 为配置缺失或解析失败时签名 URL 的有效期.
+const defaultURLExpires = 15 * time.Minute
+
+// ObjectServer 由本地存储实现：存在私有对象时由服务端校验签名后读取对象，替代公开的静态目录.
+type ObjectServer interface {
+	// PrivateMode 返回是否存在私有对象（默认 ACL 或任一场景的 ACL 为 private）
+	PrivateMode() bool
+	// ObjectACL 返回 scene 下对象的访问权限：ACLPrivate 或 ACLPublicRead
+	ObjectACL(scene string) string
+	// SignURL 为对象的访问 URL 追加签名参数；rawURL 不是本存储的对象 URL 时原样返回
+	SignURL(rawURL string) string
+	// VerifyURL 校验对象 key 的签名参数，签名不正确返回 ErrSignatureMismatch，过期返回 ErrSignedURLExpired
+	VerifyURL(key string, query url.Values) error
+	// Open 打开对象文件，以 . 开头的目录（临时文件、分片会话等）不可访问
+	Open(key string) (*os.File, error)
+}
+
+// 确保 localUploader 实现了 ObjectServer 接口.
+var _ ObjectServer = (*localUploader)(nil)
+
+// SignObjectURLs 为私有场景的对象 URL 及 metadata 中的衍生图 URL 追加签名；
+// 存储后端不支持签名或对象公开时原样返回. metadata 会被原地修改.
+func SignObjectURLs(upl Uploader, scene string, rawURL string, metadata map[string]string) string {
+	srv, ok := upl.(ObjectServer)
+	if !ok || srv.ObjectACL(scene) != ACLPrivate {
+		return rawURL
+	}
+	for k, v := range metadata {
+		if strings.HasPrefix(k, metadataVariantPrefix) {
+			metadata[k] = srv.SignURL(v)
+		}
+	}
+	return srv.SignURL(rawURL)
+}
+
+// PrivateMode 实现 ObjectServer 接口.
+func (l *localUploader) PrivateMode() bool {
+	return l.cfg.HasPrivateLocalObjects()
+}
+
+// ObjectACL 实现 ObjectServer 接口. 场景未配置 ACL 时使用存储的默认 ACL，public-read-write 按 public-read 处理.
+func (l *localUploader) ObjectACL(scene string) string {
+	acl := l.cfg.Local.ACL
+	if s, ok := l.cfg.Scenes[scene]; ok && s != nil && s.ACL != "" {
+		acl = s.ACL
+	}
+	if strings.EqualFold(acl, ACLPrivate) {
+		return ACLPrivate
+	}
+	return ACLPublicRead
+}
+
+// SignURL 实现 ObjectServer 接口. 过期时间向上取整到分钟，同一分钟内多次签名得到相同的 URL，便于浏览器缓存.
+func (l *localUploader) SignURL(rawURL string) string {
+	prefix := l.publicURL("")
+	if !strings.HasPrefix(rawURL, prefix) {
+		return rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	key := strings.TrimPrefix(rawURL, prefix)
+	if i := strings.IndexAny(key, "?#"); i >= 0 {
+		key = key[:i]
+	}
+	if unescaped, err := url.PathUnescape(key); err == nil {
+		key = unescaped
+	}
+
+	expiresAt := time.Now().Add(l.urlExpires()).Truncate(time.Minute).Add(time.Minute)
+	q := u.Query()
+	q.Set(queryExpires, strconv.FormatInt(expiresAt.Unix(), 10))
+	q.Set(querySignature, l.signature(key, expiresAt.Unix()))
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// VerifyURL 实现 ObjectServer 接口.
+func (l *localUploader) VerifyURL(key string, query url.Values) error {
+	expires, err := strconv.ParseInt(query.Get(queryExpires), 10, 64)
+	if err != nil || query.Get(querySignature) == "" {
+		return errno.ErrSignatureMismatch
+	}
+	if !hmac.Equal([]byte(query.Get(querySignature)), []byte(l.signature(key, expires))) {
+		return errno.ErrSignatureMismatch
+	}
+	if time.Now().Unix() > expires {
+		return errno.ErrSignedURLExpired
+	}
+	return nil
+}
+
+// Open 实现 ObjectServer 接口.
+func (l *localUploader) Open(key string) (*os.File, error) {
+	key = strings.TrimPrefix(path.Clean("/"+key), "/")
+	if key == "" {
+		return nil, os.ErrNotExist
+	}
+	if hasHiddenSegment(key) {
+		return nil, os.ErrNotExist
+	}
+	return os.Open(filepath.Join(l.cfg.Local.BaseDir, filepath.FromSlash(key)))
+}
+
+// StaticDir 返回用于公开 BaseDir 的静态文件系统，以 . 开头的路径（暂存、隔离等内部目录）不对外提供.
+func StaticDir(dir string) http.FileSystem {
+	return staticDir{http.Dir(dir)}
+}
+
+type staticDir struct {
+	http.FileSystem
+}
+
+func (d staticDir) Open(name string) (http.File, error) {
+	if hasHiddenSegment(path.Clean("/" + name)) {
+		return nil, os.ErrNotExist
+	}
+	return d.FileSystem.Open(name)
+}
+
+// hasHiddenSegment 返回 key 中是否有以 . 开头的路径段.
+func hasHiddenSegment(key string) bool {
+	for _, segment := range strings.Split(key, "/") {
+		if strings.HasPrefix(segment, ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// signature 计算 key 与过期时间的 HMAC-SHA256 签名.
+func (l *localUploader) signature(key string, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(l.cfg.Local.SignKey))
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (l *localUploader) urlExpires() time.Duration {
+	if d, err := opt.ParseDuration(l.cfg.Local.URLExpires); err == nil && d > 0 {
+		return d
+	}
+	return defaultURLExpires
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package uploader
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	opt "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+func newPrivateUploader(t *testing.T) *localUploader {
+	cfg := opt.NewUploadOptions()
+	cfg.Local.BaseDir = t.TempDir()
+	cfg.Local.ACL = ACLPrivate
+	cfg.Local.SignKey = "0123456789abcdef"
+	l, err := newLocalUploader(cfg)
+	require.NoError(t, err)
+	return l
+}
+
+func TestSignURL(t *testing.T) {
+	l := newPrivateUploader(t)
+	assert.True(t, l.PrivateMode())
+	assert.Equal(t, ACLPrivate, l.ObjectACL(""))
+	assert.Equal(t, ACLPublicRead, l.ObjectACL("avatar"))
+
+	key := "2025/01/02/abcd.png"
+	signed := l.SignURL(l.publicURL(key))
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(u.Path, "/"+key))
+	require.NoError(t, l.VerifyURL(key, u.Query()))
+
+	// 签名与对象键绑定
+	assert.ErrorIs(t, l.VerifyURL("2025/01/02/other.png", u.Query()), errno.ErrSignatureMismatch)
+	assert.ErrorIs(t, l.VerifyURL(key, url.Values{}), errno.ErrSignatureMismatch)
+
+	// 篡改过期时间
+	q := u.Query()
+	q.Set(queryExpires, strconv.FormatInt(time.Now().Add(time.Hour*24).Unix(), 10))
+	assert.ErrorIs(t, l.VerifyURL(key, q), errno.ErrSignatureMismatch)
+
+	// 已过期
+	expired := time.Now().Add(-time.Minute).Unix()
+	q = url.Values{queryExpires: {strconv.FormatInt(expired, 10)}, querySignature: {l.signature(key, expired)}}
+	assert.ErrorIs(t, l.VerifyURL(key, q), errno.ErrSignedURLExpired)
+
+	// 非本存储的 URL 原样返回
+	assert.Equal(t, "https://example.com/a.png", l.SignURL("https://example.com/a.png"))
+}
+
+func TestSignObjectURLs(t *testing.T) {
+	l := newPrivateUploader(t)
+	metadata := map[string]string{"variant.thumb": l.publicURL("a@thumb.jpg"), "width": "640"}
+
+	signed := SignObjectURLs(l, "", l.publicURL("a.png"), metadata)
+	assert.Contains(t, signed, "signature=")
+	assert.Contains(t, metadata["variant.thumb"], "signature=")
+	assert.Equal(t, "640", metadata["width"])
+
+	// 公开场景不签名
+	assert.Equal(t, l.publicURL("b.png"), SignObjectURLs(l, "avatar", l.publicURL("b.png"), nil))
+}
+
+func TestOpenObject(t *testing.T) {
+	l := newPrivateUploader(t)
+	base := l.cfg.Local.BaseDir
+	require.NoError(t, os.MkdirAll(filepath.Join(base, ".tmp"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, ".tmp", "x.tmp"), []byte("tmp"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "a.txt"), []byte("hello"), 0o600))
+
+	f, err := l.Open("/a.txt")
+	require.NoError(t, err)
+	_ = f.Close()
+
+	for _, key := range []string{".tmp/x.tmp", "../a.txt/../.tmp/x.tmp", ""} {
+		_, err := l.Open(key)
+		assert.ErrorIs(t, err, os.ErrNotExist, key)
+	}
+}
+
+func TestStaticDir(t *testing.T) {
+	base := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(base, ".tus", "id"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(base, ".tus", "id", "data"), []byte("<script>"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(base, "a.txt"), []byte("hello"), 0o600))
+
+	fs := StaticDir(base)
+	f, err := fs.Open("/a.txt")
+	require.NoError(t, err)
+	_ = f.Close()
+
+	// 暂存目录等以 . 开头的路径不对外提供
+	for _, name := range []string{"/.tus/id/data", "/a/../.tus/id/data", "/.tus"} {
+		_, err := fs.Open(name)
+		assert.ErrorIs(t, err, os.ErrNotExist, name)
+	}
+}
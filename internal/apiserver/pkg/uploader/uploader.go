@@ -9,8 +9,11 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"maps"
 	"mime"
 	"net/url"
 	"os"
@@ -18,6 +21,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
 	opt "github.com/clin211/miniblog-v2/pkg/options"
 )
 
@@ -28,84 +33,380 @@ type UploadedObject struct {
 	URL      string
 	Size     int64
 	MIME     string
+	// Hash 为服务端根据上传内容计算的 sha256，无法计算时为空，用于去重
 	Hash     string
 	Metadata map[string]string
 }
 
+// UploadInput 为单文件上传的入参.
+type UploadInput struct {
+	Filename string
+	// MIME 为客户端声明的类型，最终以内容嗅探结果为准
+	MIME string
+	// Scene 用于选择场景级的大小与类型限制
+	Scene string
+	// Size 为客户端声明的大小（可选），大于 0 时用于提前拒绝超限文件
+	Size int64
+}
+
 // Uploader 定义上传抽象
 type Uploader interface {
-	Upload(ctx context.Context, filename string, mimeType string, r io.Reader) (*UploadedObject, error)
+	// Provider 返回存储后端名称（local | s3 | alioss），与 UploadedObject.Provider 一致
+	Provider() string
+	Upload(ctx context.Context, in *UploadInput, r io.Reader) (*UploadedObject, error)
 }
 
-// NewLocalOrFromConfig 根据配置返回 Uploader（默认 local）
-func NewLocalOrFromConfig(cfg *opt.UploadOptions) Uploader {
-	if cfg == nil || strings.ToLower(cfg.Provider) == "local" {
-		if cfg == nil {
-			cfg = opt.NewUploadOptions()
-		}
-		return &localUploader{cfg: cfg}
+// SizeLimiter 返回所有场景中最大的上传大小限制（0 表示不限制），
+// 供 HTTP 层在解析请求体之前尽早拒绝超限请求.
+type SizeLimiter interface {
+	MaxUploadSize() int64
+}
+
+// Remover 由支持删除对象的存储后端实现，用于内容去重时清理重复写入的对象.
+// key 为 UploadedObject.Key；对象不存在时不返回错误.
+type Remover interface {
+	Remove(ctx context.Context, key string) error
+}
+
+// Deduplicator 返回是否启用内容去重（UploadOptions.Deduplicate），由业务层据此复用已存在的对象.
+type Deduplicator interface {
+	Deduplicate() bool
+}
+
+// MultipartInput 为初始化分片上传会话的入参.
+type MultipartInput struct {
+	Filename string
+	Size     int64
+	MIME     string
+	Scene    string
+	SHA256   string
+	PartSize int64
+}
+
+// MultipartSession 为分片上传会话信息.
+type MultipartSession struct {
+	UploadID string
+	Key      string
+	Mode     string // proxy | direct
+	PartSize int64
+	// PresignExpires 为直传 URL 的有效期，仅 direct 模式有意义
+	PresignExpires time.Duration
+}
+
+// Part 为已上传分片的信息.
+type Part struct {
+	PartNumber int32
+	ETag       string
+	Size       int64
+	Checksum   string
+}
+
+// MultipartUploader 定义分片上传抽象.
+// 会话与调用者（contextx.UserID）绑定，其他用户无法访问.
+type MultipartUploader interface {
+	InitMultipart(ctx context.Context, in *MultipartInput) (*MultipartSession, error)
+	UploadPart(ctx context.Context, uploadID string, partNumber int32, checksum string, r io.Reader) (*Part, error)
+	ListParts(ctx context.Context, uploadID string) ([]*Part, error)
+	// CompleteMultipart 按分片号顺序合并 parts；parts 为空时合并全部已上传分片.
+	CompleteMultipart(ctx context.Context, uploadID string, parts []*Part) (*UploadedObject, error)
+	AbortMultipart(ctx context.Context, uploadID string) error
+}
+
+// Janitor 由需要定期清理过期分片会话的存储后端实现.
+// 在服务启动时调用 StartJanitor，ctx 取消后停止清理.
+type Janitor interface {
+	StartJanitor(ctx context.Context)
+}
+
+// PresignedPart 为直传分片的预签名信息.
+type PresignedPart struct {
+	PartNumber int32
+	URL        string
+	Headers    map[string]string
+	ExpiresAt  time.Time
+}
+
+// PartPresigner 由支持客户端直传（presign.mode=direct）的存储后端实现.
+type PartPresigner interface {
+	PresignParts(ctx context.Context, uploadID string, partNumbers []int32) ([]*PresignedPart, error)
+}
+
+// NewFromConfig 根据 Provider 返回对应的 Uploader（默认 local）.
+// s3 与 alioss 均通过 S3 兼容协议访问.
+func NewFromConfig(cfg *opt.UploadOptions) (Uploader, error) {
+	if cfg == nil {
+		cfg = opt.NewUploadOptions()
+	}
+
+	switch strings.ToLower(cfg.Provider) {
+	case "", "local":
+		return newLocalUploader(cfg)
+	case "s3":
+		return newS3Uploader("s3", cfg, cfg.S3)
+	case "alioss":
+		return newS3Uploader("alioss", cfg, s3OptionsFromAliOSS(cfg.AliOSS))
+	default:
+		return nil, fmt.Errorf("unsupported upload provider: %s", cfg.Provider)
 	}
-	// 仅占位：当前仅实现本地
-	return &localUploader{cfg: cfg}
 }
 
+// localUploader 将对象保存在本地磁盘，同时实现了 MultipartUploader.
 type localUploader struct {
-	cfg *opt.UploadOptions
+	*policy
+
+	cfg       *opt.UploadOptions
+	keys      *KeyTemplate
+	images    *imageProcessor
+	scanner   *contentScanner
+	multipart *localMultipart
 }
 
-func (l *localUploader) Upload(ctx context.Context, filename string, mimeType string, r io.Reader) (*UploadedObject, error) {
-	// 解析扩展名
-	ext := filepath.Ext(filename)
-	if ext == "" {
-		if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
-			ext = exts[0]
-		}
+// 确保 localUploader 同时实现了 Uploader 与 MultipartUploader 接口.
+var (
+	_ Uploader          = (*localUploader)(nil)
+	_ MultipartUploader = (*localUploader)(nil)
+	_ Remover           = (*localUploader)(nil)
+	_ Janitor           = (*localUploader)(nil)
+)
+
+func newLocalUploader(cfg *opt.UploadOptions) (*localUploader, error) {
+	keys, err := ParseKeyTemplate(cfg.KeyTemplate)
+	if err != nil {
+		return nil, err
 	}
 
-	// 读取并计算哈希
-	hasher := sha256.New()
-	tmpPath := filepath.Join(l.cfg.Local.BaseDir, ".tmp", fmt.Sprintf("%d.tmp", time.Now().UnixNano()))
-	if err := os.MkdirAll(filepath.Dir(tmpPath), os.FileMode(l.cfg.Local.MkdirPerm)); err != nil {
+	scanner, err := newContentScanner(cfg.Scan)
+	if err != nil {
 		return nil, err
 	}
-	f, err := os.Create(tmpPath)
+
+	l := &localUploader{cfg: cfg, policy: newPolicy(cfg), keys: keys, images: newImageProcessor(cfg.Image), scanner: scanner}
+	l.multipart = newLocalMultipart(l)
+	return l, nil
+}
+
+func (l *localUploader) Provider() string {
+	return "local"
+}
+
+func (l *localUploader) Upload(ctx context.Context, in *UploadInput, r io.Reader) (*UploadedObject, error) {
+	// 校验大小与类型，mimeType 为内容嗅探结果
+	r, mimeType, err := l.guard(in.Scene, in.MIME, in.Size, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// 读取并计算哈希
+	hasher := sha256.New()
+	tmpPath, f, err := l.createTemp()
 	if err != nil {
 		return nil, err
 	}
-	defer f.Close()
 
 	n, err := io.Copy(io.MultiWriter(f, hasher), r)
+	_ = f.Close()
 	if err != nil {
 		_ = os.Remove(tmpPath)
 		return nil, err
 	}
 
-	sum := hasher.Sum(nil)
-	sumHex := hex.EncodeToString(sum)
+	sumHex := hex.EncodeToString(hasher.Sum(nil))
+	key := l.keys.objectKey(ctx, in.Filename, mimeType, in.Scene, sumHex)
+	return l.commit(ctx, tmpPath, key, mimeType, sumHex, n, objectMetadata(in.Scene, in.Filename))
+}
+
+// Remove 删除本地对象文件及其衍生图.
+func (l *localUploader) Remove(ctx context.Context, key string) error {
+	// 以根路径清理 key，避免 ../ 越出 BaseDir
+	absPath := filepath.Join(l.cfg.Local.BaseDir, filepath.Clean("/"+key))
+	if err := os.Remove(absPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	derived, _ := filepath.Glob(escapeGlob(DerivativePrefix(absPath)) + "*")
+	for _, p := range derived {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// createTemp 在 BaseDir/.tmp 下创建临时文件，调用方负责关闭并在失败时删除.
+func (l *localUploader) createTemp() (string, *os.File, error) {
+	dir := filepath.Join(l.cfg.Local.BaseDir, ".tmp")
+	if err := os.MkdirAll(dir, os.FileMode(l.cfg.Local.MkdirPerm)); err != nil {
+		return "", nil, err
+	}
+	f, err := os.CreateTemp(dir, "*.tmp")
+	if err != nil {
+		return "", nil, err
+	}
+	if err := f.Chmod(0o644); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return "", nil, err
+	}
+	return f.Name(), f, nil
+}
+
+// placeFile 将临时文件移动到 absPath 并删除临时文件，不覆盖已存在的对象.
+// 已存在的对象内容相同时直接复用，内容不同时返回 ErrObjectKeyConflict.
+func placeFile(tmpPath string, absPath string) error {
+	defer os.Remove(tmpPath)
+
+	// 硬链接在目标存在时失败，检查与写入之间不会被并发上传覆盖
+	err := os.Link(tmpPath, absPath)
+	if err == nil || !errors.Is(err, fs.ErrExist) {
+		return err
+	}
+	same, err := sameContent(tmpPath, absPath)
+	if err != nil {
+		return err
+	}
+	if !same {
+		return errno.ErrObjectKeyConflict
+	}
+	return nil
+}
+
+// sameContent 比较两个文件的内容是否相同.
+func sameContent(a string, b string) (bool, error) {
+	sumA, sizeA, err := fileSHA256(a)
+	if err != nil {
+		return false, err
+	}
+	sumB, sizeB, err := fileSHA256(b)
+	if err != nil {
+		return false, err
+	}
+	return sizeA == sizeB && sumA == sumB, nil
+}
+
+// fileSHA256 返回文件内容的 sha256 与大小.
+func fileSHA256(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
 
-	// 生成对象键（简化版：日期/哈希/扩展名）
-	datePrefix := time.Now().Format("2006/01/02")
-	key := fmt.Sprintf("%s/%s%s", datePrefix, sumHex[:16], ext)
+	hasher := sha256.New()
+	n, err := io.Copy(hasher, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), n, nil
+}
+
+// commit 将已写完的临时文件移动到对象键对应的位置，并构造 UploadedObject.
+// 写入前先扫描内容（命中时移入隔离目录），图片在写入前去除元数据，写入后生成衍生图. 单文件上传与分片合并共用该逻辑.
+func (l *localUploader) commit(ctx context.Context, tmpPath string, key string, mimeType string, sumHex string, n int64, metadata map[string]string) (*UploadedObject, error) {
 	absPath := filepath.Join(l.cfg.Local.BaseDir, key)
 
+	if err := l.scanner.check(ctx, tmpPath, &ScanInput{Filename: metadata["filename"], MIME: mimeType, Size: n}, sumHex); err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+
+	// Size 为实际存储的大小；Hash 仍为上传内容的哈希，用于去重
+	changed, err := l.images.sanitize(tmpPath, mimeType)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return nil, err
+	}
+	if changed {
+		fi, err := os.Stat(tmpPath)
+		if err != nil {
+			_ = os.Remove(tmpPath)
+			return nil, err
+		}
+		n = fi.Size()
+	}
+
 	// 确保目录存在并移动临时文件
 	if err := os.MkdirAll(filepath.Dir(absPath), os.FileMode(l.cfg.Local.MkdirPerm)); err != nil {
 		_ = os.Remove(tmpPath)
 		return nil, err
 	}
-	if err := os.Rename(tmpPath, absPath); err != nil {
-		_ = os.Remove(tmpPath)
+	if err := placeFile(tmpPath, absPath); err != nil {
 		return nil, err
 	}
 
-	// 不做任何图片转换或衍生文件生成
-	var publicKey = key
-	var publicSize = n
-	var publicMIME = mimeType
+	obj := &UploadedObject{
+		Provider: l.Provider(),
+		Key:      key,
+		URL:      l.publicURL(key),
+		Size:     n,
+		MIME:     mimeType,
+		Hash:     sumHex, // sha256
+		Metadata: metadata,
+	}
+	if obj.Metadata == nil {
+		obj.Metadata = map[string]string{}
+	}
+
+	// 衍生图生成失败不影响原图上传
+	derived, imageMetadata := l.images.derive(ctx, absPath, key, mimeType)
+	written := make([]*derivedImage, 0, len(derived))
+	for _, d := range derived {
+		if err := writeFileAtomic(filepath.Join(l.cfg.Local.BaseDir, d.key), d.data); err != nil {
+			log.W(ctx).Warnw("Failed to write image variant", "key", d.key, "err", err)
+			continue
+		}
+		written = append(written, d)
+	}
+	l.images.variantMetadata(imageMetadata, written, obj.URL, l.publicURL)
+	maps.Copy(obj.Metadata, imageMetadata)
+
+	return obj, nil
+}
 
-	// 构造 URL：
-	// - 对外访问优先使用 Local.BaseURL（包含协议/域名/路径），满足不同环境公网前缀不同的需求；
-	// - 若未配置 BaseURL，则回退为 Local.BasePath（仅路径），由前端拼接域名或同源访问。
+// escapeGlob 转义 filepath.Glob 的元字符.
+func escapeGlob(s string) string {
+	r := strings.NewReplacer("*", "\\*", "?", "\\?", "[", "\\[", "\\", "\\\\")
+	return r.Replace(s)
+}
+
+// objectMetadata 构造对象的附加元数据：scene 与客户端提供的原始文件名.
+func objectMetadata(scene string, filename string) map[string]string {
+	md := map[string]string{}
+	if scene != "" {
+		md["scene"] = scene
+	}
+	if filename != "" {
+		md["filename"] = filepath.Base(filename)
+	}
+	return md
+}
+
+// preferredExts 为常见类型的首选扩展名，mime.ExtensionsByType 按字母序返回（如 .jfif）不够直观.
+var preferredExts = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"application/pdf": ".pdf",
+	"text/plain":      ".txt",
+	"video/mp4":       ".mp4",
+}
+
+// extensionByType 返回 MIME 对应的扩展名，未知类型返回空.
+func extensionByType(mimeType string) string {
+	mimeType = baseMIME(mimeType)
+	if ext, ok := preferredExts[mimeType]; ok {
+		return ext
+	}
+	if exts, _ := mime.ExtensionsByType(mimeType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// publicURL 构造对象的访问 URL：
+// - 对外访问优先使用 Local.BaseURL（包含协议/域名/路径），满足不同环境公网前缀不同的需求；
+// - 若未配置 BaseURL，则回退为 Local.BasePath（仅路径），由前端拼接域名或同源访问。
+func (l *localUploader) publicURL(publicKey string) string {
 	var publicURL string
 	if l.cfg != nil && l.cfg.Local != nil && strings.TrimSpace(l.cfg.Local.BaseURL) != "" {
 		if uu, err := url.Parse(l.cfg.Local.BaseURL); err == nil {
@@ -131,14 +432,5 @@ func (l *localUploader) Upload(ctx context.Context, filename string, mimeType st
 		u.Path = strings.TrimRight(u.Path, "/") + "/" + publicKey
 		publicURL = u.String()
 	}
-
-	return &UploadedObject{
-		Provider: "local",
-		Key:      publicKey,
-		URL:      publicURL,
-		Size:     publicSize,
-		MIME:     publicMIME,
-		Hash:     sumHex, // sha256
-		Metadata: map[string]string{},
-	}, nil
+	return publicURL
 }
@@ -0,0 +1,54 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package validation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	genericvalidation "github.com/clin211/miniblog-v2/pkg/validation"
+)
+
+// ValidateMediaRules 定义媒体库相关的校验规则.
+func (v *Validator) ValidateMediaRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"FileID": func(value any) error {
+			if strings.TrimSpace(value.(string)) == "" {
+				return errno.ErrInvalidArgument.WithMessage("fileID cannot be empty")
+			}
+			return nil
+		},
+		"Limit": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("limit cannot be negative")
+			}
+			return nil
+		},
+		"Offset": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("offset cannot be negative")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateListMediaRequest 校验 ListMediaRequest 结构体的有效性.
+func (v *Validator) ValidateListMediaRequest(ctx context.Context, rq *v1.ListMediaRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMediaRules())
+}
+
+// ValidateGetMediaRequest 校验 GetMediaRequest 结构体的有效性.
+func (v *Validator) ValidateGetMediaRequest(ctx context.Context, rq *v1.GetMediaRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMediaRules())
+}
+
+// ValidateDeleteMediaRequest 校验 DeleteMediaRequest 结构体的有效性.
+func (v *Validator) ValidateDeleteMediaRequest(ctx context.Context, rq *v1.DeleteMediaRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateMediaRules())
+}
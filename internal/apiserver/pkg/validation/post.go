@@ -233,8 +233,26 @@ func (v *Validator) ValidatePostRules() genericvalidation.Rules {
 	// 分类ID校验函数
 	validateCategoryID := func() genericvalidation.ValidatorFunc {
 		return func(value any) error {
-			if value.(string) == "" {
-				return errno.ErrInvalidArgument.WithMessage("category ID cannot be empty")
+			// 创建、更新文章时为 int32 或 *int32（0 表示未分类），查询文章列表时为 *string
+			switch v := value.(type) {
+			case int32:
+				if v < 0 {
+					return errno.ErrInvalidArgument.WithMessage("category ID cannot be negative")
+				}
+			case *int32:
+				if v != nil && *v < 0 {
+					return errno.ErrInvalidArgument.WithMessage("category ID cannot be negative")
+				}
+			case string:
+				if v == "" {
+					return errno.ErrInvalidArgument.WithMessage("category ID cannot be empty")
+				}
+			case *string:
+				if v != nil && *v == "" {
+					return errno.ErrInvalidArgument.WithMessage("category ID cannot be empty")
+				}
+			default:
+				return errno.ErrInvalidArgument.WithMessage("category ID field type error")
 			}
 
 			return nil
@@ -437,3 +455,16 @@ func (v *Validator) ValidateBatchGetPostsRequest(ctx context.Context, rq *v1.Bat
 	}
 	return nil
 }
+
+// ValidateWatchPostsRequest 校验 WatchPostsRequest 结构体的有效性
+func (v *Validator) ValidateWatchPostsRequest(ctx context.Context, rq *v1.WatchPostsRequest) error {
+	if rq.CategoryID != nil && rq.GetCategoryID() <= 0 {
+		return errno.ErrInvalidArgument.WithMessage("categoryID must be a positive integer")
+	}
+	for _, eventType := range rq.GetTypes() {
+		if _, ok := v1.PostEventType_name[int32(eventType)]; !ok || eventType == v1.PostEventType_POST_EVENT_TYPE_UNSPECIFIED {
+			return errno.ErrInvalidArgument.WithMessage("invalid post event type: %d", eventType)
+		}
+	}
+	return nil
+}
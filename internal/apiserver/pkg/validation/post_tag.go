@@ -0,0 +1,62 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package validation
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// maxBatchPostTags 为一次批量操作允许的最大标签数.
+const maxBatchPostTags = 100
+
+// ValidateCreatePostTagRequest 校验 CreatePostTagRequest 结构体的有效性.
+func (v *Validator) ValidateCreatePostTagRequest(ctx context.Context, rq *v1.CreatePostTagRequest) error {
+	return validatePostTag(rq.GetPostID(), []int32{rq.GetTagID()})
+}
+
+// ValidateDeletePostTagRequest 校验 DeletePostTagRequest 结构体的有效性.
+func (v *Validator) ValidateDeletePostTagRequest(ctx context.Context, rq *v1.DeletePostTagRequest) error {
+	return validatePostTag(rq.GetPostID(), []int32{rq.GetTagID()})
+}
+
+// ValidateListPostTagsRequest 校验 ListPostTagsRequest 结构体的有效性.
+func (v *Validator) ValidateListPostTagsRequest(ctx context.Context, rq *v1.ListPostTagsRequest) error {
+	if rq.PostID == nil && rq.TagID == nil {
+		return errno.ErrInvalidArgument.WithMessage("postID or tagID is required")
+	}
+	return nil
+}
+
+// ValidateBatchCreatePostTagsRequest 校验 BatchCreatePostTagsRequest 结构体的有效性.
+func (v *Validator) ValidateBatchCreatePostTagsRequest(ctx context.Context, rq *v1.BatchCreatePostTagsRequest) error {
+	return validatePostTag(rq.GetPostID(), rq.GetTagIDs())
+}
+
+// ValidateBatchDeletePostTagsRequest 校验 BatchDeletePostTagsRequest 结构体的有效性.
+func (v *Validator) ValidateBatchDeletePostTagsRequest(ctx context.Context, rq *v1.BatchDeletePostTagsRequest) error {
+	return validatePostTag(rq.GetPostID(), rq.GetTagIDs())
+}
+
+func validatePostTag(postID string, tagIDs []int32) error {
+	if postID == "" {
+		return errno.ErrInvalidArgument.WithMessage("postID cannot be empty")
+	}
+	if len(tagIDs) == 0 {
+		return errno.ErrInvalidArgument.WithMessage("tagIDs cannot be empty")
+	}
+	if len(tagIDs) > maxBatchPostTags {
+		return errno.ErrInvalidArgument.WithMessage("tagIDs cannot exceed %d items", maxBatchPostTags)
+	}
+	for _, tagID := range tagIDs {
+		if tagID <= 0 {
+			return errno.ErrInvalidArgument.WithMessage("tagID must be a positive integer")
+		}
+	}
+	return nil
+}
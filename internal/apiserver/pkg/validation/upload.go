@@ -0,0 +1,114 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package validation
+
+import (
+	"context"
+	"strings"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	genericvalidation "github.com/clin211/miniblog-v2/pkg/validation"
+)
+
+// ValidateUploadRules 定义上传相关的校验规则.
+func (v *Validator) ValidateUploadRules() genericvalidation.Rules {
+	return genericvalidation.Rules{
+		"UploadID": func(value any) error {
+			if strings.TrimSpace(value.(string)) == "" {
+				return errno.ErrInvalidArgument.WithMessage("uploadID cannot be empty")
+			}
+			return nil
+		},
+		"Filename": func(value any) error {
+			filename := strings.TrimSpace(value.(string))
+			if filename == "" {
+				return errno.ErrInvalidArgument.WithMessage("filename cannot be empty")
+			}
+			if len(filename) > 255 {
+				return errno.ErrInvalidArgument.WithMessage("filename cannot exceed 255 characters")
+			}
+			return nil
+		},
+		"Size": func(value any) error {
+			if value.(int64) <= 0 {
+				return errno.ErrInvalidArgument.WithMessage("size must be greater than 0")
+			}
+			return nil
+		},
+		"PartSize": func(value any) error {
+			if value.(int64) < 0 {
+				return errno.ErrInvalidArgument.WithMessage("partSize cannot be negative")
+			}
+			return nil
+		},
+		"PartNumber": func(value any) error {
+			if value.(int32) < 1 {
+				return errno.ErrInvalidArgument.WithMessage("partNumber must be greater than 0")
+			}
+			return nil
+		},
+	}
+}
+
+// ValidateUploadFileRequest 校验 UploadFileRequest 结构体的有效性.
+// 单文件上传的 filename 与 size 均为可选，因此不复用 ValidateUploadRules.
+func (v *Validator) ValidateUploadFileRequest(ctx context.Context, rq *v1.UploadFileRequest) error {
+	if len(rq.GetFilename()) > 255 {
+		return errno.ErrInvalidArgument.WithMessage("filename cannot exceed 255 characters")
+	}
+	if rq.GetSize() < 0 {
+		return errno.ErrInvalidArgument.WithMessage("size cannot be negative")
+	}
+	return nil
+}
+
+// ValidateInitMultipartRequest 校验 InitMultipartRequest 结构体的有效性.
+func (v *Validator) ValidateInitMultipartRequest(ctx context.Context, rq *v1.InitMultipartRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules())
+}
+
+// ValidatePresignPartsRequest 校验 PresignPartsRequest 结构体的有效性.
+func (v *Validator) ValidatePresignPartsRequest(ctx context.Context, rq *v1.PresignPartsRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules()); err != nil {
+		return err
+	}
+	if len(rq.GetPartNumbers()) == 0 {
+		return errno.ErrInvalidArgument.WithMessage("partNumbers cannot be empty")
+	}
+	if len(rq.GetPartNumbers()) > 1000 {
+		return errno.ErrInvalidArgument.WithMessage("at most 1000 parts can be presigned at once")
+	}
+	return nil
+}
+
+// ValidateUploadPartRequest 校验 UploadPartRequest 结构体的有效性.
+func (v *Validator) ValidateUploadPartRequest(ctx context.Context, rq *v1.UploadPartRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules())
+}
+
+// ValidateListPartsRequest 校验 ListPartsRequest 结构体的有效性.
+func (v *Validator) ValidateListPartsRequest(ctx context.Context, rq *v1.ListPartsRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules())
+}
+
+// ValidateCompleteMultipartRequest 校验 CompleteMultipartRequest 结构体的有效性.
+func (v *Validator) ValidateCompleteMultipartRequest(ctx context.Context, rq *v1.CompleteMultipartRequest) error {
+	if err := genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules()); err != nil {
+		return err
+	}
+	for _, part := range rq.GetParts() {
+		if part.GetPartNumber() < 1 {
+			return errno.ErrInvalidArgument.WithMessage("partNumber must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// ValidateAbortMultipartRequest 校验 AbortMultipartRequest 结构体的有效性.
+func (v *Validator) ValidateAbortMultipartRequest(ctx context.Context, rq *v1.AbortMultipartRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUploadRules())
+}
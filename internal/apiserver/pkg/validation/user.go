@@ -12,6 +12,7 @@ import (
 
 	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
 	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
 	genericvalidation "github.com/clin211/miniblog-v2/pkg/validation"
 
 	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
@@ -181,6 +182,19 @@ func (v *Validator) ValidateLoginRequest(ctx context.Context, rq *v1.LoginReques
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
+// ValidateRefreshTokenRequest 校验 RefreshTokenRequest 结构体的有效性.
+func (v *Validator) ValidateRefreshTokenRequest(ctx context.Context, rq *v1.RefreshTokenRequest) error {
+	if rq.GetRefreshToken() == "" {
+		return errno.ErrInvalidArgument.WithMessage("refreshToken cannot be empty")
+	}
+	return nil
+}
+
+// ValidateLogoutRequest 校验 LogoutRequest 结构体的有效性.
+func (v *Validator) ValidateLogoutRequest(ctx context.Context, rq *v1.LogoutRequest) error {
+	return nil
+}
+
 // ValidateChangePasswordRequest 校验 ChangePasswordRequest 结构体的有效性.
 func (v *Validator) ValidateChangePasswordRequest(ctx context.Context, rq *v1.ChangePasswordRequest) error {
 	if rq.GetUserID() != contextx.UserID(ctx) {
@@ -207,6 +221,47 @@ func (v *Validator) ValidateDeleteUserRequest(ctx context.Context, rq *v1.Delete
 	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
 }
 
+// ValidateRevokeUserTokensRequest 校验 RevokeUserTokensRequest 结构体的有效性.
+func (v *Validator) ValidateRevokeUserTokensRequest(ctx context.Context, rq *v1.RevokeUserTokensRequest) error {
+	// 只有管理员可以吊销其他用户的令牌
+	if contextx.Username(ctx) != known.AdminUsername {
+		return errno.ErrPermissionDenied.WithMessage("Only the administrator can revoke user tokens")
+	}
+	if rq.GetUserID() == "" {
+		return errno.ErrInvalidArgument.WithMessage("userID cannot be empty")
+	}
+	return nil
+}
+
+// ValidateSendVerificationEmailRequest 校验 SendVerificationEmailRequest 结构体的有效性.
+func (v *Validator) ValidateSendVerificationEmailRequest(ctx context.Context, rq *v1.SendVerificationEmailRequest) error {
+	if rq.GetUserID() != contextx.UserID(ctx) {
+		return errno.ErrPermissionDenied.WithMessage("The logged-in user `%s` does not match request user `%s`", contextx.UserID(ctx), rq.GetUserID())
+	}
+	return nil
+}
+
+// ValidateConfirmEmailVerificationRequest 校验 ConfirmEmailVerificationRequest 结构体的有效性.
+func (v *Validator) ValidateConfirmEmailVerificationRequest(ctx context.Context, rq *v1.ConfirmEmailVerificationRequest) error {
+	if rq.GetToken() == "" {
+		return errno.ErrInvalidArgument.WithMessage("token cannot be empty")
+	}
+	return nil
+}
+
+// ValidateForgotPasswordRequest 校验 ForgotPasswordRequest 结构体的有效性.
+func (v *Validator) ValidateForgotPasswordRequest(ctx context.Context, rq *v1.ForgotPasswordRequest) error {
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
+// ValidateResetPasswordRequest 校验 ResetPasswordRequest 结构体的有效性.
+func (v *Validator) ValidateResetPasswordRequest(ctx context.Context, rq *v1.ResetPasswordRequest) error {
+	if rq.GetToken() == "" {
+		return errno.ErrInvalidArgument.WithMessage("token cannot be empty")
+	}
+	return genericvalidation.ValidateAllFields(rq, v.ValidateUserRules())
+}
+
 // ValidateGetUserRequest 校验 GetUserRequest 结构体的有效性.
 func (v *Validator) ValidateGetUserRequest(ctx context.Context, rq *v1.GetUserRequest) error {
 	if rq.GetUserID() != contextx.UserID(ctx) {
@@ -0,0 +1,298 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package verification 通过邮件向用户发送一次性的验证令牌，并在用户提交令牌时完成校验，
+// 用于验证邮箱与找回密码.
+//
+// 令牌是不透明的随机字符串，Redis 中只保存其 SHA-256 摘要以及令牌所属的用户与邮箱。
+// 令牌使用一次后立即删除；同一用户重新发送时，此前发送的令牌随即失效.
+package verification
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	"github.com/clin211/miniblog-v2/pkg/mailer"
+	"github.com/clin211/miniblog-v2/pkg/token"
+)
+
+// Purpose 表示令牌的用途，不同用途的令牌互不通用.
+type Purpose string
+
+const (
+	// PurposeVerifyEmail 用于验证邮箱.
+	PurposeVerifyEmail Purpose = "verify-email"
+	// PurposeResetPassword 用于找回密码.
+	PurposeResetPassword Purpose = "reset-password"
+)
+
+const (
+	// keyPrefix 为验证令牌相关键的统一前缀.
+	keyPrefix = "miniblog:verification:"
+
+	// DefaultEmailExpiration 为未配置时邮箱验证令牌的有效期.
+	DefaultEmailExpiration = 24 * time.Hour
+
+	// DefaultPasswordResetExpiration 为未配置时重置密码令牌的有效期.
+	DefaultPasswordResetExpiration = 30 * time.Minute
+
+	// DefaultCooldown 为同一用户两次发送验证邮件之间的默认间隔.
+	DefaultCooldown = time.Minute
+)
+
+//go:embed templates
+var templates embed.FS
+
+// 每种用途对应一组纯文本与 HTML 模板，纯文本模板中的 subject 模板为邮件主题.
+var (
+	textTemplates = map[Purpose]*texttemplate.Template{}
+	htmlTemplates = map[Purpose]*htmltemplate.Template{}
+)
+
+func init() {
+	for _, purpose := range []Purpose{PurposeVerifyEmail, PurposeResetPassword} {
+		textTemplates[purpose] = texttemplate.Must(texttemplate.ParseFS(templates, "templates/"+string(purpose)+".txt.tmpl"))
+		htmlTemplates[purpose] = htmltemplate.Must(htmltemplate.ParseFS(templates, "templates/"+string(purpose)+".html.tmpl"))
+	}
+}
+
+// Options 为验证令牌的配置.
+type Options struct {
+	// PublicURL 为前端页面的访问地址，邮件中的链接为 <PublicURL>/<purpose>?token=<token>
+	PublicURL string
+	// EmailExpiration 为邮箱验证令牌的有效期
+	EmailExpiration time.Duration
+	// PasswordResetExpiration 为重置密码令牌的有效期
+	PasswordResetExpiration time.Duration
+	// Cooldown 为同一用户两次发送之间的最小间隔，0 表示不限制
+	Cooldown time.Duration
+	// RequireVerifiedEmail 为 true 时，作者验证邮箱后才能发布文章
+	RequireVerifiedEmail bool
+}
+
+// Recipient 为接收验证邮件的用户.
+type Recipient struct {
+	UserID   string
+	Username string
+	Email    string
+}
+
+// Ticket 为令牌中保存的信息.
+type Ticket struct {
+	UserID string
+	// Email 为发送令牌时的邮箱，用户此后修改了邮箱时令牌不应再生效
+	Email string
+}
+
+// Verifier 负责验证令牌的签发、发送与校验.
+type Verifier struct {
+	rdb    *redis.Client
+	mailer mailer.Mailer
+	opts   Options
+}
+
+// templateData 为渲染邮件模板的数据.
+type templateData struct {
+	Username  string
+	Email     string
+	Token     string
+	Link      string
+	ExpiresIn string
+}
+
+// New 创建一个 Verifier. rdb 或 m 为 nil 时无法发送验证邮件.
+func New(rdb *redis.Client, m mailer.Mailer, opts Options) *Verifier {
+	if opts.EmailExpiration <= 0 {
+		opts.EmailExpiration = DefaultEmailExpiration
+	}
+	if opts.PasswordResetExpiration <= 0 {
+		opts.PasswordResetExpiration = DefaultPasswordResetExpiration
+	}
+	opts.PublicURL = strings.TrimRight(opts.PublicURL, "/")
+	return &Verifier{rdb: rdb, mailer: m, opts: opts}
+}
+
+// Available 返回是否配置了 Redis 与发件服务.
+func (v *Verifier) Available() bool {
+	return v != nil && v.rdb != nil && v.mailer != nil
+}
+
+// RequireVerifiedEmail 返回发布文章前是否要求作者已验证邮箱.
+func (v *Verifier) RequireVerifiedEmail() bool {
+	return v != nil && v.opts.RequireVerifiedEmail
+}
+
+// Send 签发一个新的令牌并通过邮件发送给用户，此前发送给该用户的同类令牌随即失效.
+// 冷却期内重复发送返回 errno.ErrVerificationThrottled.
+func (v *Verifier) Send(ctx context.Context, purpose Purpose, to Recipient) error {
+	if !v.Available() {
+		return errno.ErrMailerUnavailable
+	}
+
+	if v.opts.Cooldown > 0 {
+		ok, err := v.rdb.SetNX(ctx, cooldownKey(purpose, to.UserID), 1, v.opts.Cooldown).Result()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			ttl, _ := v.rdb.TTL(ctx, cooldownKey(purpose, to.UserID)).Result()
+			return throttled(ttl)
+		}
+	}
+
+	token, err := token.NewOpaque()
+	if err != nil {
+		return err
+	}
+	key, expiration := ticketKey(purpose, token), v.expiration(purpose)
+	_, err = v.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "user_id", to.UserID, "email", to.Email)
+		pipe.Expire(ctx, key, expiration)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 每个用户只保留最新的令牌
+	previous, err := v.rdb.SetArgs(ctx, userKey(purpose, to.UserID), key, redis.SetArgs{Get: true, TTL: expiration}).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return err
+	}
+	if previous != "" && previous != key {
+		if err := v.rdb.Del(ctx, previous).Err(); err != nil {
+			return err
+		}
+	}
+
+	msg, err := v.render(purpose, to, token, expiration)
+	if err == nil {
+		err = v.mailer.Send(ctx, msg)
+	}
+	if err != nil {
+		// 发送失败时撤销令牌与冷却期，允许用户立即重试
+		if err := v.rdb.Del(ctx, key, cooldownKey(purpose, to.UserID)).Err(); err != nil {
+			log.W(ctx).Errorw("Failed to clean up verification token", "err", err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Consume 校验并删除令牌，令牌只能使用一次. 令牌不存在、已过期或已被使用时
+// 返回 errno.ErrVerificationTokenInvalid.
+func (v *Verifier) Consume(ctx context.Context, purpose Purpose, token string) (*Ticket, error) {
+	if v == nil || v.rdb == nil || token == "" {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+
+	key := ticketKey(purpose, token)
+	var fields *redis.MapStringStringCmd
+	_, err := v.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		fields = pipe.HGetAll(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := &Ticket{UserID: fields.Val()["user_id"], Email: fields.Val()["email"]}
+	if ticket.UserID == "" {
+		return nil, errno.ErrVerificationTokenInvalid
+	}
+	return ticket, nil
+}
+
+// expiration 返回指定用途的令牌有效期.
+func (v *Verifier) expiration(purpose Purpose) time.Duration {
+	if purpose == PurposeResetPassword {
+		return v.opts.PasswordResetExpiration
+	}
+	return v.opts.EmailExpiration
+}
+
+// render 使用模板生成验证邮件.
+func (v *Verifier) render(purpose Purpose, to Recipient, token string, expiration time.Duration) (*mailer.Message, error) {
+	textTmpl, htmlTmpl := textTemplates[purpose], htmlTemplates[purpose]
+	if textTmpl == nil || htmlTmpl == nil {
+		return nil, fmt.Errorf("no email template for %q", purpose)
+	}
+
+	data := templateData{
+		Username:  to.Username,
+		Email:     to.Email,
+		Token:     token,
+		Link:      v.opts.PublicURL + "/" + string(purpose) + "?token=" + url.QueryEscape(token),
+		ExpiresIn: humanize(expiration),
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := textTmpl.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, err
+	}
+	if err := textTmpl.Execute(&text, data); err != nil {
+		return nil, err
+	}
+	if err := htmlTmpl.Execute(&html, data); err != nil {
+		return nil, err
+	}
+
+	return &mailer.Message{
+		To:      []string{to.Email},
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// throttled 返回带等待时间的发送过于频繁错误.
+func throttled(retryAfter time.Duration) error {
+	return errno.ErrVerificationThrottled.Copy().KV("retryAfter", strconv.Itoa(int(retryAfter.Round(time.Second).Seconds())))
+}
+
+// humanize 将有效期格式化为邮件中展示的文字，例如 24 hours、30 minutes.
+func humanize(d time.Duration) string {
+	unit, n := "minute", int(d.Round(time.Minute)/time.Minute)
+	if d >= time.Hour && d%time.Hour == 0 {
+		unit, n = "hour", int(d/time.Hour)
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return strconv.Itoa(n) + " " + unit
+}
+
+// ticketKey 返回令牌的键，键中只包含令牌的摘要.
+func ticketKey(purpose Purpose, token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return keyPrefix + string(purpose) + ":token:" + hex.EncodeToString(sum[:])
+}
+
+// userKey 返回用户当前有效令牌的键，值为令牌的键.
+func userKey(purpose Purpose, userID string) string {
+	return keyPrefix + string(purpose) + ":user:" + userID
+}
+
+// cooldownKey 返回发送冷却期的键.
+func cooldownKey(purpose Purpose, userID string) string {
+	return keyPrefix + string(purpose) + ":cooldown:" + userID
+}
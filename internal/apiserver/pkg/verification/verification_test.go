@@ -0,0 +1,174 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package verification
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/pkg/mailer"
+)
+
+var alice = Recipient{UserID: "user-1", Username: "alice", Email: "alice@example.com"}
+
+func newTestVerifier(t *testing.T, m mailer.Mailer, opts Options) (*Verifier, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	rdb := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = rdb.Close() })
+	return New(rdb, m, opts), mr
+}
+
+// tokenOf 从邮件的链接中取出令牌.
+func tokenOf(t *testing.T, msg *mailer.Message) string {
+	require.NotNil(t, msg)
+	for _, field := range strings.Fields(msg.Text) {
+		if u, err := url.Parse(field); err == nil && u.Query().Get("token") != "" {
+			return u.Query().Get("token")
+		}
+	}
+	t.Fatal("no token found in message")
+	return ""
+}
+
+func TestSendAndConsume(t *testing.T) {
+	ctx := context.Background()
+	m := mailer.NewMemory("noreply@example.com")
+	v, mr := newTestVerifier(t, m, Options{PublicURL: "https://blog.example.com/"})
+
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	msg := m.Last()
+	assert.Equal(t, []string{"alice@example.com"}, msg.To)
+	assert.Equal(t, "Verify your email address", msg.Subject)
+	assert.Contains(t, msg.Text, "https://blog.example.com/verify-email?token=")
+	assert.Contains(t, msg.Text, "24 hours")
+	assert.Contains(t, msg.HTML, "<strong>alice@example.com</strong>")
+
+	// Redis 中不保存明文令牌
+	token := tokenOf(t, msg)
+	for _, key := range mr.Keys() {
+		assert.NotContains(t, key, token)
+	}
+
+	// 不同用途的令牌互不通用
+	_, err := v.Consume(ctx, Purpose("other"), token)
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+
+	ticket, err := v.Consume(ctx, PurposeVerifyEmail, token)
+	require.NoError(t, err)
+	assert.Equal(t, &Ticket{UserID: "user-1", Email: "alice@example.com"}, ticket)
+
+	// 令牌只能使用一次
+	_, err = v.Consume(ctx, PurposeVerifyEmail, token)
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+}
+
+func TestResendInvalidatesPrevious(t *testing.T) {
+	ctx := context.Background()
+	m := mailer.NewMemory("noreply@example.com")
+	v, mr := newTestVerifier(t, m, Options{EmailExpiration: time.Hour})
+
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	first := tokenOf(t, m.Last())
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	second := tokenOf(t, m.Last())
+
+	_, err := v.Consume(ctx, PurposeVerifyEmail, first)
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+
+	// 令牌过期后失效
+	mr.FastForward(time.Hour)
+	_, err = v.Consume(ctx, PurposeVerifyEmail, second)
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+}
+
+func TestResetPassword(t *testing.T) {
+	ctx := context.Background()
+	m := mailer.NewMemory("noreply@example.com")
+	v, mr := newTestVerifier(t, m, Options{PublicURL: "https://blog.example.com"})
+
+	// 两种用途的令牌与冷却期互不影响
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	verifyToken := tokenOf(t, m.Last())
+	require.NoError(t, v.Send(ctx, PurposeResetPassword, alice))
+	msg := m.Last()
+	assert.Equal(t, "Reset your password", msg.Subject)
+	assert.Contains(t, msg.Text, "https://blog.example.com/reset-password?token=")
+	assert.Contains(t, msg.Text, "30 minutes")
+
+	_, err := v.Consume(ctx, PurposeVerifyEmail, verifyToken)
+	require.NoError(t, err)
+
+	// 重置密码令牌的有效期更短
+	mr.FastForward(30 * time.Minute)
+	_, err = v.Consume(ctx, PurposeResetPassword, tokenOf(t, msg))
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+}
+
+func TestCooldown(t *testing.T) {
+	ctx := context.Background()
+	m := mailer.NewMemory("noreply@example.com")
+	v, mr := newTestVerifier(t, m, Options{Cooldown: time.Minute})
+
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	err := v.Send(ctx, PurposeVerifyEmail, alice)
+	assert.True(t, errno.ErrVerificationThrottled.Is(err))
+	assert.Equal(t, "60", errno.FromError(err).Metadata["retryAfter"])
+	assert.Len(t, m.Messages(), 1)
+
+	// 冷却期只针对同一用户
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, Recipient{UserID: "user-2", Email: "bob@example.com"}))
+
+	mr.FastForward(time.Minute)
+	require.NoError(t, v.Send(ctx, PurposeVerifyEmail, alice))
+}
+
+// failingMailer 总是发送失败.
+type failingMailer struct{}
+
+func (failingMailer) Send(context.Context, *mailer.Message) error {
+	return errors.New("connection refused")
+}
+
+func TestSendFailure(t *testing.T) {
+	ctx := context.Background()
+	v, mr := newTestVerifier(t, failingMailer{}, Options{Cooldown: time.Minute})
+
+	assert.Error(t, v.Send(ctx, PurposeVerifyEmail, alice))
+	// 发送失败时不保留令牌与冷却期
+	assert.False(t, mr.Exists(cooldownKey(PurposeVerifyEmail, "user-1")))
+	for _, key := range mr.Keys() {
+		assert.NotContains(t, key, ":token:")
+	}
+}
+
+func TestUnavailable(t *testing.T) {
+	var v *Verifier
+	assert.Equal(t, errno.ErrMailerUnavailable, v.Send(context.Background(), PurposeVerifyEmail, alice))
+	assert.False(t, v.RequireVerifiedEmail())
+
+	v = New(nil, mailer.NewMemory(""), Options{RequireVerifiedEmail: true})
+	assert.Equal(t, errno.ErrMailerUnavailable, v.Send(context.Background(), PurposeVerifyEmail, alice))
+	assert.True(t, v.RequireVerifiedEmail())
+	_, err := v.Consume(context.Background(), PurposeVerifyEmail, "token")
+	assert.Equal(t, errno.ErrVerificationTokenInvalid, err)
+}
+
+func TestHumanize(t *testing.T) {
+	assert.Equal(t, "24 hours", humanize(24*time.Hour))
+	assert.Equal(t, "1 hour", humanize(time.Hour))
+	assert.Equal(t, "30 minutes", humanize(30*time.Minute))
+	assert.Equal(t, "90 minutes", humanize(90*time.Minute))
+}
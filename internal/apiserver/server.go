@@ -15,16 +15,27 @@ import (
 	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
 	"github.com/redis/go-redis/v9"
 	"go.mongodb.org/mongo-driver/mongo"
+	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
 
 	"github.com/clin211/miniblog-v2/internal/apiserver/biz"
 	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/pubsub"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/quota"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/session"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/tus"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/uploader"
 	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/validation"
+	"github.com/clin211/miniblog-v2/internal/apiserver/pkg/verification"
 	"github.com/clin211/miniblog-v2/internal/apiserver/store"
 	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
 	"github.com/clin211/miniblog-v2/internal/pkg/known"
 	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
 	"github.com/clin211/miniblog-v2/pkg/auth"
+	"github.com/clin211/miniblog-v2/pkg/clientip"
+	"github.com/clin211/miniblog-v2/pkg/geoip"
+	"github.com/clin211/miniblog-v2/pkg/mailer"
 	"github.com/clin211/miniblog-v2/pkg/server"
 	"github.com/clin211/miniblog-v2/pkg/token"
 	"github.com/clin211/miniblog-v2/pkg/where"
@@ -42,14 +53,32 @@ const (
 	// GinServerMode 定义 Gin 服务模式.
 	// 使用 Gin Web 框架启动一个 HTTP 服务器.
 	GinServerMode = "gin"
+	// CombinedServerMode 定义单端口服务模式.
+	// 在 HTTP 端口上同时提供 Gin REST、gRPC 与可选的 gRPC-Web 服务.
+	CombinedServerMode = "combined"
 )
 
+// postEventsChannel 为多副本之间转发文章变更事件的 Redis channel.
+const postEventsChannel = "miniblog:post-events"
+
 // Config 配置结构体，用于存储应用相关的配置.
 // 不用 viper.Get，是因为这种方式能更加清晰的知道应用提供了哪些配置项.
 type Config struct {
-	ServerMode    string
-	JWTKey        string
-	Expiration    time.Duration
+	ServerMode string
+	JWTKey     string
+	Expiration time.Duration
+	// RefreshExpiration 为刷新令牌的有效期
+	RefreshExpiration time.Duration
+	// PublicURL 为前端页面的访问地址，用于生成邮件中的链接
+	PublicURL string
+	// EmailVerificationExpiration 为邮箱验证链接的有效期
+	EmailVerificationExpiration time.Duration
+	// PasswordResetExpiration 为重置密码链接的有效期
+	PasswordResetExpiration time.Duration
+	// RequireVerifiedEmail 为 true 时，作者验证邮箱后才能发布文章
+	RequireVerifiedEmail bool
+	// AuthnBypass 为 true 时 gRPC 模式跳过 JWT 认证，仅供本地开发使用
+	AuthnBypass   bool
 	HTTPOptions   *genericoptions.HTTPOptions
 	GRPCOptions   *genericoptions.GRPCOptions
 	MySQLOptions  *genericoptions.MySQLOptions
@@ -57,6 +86,8 @@ type Config struct {
 	MongoOptions  *genericoptions.MongoOptions
 	RedisOptions  *genericoptions.RedisOptions
 	UploadOptions *genericoptions.UploadOptions
+	GeoIPOptions  *genericoptions.GeoIPOptions
+	MailerOptions *genericoptions.MailerOptions
 }
 
 // UnionServer 定义一个联合服务器. 根据 ServerMode 决定要启动的服务器类型.
@@ -67,6 +98,7 @@ type Config struct {
 //  2. GRPC 服务器：由 gRPC 框架创建的标准 RPC 服务器
 //  3. HTTP 反向代理服务器：由 grpc-gateway 框架创建的 HTTP 反向代理服务器。
 //     根据是否开启 TLS，来判断启动 HTTP 或者 HTTPS；
+//  4. 单端口服务器：在同一个端口上按协议分发 Gin REST、gRPC 与 gRPC-Web 请求.
 //
 // HTTP 反向代理服务器依赖 gRPC 服务器，所以在开启 HTTP 反向代理服务器时，会先启动 gRPC 服务器.
 type UnionServer struct {
@@ -80,6 +112,13 @@ type ServerConfig struct {
 	val       *validation.Validator
 	retriever mw.UserRetriever
 	authz     *auth.Authz
+	upl       uploader.Uploader
+	tus       *tus.Store
+	store     store.IStore
+	clientIP  *clientip.Extractor
+	geo       geoip.Resolver
+	sessions  *session.Manager
+	events    *pubsub.Broker[*v1.PostEvent]
 }
 
 // NewUnionServer 根据配置创建联合服务器.
@@ -155,12 +194,48 @@ func (cfg *Config) NewServerConfig() (*ServerConfig, error) {
 		return nil, err
 	}
 
+	upl, err := uploader.NewFromConfig(cfg.UploadOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	tusStore, err := cfg.NewTusStore()
+	if err != nil {
+		return nil, err
+	}
+
+	extractor, err := cfg.GeoIPOptions.NewClientIPExtractor()
+	if err != nil {
+		return nil, err
+	}
+
+	geo, err := cfg.GeoIPOptions.NewResolver()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := session.New(r, cfg.Expiration, cfg.RefreshExpiration)
+
+	mail, err := cfg.MailerOptions.NewMailer()
+	if err != nil {
+		return nil, err
+	}
+
+	events := ProvidePostEvents(r)
+
 	return &ServerConfig{
 		cfg:       cfg,
-		biz:       biz.NewBiz(store, authz),
+		biz:       biz.NewBiz(store, authz, upl, quota.New(cfg.UploadOptions, store, authz), events, sessions, ProvideVerification(cfg, r, mail)),
 		val:       validation.New(store),
 		retriever: &UserRetriever{store: store},
 		authz:     authz,
+		upl:       upl,
+		tus:       tusStore,
+		store:     store,
+		clientIP:  extractor,
+		geo:       geo,
+		sessions:  sessions,
+		events:    events,
 	}, nil
 }
 
@@ -179,6 +254,14 @@ func (cfg *Config) NewRedisClient() (*redis.Client, error) {
 	return cfg.RedisOptions.NewClient()
 }
 
+// NewTusStore 创建可续传上传的状态存储，未启用时为 nil.
+func (cfg *Config) NewTusStore() (*tus.Store, error) {
+	if cfg.UploadOptions == nil {
+		return nil, nil
+	}
+	return tus.New(cfg.UploadOptions.Tus)
+}
+
 // UserRetriever 定义一个用户数据获取器. 用来获取用户信息.
 type UserRetriever struct {
 	store store.IStore
@@ -203,15 +286,139 @@ func ProvideRedis(cfg *Config) (*redis.Client, error) {
 	return cfg.NewRedisClient()
 }
 
+// ProvideUploader 根据上传配置提供对象存储实现.
+func ProvideUploader(cfg *Config) (uploader.Uploader, error) {
+	return uploader.NewFromConfig(cfg.UploadOptions)
+}
+
+// ProvideQuota 根据上传配置提供上传配额检查器，未启用时为 nil.
+func ProvideQuota(cfg *Config, store store.IStore, authz *auth.Authz) *quota.Limiter {
+	return quota.New(cfg.UploadOptions, store, authz)
+}
+
+// ProvideTus 根据上传配置提供可续传上传的状态存储，未启用时为 nil.
+func ProvideTus(cfg *Config) (*tus.Store, error) {
+	return cfg.NewTusStore()
+}
+
+// ProvideClientIPExtractor 根据受信任的代理配置提供客户端 IP 解析器.
+func ProvideClientIPExtractor(cfg *Config) (*clientip.Extractor, error) {
+	return cfg.GeoIPOptions.NewClientIPExtractor()
+}
+
+// ProvideGeoIP 根据配置提供客户端地理位置解析器，未启用时为 nil.
+func ProvideGeoIP(cfg *Config) (geoip.Resolver, error) {
+	return cfg.GeoIPOptions.NewResolver()
+}
+
+// ProvideSessions 提供基于 Redis 的刷新令牌管理与访问令牌吊销.
+func ProvideSessions(cfg *Config, rdb *redis.Client) *session.Manager {
+	return session.New(rdb, cfg.Expiration, cfg.RefreshExpiration)
+}
+
+// ProvideMailer 根据配置提供发件服务，未启用时为 nil.
+func ProvideMailer(cfg *Config) (mailer.Mailer, error) {
+	return cfg.MailerOptions.NewMailer()
+}
+
+// ProvideVerification 提供通过邮件发送的一次性验证令牌，用于验证邮箱与找回密码.
+func ProvideVerification(cfg *Config, rdb *redis.Client, m mailer.Mailer) *verification.Verifier {
+	return verification.New(rdb, m, verification.Options{
+		PublicURL:               cfg.PublicURL,
+		EmailExpiration:         cfg.EmailVerificationExpiration,
+		PasswordResetExpiration: cfg.PasswordResetExpiration,
+		Cooldown:                verification.DefaultCooldown,
+		RequireVerifiedEmail:    cfg.RequireVerifiedEmail,
+	})
+}
+
+// ProvidePostEvents 提供文章变更事件发布订阅. 配置 Redis 时事件经 Redis 在多个副本之间转发，
+// 否则只在进程内分发，WatchPosts 只能收到同一副本上发生的变更.
+func ProvidePostEvents(rdb *redis.Client) *pubsub.Broker[*v1.PostEvent] {
+	return pubsub.NewBroker[*v1.PostEvent](0).WithRedis(rdb, postEventsChannel, pubsub.Codec[*v1.PostEvent]{
+		Marshal: func(event *v1.PostEvent) ([]byte, error) {
+			return proto.Marshal(event)
+		},
+		Unmarshal: func(data []byte) (*v1.PostEvent, error) {
+			var event v1.PostEvent
+			return &event, proto.Unmarshal(data, &event)
+		},
+	})
+}
+
 func NewWebServer(serverMode string, serverConfig *ServerConfig) (server.Server, error) {
 	// 根据服务模式创建对应的服务实例
 	// 实际企业开发中，可以根据需要只选择一种服务器模式.
 	// 这里为了方便给你展示，通过 cfg.ServerMode 同时支持了 Gin 和 GRPC 2 种服务器模式.
 	// 默认为 gRPC 服务器模式.
+	var (
+		srv server.Server
+		err error
+	)
 	switch serverMode {
 	case GinServerMode:
-		return serverConfig.NewGinServer(), nil
+		srv = serverConfig.NewGinServer()
+	case CombinedServerMode:
+		srv = serverConfig.NewCombinedServer()
 	default:
-		return serverConfig.NewGRPCServerOr()
+		if srv, err = serverConfig.NewGRPCServerOr(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 后台任务随服务停止而停止
+	ctx, cancel := context.WithCancel(context.Background())
+	serverConfig.startMediaGC(ctx)
+	serverConfig.tus.StartJanitor(ctx)
+	serverConfig.events.Start(ctx)
+	if janitor, ok := serverConfig.upl.(uploader.Janitor); ok {
+		janitor.StartJanitor(ctx)
+	}
+	return &backgroundServer{Server: srv, stop: cancel}, nil
+}
+
+// backgroundServer 在服务优雅关闭时一并停止后台任务.
+type backgroundServer struct {
+	server.Server
+	stop context.CancelFunc
+}
+
+// GracefulStop 先停止后台任务，再关闭服务.
+func (s *backgroundServer) GracefulStop(ctx context.Context) {
+	s.stop()
+	s.Server.GracefulStop(ctx)
+}
+
+// startMediaGC 按配置周期性回收超过宽限期且未被引用的上传文件.
+// 回收操作是幂等的，多副本同时执行不会产生副作用. ctx 取消后停止回收.
+func (c *ServerConfig) startMediaGC(ctx context.Context) {
+	if c.cfg.UploadOptions == nil || c.cfg.UploadOptions.GC == nil || !c.cfg.UploadOptions.GC.Enabled {
+		return
+	}
+	gcOpts := c.cfg.UploadOptions.GC
+	interval, _ := genericoptions.ParseDuration(gcOpts.Interval)
+	gracePeriod, _ := genericoptions.ParseDuration(gcOpts.GracePeriod)
+	if interval <= 0 || gracePeriod <= 0 {
+		return
 	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			removed, err := c.biz.MediaV1().CollectOrphans(ctx, time.Now().Add(-gracePeriod), gcOpts.BatchSize)
+			if err != nil {
+				log.W(ctx).Errorw("Failed to collect orphan media files", "err", err)
+				continue
+			}
+			if removed > 0 {
+				log.W(ctx).Infow("Collected orphan media files", "removed", removed)
+			}
+		}
+	}()
 }
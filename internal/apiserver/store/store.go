@@ -46,6 +46,7 @@ type IStore interface {
 	Tag() TagStore
 	PostTag() PostTagStore
 	Category() CategoryStore
+	UploadedFile() UploadedFileStore
 	// ConcretePosts 是一个示例 store 实现，用来演示在 Go 中如何直接与 DB 交互.
 	ConcretePost() ConcretePostStore
 	// Device 返回一个实现了 DeviceStore 接口的实例，用于演示 MongoDB 的使用.
@@ -139,6 +140,11 @@ func (store *datastore) Category() CategoryStore {
 	return newCategoryStore(store)
 }
 
+// UploadedFile 返回一个实现了 UploadedFileStore 接口的实例.
+func (store *datastore) UploadedFile() UploadedFileStore {
+	return newUploadedFileStore(store)
+}
+
 // ConcretePosts 返回一个实现了 ConcretePostStore 接口的实例.
 func (store *datastore) ConcretePost() ConcretePostStore {
 	return newConcretePostStore(store)
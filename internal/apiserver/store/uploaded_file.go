@@ -0,0 +1,147 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package store
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/clin211/miniblog-v2/internal/apiserver/model"
+	genericstore "github.com/clin211/miniblog-v2/pkg/store"
+)
+
+// UploadedFileStore 定义了 uploaded_file 模块在 store 层所实现的方法.
+type UploadedFileStore interface {
+	genericstore.IStore[model.UploadedFileM]
+
+	UploadedFileExpansion
+}
+
+// UploadedFileExpansion 定义了上传文件操作的附加方法.
+type UploadedFileExpansion interface {
+	// FindBySHA256 返回指定存储后端中内容哈希为 sha256 的最早一条记录，userID 为空时不限上传者.
+	// 未找到时返回 nil, nil，便于去重逻辑区分“未命中”与查询失败.
+	FindBySHA256(ctx context.Context, provider string, sha256 string, userID string) (*model.UploadedFileM, error)
+	// References 返回内容中包含 substrs 任一子串的文章封面、文章内容与用户头像，用于追踪文件的引用方.
+	References(ctx context.Context, substrs ...string) ([]*ObjectReference, error)
+	// ListCreatedBefore 按 ID 升序返回 ID 大于 afterID 且创建时间早于 before 的记录，用于分批扫描孤儿文件.
+	ListCreatedBefore(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.UploadedFileM, error)
+	// SumSize 返回用户所有上传记录的字节数之和，用于统计存储配额用量.
+	SumSize(ctx context.Context, userID string) (int64, error)
+}
+
+// 文件引用位置.
+const (
+	ReferencePostCover   = "post.cover"
+	ReferencePostContent = "post.content"
+	ReferenceUserAvatar  = "user.avatar"
+)
+
+// ObjectReference 表示引用了上传文件的资源.
+type ObjectReference struct {
+	Kind       string
+	ResourceID string
+	Title      string
+}
+
+// uploadedFileStore 是 UploadedFileStore 接口的实现.
+type uploadedFileStore struct {
+	*genericstore.Store[model.UploadedFileM]
+	ds *datastore
+}
+
+// 确保 uploadedFileStore 实现了 UploadedFileStore 接口.
+var _ UploadedFileStore = (*uploadedFileStore)(nil)
+
+// newUploadedFileStore 创建 uploadedFileStore 的实例.
+func newUploadedFileStore(store *datastore) *uploadedFileStore {
+	return &uploadedFileStore{
+		Store: genericstore.NewStore[model.UploadedFileM](store, genericstore.NewLogger()),
+		ds:    store,
+	}
+}
+
+// FindBySHA256 实现 UploadedFileExpansion 接口中的 FindBySHA256 方法.
+func (s *uploadedFileStore) FindBySHA256(ctx context.Context, provider string, sha256 string, userID string) (*model.UploadedFileM, error) {
+	db := s.ds.DB(ctx).Where("provider = ? AND sha256 = ?", provider, sha256)
+	if userID != "" {
+		db = db.Where("user_id = ?", userID)
+	}
+
+	var files []*model.UploadedFileM
+	if err := db.Order("id asc").Limit(1).Find(&files).Error; err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	return files[0], nil
+}
+
+// References 实现 UploadedFileExpansion 接口中的 References 方法.
+// 对象键只包含 URL 安全字符，不会出现 %；_ 会按 LIKE 通配符匹配任意字符，只可能多报引用，不会误删文件.
+func (s *uploadedFileStore) References(ctx context.Context, substrs ...string) ([]*ObjectReference, error) {
+	if len(substrs) == 0 {
+		return nil, nil
+	}
+
+	var refs []*ObjectReference
+	for _, target := range []struct {
+		kind   string
+		model  any
+		column string
+		id     string
+		title  string
+	}{
+		{ReferencePostCover, &model.PostM{}, "cover", "post_id", "title"},
+		{ReferencePostContent, &model.PostM{}, "content", "post_id", "title"},
+		{ReferenceUserAvatar, &model.UserM{}, "avatar", "user_id", "username"},
+	} {
+		conds := make([]string, 0, len(substrs))
+		args := make([]any, 0, len(substrs))
+		for _, substr := range substrs {
+			conds = append(conds, target.column+" LIKE ?")
+			args = append(args, "%"+substr+"%")
+		}
+
+		var rows []struct {
+			ResourceID string
+			Title      string
+		}
+		err := s.ds.DB(ctx).Model(target.model).
+			Select(target.id+" AS resource_id", target.title+" AS title").
+			Where(strings.Join(conds, " OR "), args...).
+			Find(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			refs = append(refs, &ObjectReference{Kind: target.kind, ResourceID: row.ResourceID, Title: row.Title})
+		}
+	}
+	return refs, nil
+}
+
+// ListCreatedBefore 实现 UploadedFileExpansion 接口中的 ListCreatedBefore 方法.
+func (s *uploadedFileStore) ListCreatedBefore(ctx context.Context, before time.Time, afterID int64, limit int) ([]*model.UploadedFileM, error) {
+	var files []*model.UploadedFileM
+	err := s.ds.DB(ctx).
+		Where("id > ? AND created_at < ?", afterID, before).
+		Order("id asc").Limit(limit).
+		Find(&files).Error
+	return files, err
+}
+
+// SumSize 实现 UploadedFileExpansion 接口中的 SumSize 方法.
+func (s *uploadedFileStore) SumSize(ctx context.Context, userID string) (int64, error) {
+	var total int64
+	err := s.ds.DB(ctx).Model(&model.UploadedFileM{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(size), 0)").
+		Scan(&total).Error
+	return total, err
+}
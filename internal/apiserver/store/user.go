@@ -6,6 +6,10 @@
 package store
 
 import (
+	"context"
+
+	"gorm.io/gorm"
+
 	"github.com/clin211/miniblog-v2/internal/apiserver/model"
 	genericstore "github.com/clin211/miniblog-v2/pkg/store"
 )
@@ -13,11 +17,22 @@ import (
 // UserStore 定义了 user 模块在 store 层所实现的方法
 type UserStore interface {
 	genericstore.IStore[model.UserM]
+
+	UserExpansion
+}
+
+// UserExpansion 定义了用户操作的附加方法.
+type UserExpansion interface {
+	// UpdateColumns 只更新用户的指定列，避免整行写回覆盖并发请求对其他列的修改.
+	UpdateColumns(ctx context.Context, userID string, columns map[string]any) error
+	// IncrFailedLoginAttempts 原子地将用户的登录失败次数加一，返回增加后的次数.
+	IncrFailedLoginAttempts(ctx context.Context, userID string) (int32, error)
 }
 
 // userStore 是 UserStore 接口的实现
 type userStore struct {
 	*genericstore.Store[model.UserM]
+	ds *datastore
 }
 
 // 确保 userStore 实现了 UserStore 接口
@@ -27,5 +42,26 @@ var _ UserStore = (*userStore)(nil)
 func newUserStore(store *datastore) *userStore {
 	return &userStore{
 		Store: genericstore.NewStore[model.UserM](store, genericstore.NewLogger()),
+		ds:    store,
 	}
 }
+
+// UpdateColumns 实现 UserExpansion 接口中的 UpdateColumns 方法.
+func (s *userStore) UpdateColumns(ctx context.Context, userID string, columns map[string]any) error {
+	return s.ds.DB(ctx).Model(&model.UserM{}).Where("user_id = ?", userID).UpdateColumns(columns).Error
+}
+
+// IncrFailedLoginAttempts 实现 UserExpansion 接口中的 IncrFailedLoginAttempts 方法.
+// 加一与读取在同一事务中完成，更新持有的行锁保证读到的是本次加一后的值.
+func (s *userStore) IncrFailedLoginAttempts(ctx context.Context, userID string) (int32, error) {
+	var attempts int32
+	err := s.ds.TX(ctx, func(ctx context.Context) error {
+		db := s.ds.DB(ctx).Model(&model.UserM{}).Where("user_id = ?", userID)
+		if err := db.UpdateColumn("failed_login_attempts", gorm.Expr("COALESCE(failed_login_attempts, 0) + 1")).Error; err != nil {
+			return err
+		}
+		return s.ds.DB(ctx).Model(&model.UserM{}).Where("user_id = ?", userID).
+			Select("failed_login_attempts").Scan(&attempts).Error
+	})
+	return attempts, err
+}
@@ -27,6 +27,15 @@ func InitializeWebServer(*Config) (server.Server, error) {
 		ProvideDB, // 提供数据库实例
 		ProvideMongoDB,
 		ProvideRedis,
+		ProvideUploader,
+		ProvideQuota,
+		ProvideTus,
+		ProvidePostEvents,
+		ProvideSessions,
+		ProvideMailer,
+		ProvideVerification,
+		ProvideClientIPExtractor,
+		ProvideGeoIP,
 		validation.ProviderSet,
 		wire.NewSet(
 			wire.Struct(new(UserRetriever), "*"),
@@ -41,8 +41,32 @@ func InitializeWebServer(config *Config) (server.Server, error) {
 	if err != nil {
 		return nil, err
 	}
-	bizBiz := biz.NewBiz(datastore, authz)
+	uploaderUploader, err := ProvideUploader(config)
+	if err != nil {
+		return nil, err
+	}
+	limiter := ProvideQuota(config, datastore, authz)
+	broker := ProvidePostEvents(redisClient)
+	manager := ProvideSessions(config, redisClient)
+	mailerMailer, err := ProvideMailer(config)
+	if err != nil {
+		return nil, err
+	}
+	verifier := ProvideVerification(config, redisClient, mailerMailer)
+	bizBiz := biz.NewBiz(datastore, authz, uploaderUploader, limiter, broker, manager, verifier)
 	validator := validation.New(datastore)
+	store2, err := ProvideTus(config)
+	if err != nil {
+		return nil, err
+	}
+	extractor, err := ProvideClientIPExtractor(config)
+	if err != nil {
+		return nil, err
+	}
+	resolver, err := ProvideGeoIP(config)
+	if err != nil {
+		return nil, err
+	}
 	userRetriever := &UserRetriever{
 		store: datastore,
 	}
@@ -52,6 +76,13 @@ func InitializeWebServer(config *Config) (server.Server, error) {
 		val:       validator,
 		retriever: userRetriever,
 		authz:     authz,
+		upl:       uploaderUploader,
+		tus:       store2,
+		store:     datastore,
+		clientIP:  extractor,
+		geo:       resolver,
+		sessions:  manager,
+		events:    broker,
 	}
 	serverServer, err := NewWebServer(string2, serverConfig)
 	if err != nil {
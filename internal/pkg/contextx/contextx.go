@@ -7,6 +7,7 @@ package contextx
 
 import (
 	"context"
+	"time"
 )
 
 // 定义用于上下文的键.
@@ -17,12 +18,18 @@ type (
 	userIDKey struct{}
 	// accessTokenKey 定义访问令牌的上下文键.
 	accessTokenKey struct{}
+	// tokenIDKey 定义访问令牌唯一标识（jti）的上下文键.
+	tokenIDKey struct{}
+	// tokenExpireAtKey 定义访问令牌过期时间的上下文键.
+	tokenExpireAtKey struct{}
 	// requestIDKey 定义请求 ID 的上下文键.
 	requestIDKey struct{}
 	// clientIPKey 定义客户端 IP 地址的上下文键.
 	clientIPKey struct{}
 	// clientLocationKey 定义客户端地理位置的上下文键.
 	clientLocationKey struct{}
+	// userAgentKey 定义客户端 User-Agent 的上下文键.
+	userAgentKey struct{}
 )
 
 // WithUserID 将用户 ID 存放到上下文中.
@@ -58,6 +65,28 @@ func AccessToken(ctx context.Context) string {
 	return accessToken
 }
 
+// WithTokenID 将访问令牌的唯一标识存放到上下文中.
+func WithTokenID(ctx context.Context, tokenID string) context.Context {
+	return context.WithValue(ctx, tokenIDKey{}, tokenID)
+}
+
+// TokenID 从上下文中提取访问令牌的唯一标识.
+func TokenID(ctx context.Context) string {
+	tokenID, _ := ctx.Value(tokenIDKey{}).(string)
+	return tokenID
+}
+
+// WithTokenExpireAt 将访问令牌的过期时间存放到上下文中.
+func WithTokenExpireAt(ctx context.Context, expireAt time.Time) context.Context {
+	return context.WithValue(ctx, tokenExpireAtKey{}, expireAt)
+}
+
+// TokenExpireAt 从上下文中提取访问令牌的过期时间.
+func TokenExpireAt(ctx context.Context) time.Time {
+	expireAt, _ := ctx.Value(tokenExpireAtKey{}).(time.Time)
+	return expireAt
+}
+
 // WithRequestID 将请求 ID 存放到上下文中.
 func WithRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, requestIDKey{}, requestID)
@@ -90,3 +119,14 @@ func ClientLocation(ctx context.Context) string {
 	location, _ := ctx.Value(clientLocationKey{}).(string)
 	return location
 }
+
+// WithUserAgent 将客户端的 User-Agent 存放到上下文中.
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentKey{}, userAgent)
+}
+
+// UserAgent 从上下文中提取客户端的 User-Agent.
+func UserAgent(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey{}).(string)
+	return userAgent
+}
@@ -10,7 +10,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
 	"github.com/clin211/miniblog-v2/internal/pkg/errno"
 )
 
@@ -34,6 +36,27 @@ type ErrorResponse struct {
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
+// NewErrorResponse 将错误转换为 HTTP 状态码和统一格式的错误响应.
+// requestID 不为空时写入元数据的 X-Request-ID 中，Gin 与 gRPC-Gateway 模式共用该函数以保证错误响应一致.
+func NewErrorResponse(err error, requestID string) (int, ErrorResponse) {
+	errs := errno.FromError(err) // 提取错误详细信息
+
+	// 复制元数据，避免修改预定义的错误变量
+	metadata := make(map[string]string, len(errs.Metadata)+1)
+	for k, v := range errs.Metadata {
+		metadata[k] = v
+	}
+	if requestID != "" {
+		metadata["X-Request-ID"] = requestID
+	}
+
+	return errs.Code, ErrorResponse{
+		Reason:   errs.Reason,
+		Message:  errs.Message,
+		Metadata: metadata,
+	}
+}
+
 // HandleJSONRequest 是处理 JSON 请求的快捷函数.
 func HandleJSONRequest[T any, R any](c *gin.Context, handler Handler[T, R], validators ...Validator[T]) {
 	HandleRequest(c, c.ShouldBindJSON, handler, validators...)
@@ -91,6 +114,11 @@ func ShouldBindQuery[T any](c *gin.Context, rq *T, validators ...Validator[T]) e
 	return ReadRequest(c, rq, c.ShouldBindQuery, validators...)
 }
 
+// ShouldBindForm 使用表单（含 multipart/form-data）格式的绑定函数绑定请求参数并执行验证。
+func ShouldBindForm[T any](c *gin.Context, rq *T, validators ...Validator[T]) error {
+	return ReadRequest(c, rq, func(obj any) error { return c.ShouldBindWith(obj, binding.Form) }, validators...)
+}
+
 // ShouldBindUri 使用 URI 格式的绑定函数绑定请求参数并执行验证。
 func ShouldBindUri[T any](c *gin.Context, rq *T, validators ...Validator[T]) error {
 	return ReadRequest(c, rq, c.ShouldBindUri, validators...)
@@ -129,12 +157,8 @@ func ReadRequest[T any](c *gin.Context, rq *T, binder Binder, validators ...Vali
 func WriteResponse(c *gin.Context, data any, err error) {
 	if err != nil {
 		// 如果发生错误，生成错误响应
-		errs := errno.FromError(err) // 提取错误详细信息
-		c.JSON(errs.Code, ErrorResponse{
-			Reason:   errs.Reason,
-			Message:  errs.Message,
-			Metadata: errs.Metadata,
-		})
+		code, rsp := NewErrorResponse(err, contextx.RequestID(c.Request.Context()))
+		c.JSON(code, rsp)
 		return
 	}
 
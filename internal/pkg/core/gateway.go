@@ -0,0 +1,102 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+)
+
+// GatewayServeMuxOptions 返回 gRPC-Gateway 的选项，使其请求 ID 与错误响应的格式与 Gin 模式一致：
+//   - 透传或生成 x-request-id，写入响应头并转发给 gRPC 服务；
+//   - 错误响应使用与 WriteResponse 相同的状态码与 {reason, message, metadata} 格式.
+func GatewayServeMuxOptions() []runtime.ServeMuxOption {
+	return []runtime.ServeMuxOption{
+		runtime.WithMiddlewares(gatewayRequestID),
+		runtime.WithIncomingHeaderMatcher(gatewayIncomingHeaderMatcher),
+		runtime.WithOutgoingHeaderMatcher(gatewayOutgoingHeaderMatcher),
+		runtime.WithErrorHandler(GatewayErrorHandler),
+		runtime.WithRoutingErrorHandler(GatewayRoutingErrorHandler),
+	}
+}
+
+// GatewayErrorHandler 是 gRPC-Gateway 的错误处理函数，以与 WriteResponse 相同的格式输出错误.
+func GatewayErrorHandler(_ context.Context, _ *runtime.ServeMux, _ runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	var customStatus *runtime.HTTPStatusError
+	if errors.As(err, &customStatus) {
+		err = customStatus.Err
+	}
+
+	code, rsp := NewErrorResponse(err, ensureRequestID(w, r))
+	data, merr := json.Marshal(rsp)
+	if merr != nil {
+		code, data = errno.ErrInternal.Code, []byte(`{"reason":"InternalError","message":"Failed to marshal error message."}`)
+	}
+
+	w.Header().Del("Trailer")
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_, _ = w.Write(data)
+}
+
+// GatewayRoutingErrorHandler 处理未匹配到路由的请求. Gin 未开启 HandleMethodNotAllowed，
+// 因此路径或方法不匹配时均与 Gin 的 NoRoute 一样返回 ErrPageNotFound.
+func GatewayRoutingErrorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, httpStatus int) {
+	var err error
+	switch httpStatus {
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		err = errno.ErrPageNotFound
+	case http.StatusBadRequest:
+		err = errno.ErrInvalidArgument
+	default:
+		err = errno.ErrInternal
+	}
+	GatewayErrorHandler(ctx, mux, marshaler, w, r, err)
+}
+
+// gatewayRequestID 确保请求携带 x-request-id，并将其写入响应头.
+func gatewayRequestID(next runtime.HandlerFunc) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ensureRequestID(w, r)
+		next(w, r, pathParams)
+	}
+}
+
+// ensureRequestID 返回请求的 x-request-id，不存在时生成新的 UUID 并写回请求头，以便转发给 gRPC 服务.
+func ensureRequestID(w http.ResponseWriter, r *http.Request) string {
+	requestID := r.Header.Get(known.XRequestID)
+	if requestID == "" {
+		requestID = uuid.New().String()
+		r.Header.Set(known.XRequestID, requestID)
+	}
+	w.Header().Set(known.XRequestID, requestID)
+	return requestID
+}
+
+// gatewayIncomingHeaderMatcher 将 x-request-id 请求头作为 gRPC 元数据转发，其余请求头使用默认规则.
+func gatewayIncomingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, known.XRequestID) {
+		return known.XRequestID, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// gatewayOutgoingHeaderMatcher 忽略 gRPC 响应头中的 x-request-id，该响应头已由 gatewayRequestID 设置.
+func gatewayOutgoingHeaderMatcher(key string) (string, bool) {
+	if strings.EqualFold(key, known.XRequestID) {
+		return "", false
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,69 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+type fakeUserServer struct {
+	v1.UnimplementedMiniBlogServer
+}
+
+func (fakeUserServer) GetUser(context.Context, *v1.GetUserRequest) (*v1.GetUserResponse, error) {
+	return nil, errno.ErrUserNotFound
+}
+
+// serve 发起请求并返回响应.
+func serve(h http.Handler, method, path string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(known.XRequestID, "request-1")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w
+}
+
+func TestGatewayErrorMatchesGin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(contextx.WithRequestID(c.Request.Context(), c.GetHeader(known.XRequestID)))
+	})
+	engine.GET("/v1/system/users/:userID", func(c *gin.Context) {
+		WriteResponse(c, nil, errno.ErrUserNotFound)
+	})
+	engine.NoRoute(func(c *gin.Context) {
+		WriteResponse(c, nil, errno.ErrPageNotFound)
+	})
+
+	mux := runtime.NewServeMux(GatewayServeMuxOptions()...)
+	require.NoError(t, v1.RegisterMiniBlogHandlerServer(context.Background(), mux, fakeUserServer{}))
+
+	for _, tc := range []struct{ method, path string }{
+		{http.MethodGet, "/v1/system/users/user-000001"},
+		{http.MethodGet, "/v1/system/unknown"},
+		{http.MethodPatch, "/v1/system/users/user-000001"},
+	} {
+		want, got := serve(engine, tc.method, tc.path), serve(mux, tc.method, tc.path)
+		assert.Equal(t, want.Code, got.Code, tc.path)
+		assert.Equal(t, want.Header().Get("Content-Type"), got.Header().Get("Content-Type"), tc.path)
+		assert.JSONEq(t, want.Body.String(), got.Body.String(), tc.path)
+		assert.Equal(t, "request-1", got.Header().Get(known.XRequestID), tc.path)
+	}
+}
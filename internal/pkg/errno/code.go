@@ -47,6 +47,9 @@ var (
 	// ErrTokenInvalid 表示 JWT Token 格式无效.
 	ErrTokenInvalid = &ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenInvalid", Message: "Token was invalid."}
 
+	// ErrTokenRevoked 表示 JWT Token 已被吊销，例如用户已退出登录或修改了密码.
+	ErrTokenRevoked = &ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.TokenRevoked", Message: "Token has been revoked, please log in again."}
+
 	// ErrDBRead 表示数据库读取失败.
 	ErrDBRead = &ErrorX{Code: http.StatusInternalServerError, Reason: "InternalError.DBRead", Message: "Database read failure."}
 
@@ -8,6 +8,7 @@ package errno
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 
 	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
@@ -44,6 +45,14 @@ func (err *ErrorX) Error() string {
 	return fmt.Sprintf("error: code = %d reason = %s message = %s metadata = %v", err.Code, err.Reason, err.Message, err.Metadata)
 }
 
+// Copy 返回错误的副本. 预定义的错误变量为全局共享，设置 Message 或 Metadata 前应先复制，
+// 避免并发请求之间相互覆盖.
+func (err *ErrorX) Copy() *ErrorX {
+	e := *err
+	e.Metadata = maps.Clone(err.Metadata)
+	return &e
+}
+
 // WithMessage 设置错误的 Message 字段
 func (err *ErrorX) WithMessage(format string, args ...any) *ErrorX {
 	err.Message = fmt.Sprintf(format, args...)
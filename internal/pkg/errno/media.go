@@ -0,0 +1,16 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package errno
+
+import "net/http"
+
+var (
+	// ErrMediaNotFound 表示未找到指定的上传文件.
+	ErrMediaNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound.MediaNotFound", Message: "Media file not found."}
+
+	// ErrMediaInUse 表示文件仍被文章或用户引用，需指定 force 才能删除.
+	ErrMediaInUse = &ErrorX{Code: http.StatusConflict, Reason: "FailedPrecondition.MediaInUse", Message: "Media file is still referenced."}
+)
@@ -9,3 +9,12 @@ import "net/http"
 
 // ErrPostNotFound 表示未找到指定的博客.
 var ErrPostNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound.PostNotFound", Message: "Post not found."}
+
+// ErrTagNotFound 表示未找到指定的标签.
+var ErrTagNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound.TagNotFound", Message: "Tag not found."}
+
+// ErrWatchLagged 表示订阅者处理事件过慢，订阅已被服务端关闭.
+var ErrWatchLagged = &ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.WatchLagged", Message: "Subscriber is too slow and missed events, please watch again."}
+
+// ErrWatchUnavailable 表示服务端未启用文章事件订阅.
+var ErrWatchUnavailable = &ErrorX{Code: http.StatusServiceUnavailable, Reason: "Unavailable.WatchUnavailable", Message: "Watching posts is not available."}
@@ -0,0 +1,73 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package errno
+
+import "net/http"
+
+var (
+	// ErrFileTooLarge 表示上传内容超过了允许的大小.
+	ErrFileTooLarge = &ErrorX{Code: http.StatusRequestEntityTooLarge, Reason: "InvalidArgument.FileTooLarge", Message: "The uploaded file exceeds the maximum allowed size."}
+
+	// ErrMIMENotAllowed 表示上传内容的类型（以内容嗅探结果为准）不在允许列表中.
+	ErrMIMENotAllowed = &ErrorX{Code: http.StatusUnsupportedMediaType, Reason: "InvalidArgument.MIMENotAllowed", Message: "The uploaded file type is not allowed."}
+
+	// ErrMultipartDisabled 表示分片上传未开启.
+	ErrMultipartDisabled = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.MultipartDisabled", Message: "Multipart upload is disabled."}
+
+	// ErrPresignDisabled 表示当前存储后端或配置不支持分片直传.
+	ErrPresignDisabled = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.PresignDisabled", Message: "Direct upload with presigned URLs is disabled."}
+
+	// ErrUploadSessionNotFound 表示分片上传会话不存在或已过期.
+	ErrUploadSessionNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UploadSessionNotFound", Message: "Upload session not found or expired."}
+
+	// ErrInvalidPart 表示分片号、分片大小或分片列表不合法.
+	ErrInvalidPart = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.InvalidPart", Message: "One or more of the specified parts is invalid."}
+
+	// ErrChecksumMismatch 表示服务端计算的校验值与客户端提供的不一致.
+	ErrChecksumMismatch = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.ChecksumMismatch", Message: "The checksum of the uploaded content does not match."}
+
+	// ErrInvalidImage 表示上传的图片结构损坏，无法去除元数据或解析.
+	ErrInvalidImage = &ErrorX{Code: http.StatusBadRequest, Reason: "InvalidArgument.InvalidImage", Message: "The uploaded image is malformed."}
+
+	// ErrFileQuarantined 表示上传内容被扫描器判定为威胁并已隔离，metadata 中包含 quarantineID、scanner 与 threat.
+	ErrFileQuarantined = &ErrorX{Code: http.StatusUnprocessableEntity, Reason: "InvalidArgument.FileQuarantined", Message: "The uploaded file was rejected by the content scanner and quarantined."}
+
+	// ErrScanFailed 表示扫描器不可用，且未配置失败放行.
+	ErrScanFailed = &ErrorX{Code: http.StatusServiceUnavailable, Reason: "Unavailable.ScanFailed", Message: "The uploaded file could not be scanned, please try again later."}
+
+	// ErrStorageQuotaExceeded 表示上传后将超过用户的总存储配额，metadata 中的 remaining 为剩余字节数.
+	ErrStorageQuotaExceeded = &ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.StorageQuotaExceeded", Message: "Storage quota exceeded."}
+
+	// ErrDailyUploadLimitExceeded 表示上传后将超过用户当天的上传字节数限制，metadata 中的 remaining 为剩余字节数.
+	ErrDailyUploadLimitExceeded = &ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.DailyUploadLimitExceeded", Message: "Daily upload limit exceeded."}
+
+	// ErrTooManyUploadSessions 表示用户进行中的分片上传会话数已达上限，metadata 中的 remaining 为剩余会话数.
+	ErrTooManyUploadSessions = &ErrorX{Code: http.StatusTooManyRequests, Reason: "ResourceExhausted.TooManyUploadSessions", Message: "Too many concurrent upload sessions."}
+
+	// ErrTusVersionUnsupported 表示请求的 Tus-Resumable 头缺失或版本不受支持.
+	ErrTusVersionUnsupported = &ErrorX{Code: http.StatusPreconditionFailed, Reason: "FailedPrecondition.TusVersionUnsupported", Message: "Unsupported tus protocol version."}
+
+	// ErrUploadOffsetMismatch 表示 Upload-Offset 与服务端已接收的字节数不一致，客户端应通过 HEAD 重新获取偏移量.
+	ErrUploadOffsetMismatch = &ErrorX{Code: http.StatusConflict, Reason: "FailedPrecondition.UploadOffsetMismatch", Message: "Upload-Offset does not match the current offset of the upload."}
+
+	// ErrUploadContentType 表示续传数据的 Content-Type 不是 application/offset+octet-stream.
+	ErrUploadContentType = &ErrorX{Code: http.StatusUnsupportedMediaType, Reason: "InvalidArgument.UploadContentType", Message: "Content-Type must be application/offset+octet-stream."}
+
+	// ErrUploadIncomplete 表示可续传上传尚未接收完全部字节.
+	ErrUploadIncomplete = &ErrorX{Code: http.StatusConflict, Reason: "FailedPrecondition.UploadIncomplete", Message: "The upload is not completed yet."}
+
+	// ErrUploadExpired 表示可续传上传已过期，客户端需要重新创建上传.
+	ErrUploadExpired = &ErrorX{Code: http.StatusGone, Reason: "NotFound.UploadExpired", Message: "The upload has expired."}
+
+	// ErrSignatureMismatch 表示私有对象的访问 URL 缺少签名或签名不正确.
+	ErrSignatureMismatch = &ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.SignatureMismatch", Message: "The request signature does not match."}
+
+	// ErrSignedURLExpired 表示私有对象的签名 URL 已过期，需要重新获取.
+	ErrSignedURLExpired = &ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.SignedURLExpired", Message: "The signed URL has expired."}
+
+	// ErrObjectKeyConflict 表示对象键已被其他内容占用，已存在的对象不会被覆盖.
+	ErrObjectKeyConflict = &ErrorX{Code: http.StatusConflict, Reason: "FailedPrecondition.ObjectKeyConflict", Message: "An object with the same key but different content already exists."}
+)
@@ -27,4 +27,44 @@ var (
 
 	// ErrUserNotFound 表示未找到指定用户.
 	ErrUserNotFound = &ErrorX{Code: http.StatusNotFound, Reason: "NotFound.UserNotFound", Message: "User not found."}
+
+	// ErrAccountLocked 表示连续登录失败次数过多，账户被临时锁定，metadata 中的 unlockAt 为解锁时间（Unix 时间戳）.
+	ErrAccountLocked = &ErrorX{
+		Code:    http.StatusTooManyRequests,
+		Reason:  "ResourceExhausted.AccountLocked",
+		Message: "Too many failed login attempts, the account is temporarily locked.",
+	}
+
+	// ErrRefreshTokenInvalid 表示刷新令牌无效、已过期或已被吊销.
+	ErrRefreshTokenInvalid = &ErrorX{Code: http.StatusUnauthorized, Reason: "Unauthenticated.RefreshTokenInvalid", Message: "Refresh token is invalid or has expired."}
+
+	// ErrRefreshTokenReused 表示刷新令牌被重复使用，同一次登录签发的刷新令牌已全部吊销.
+	ErrRefreshTokenReused = &ErrorX{
+		Code:    http.StatusUnauthorized,
+		Reason:  "Unauthenticated.RefreshTokenReused",
+		Message: "Refresh token has already been used, please log in again.",
+	}
+
+	// ErrEmailAlreadyVerified 表示邮箱已经验证，无需再次验证.
+	ErrEmailAlreadyVerified = &ErrorX{Code: http.StatusConflict, Reason: "FailedPrecondition.EmailAlreadyVerified", Message: "Email has already been verified."}
+
+	// ErrEmailNotVerified 表示邮箱尚未验证，不能执行需要验证邮箱的操作.
+	ErrEmailNotVerified = &ErrorX{Code: http.StatusForbidden, Reason: "PermissionDenied.EmailNotVerified", Message: "Please verify your email address first."}
+
+	// ErrVerificationTokenInvalid 表示验证令牌无效、已过期或已被使用.
+	ErrVerificationTokenInvalid = &ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.VerificationTokenInvalid",
+		Message: "Verification token is invalid or has expired.",
+	}
+
+	// ErrVerificationThrottled 表示验证邮件发送过于频繁，metadata 中的 retryAfter 为需要等待的秒数.
+	ErrVerificationThrottled = &ErrorX{
+		Code:    http.StatusTooManyRequests,
+		Reason:  "ResourceExhausted.VerificationThrottled",
+		Message: "Verification email was sent recently, please try again later.",
+	}
+
+	// ErrMailerUnavailable 表示未配置发件服务，无法发送邮件.
+	ErrMailerUnavailable = &ErrorX{Code: http.StatusServiceUnavailable, Reason: "Unavailable.MailerUnavailable", Message: "Sending email is not available."}
 )
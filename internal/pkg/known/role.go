@@ -10,4 +10,6 @@ const (
 	RoleUser = "role::user"
 	// 管理员角色
 	RoleAdmin = "role::admin"
+	// 投稿者角色，用于访客投稿（POST_TYPE_CONTRIBUTION），通常配置更小的上传配额
+	RoleContributor = "role::contributor"
 )
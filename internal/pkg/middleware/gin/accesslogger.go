@@ -21,6 +21,8 @@ import (
 const (
 	// MultipartFormData 文件上传的 Content-Type
 	MultipartFormData = "multipart/form-data"
+	// OctetStream 分片上传等二进制请求体的 Content-Type
+	OctetStream = "application/octet-stream"
 	// MaxResponseLogSize 响应日志记录的最大大小 (10KB)
 	MaxResponseLogSize = 10 * 1024
 	// ResponseTooLargeMessage 响应过大时的提示信息
@@ -66,8 +68,8 @@ func AccessLogger() gin.HandlerFunc {
 		//保存body
 		var reqBody []byte
 		contentType := ctx.GetHeader("Content-Type")
-		// multipart/form-data 文件上传请求, 不在日志里记录body
-		if !strings.Contains(contentType, MultipartFormData) {
+		// multipart/form-data 文件上传及二进制分片请求, 不在日志里记录body
+		if !strings.Contains(contentType, MultipartFormData) && !strings.Contains(contentType, OctetStream) {
 			reqBody, _ = io.ReadAll(ctx.Request.Body)
 			ctx.Request.Body = io.NopCloser(bytes.NewReader(reqBody))
 		}
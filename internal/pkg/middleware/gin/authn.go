@@ -23,19 +23,42 @@ type UserRetriever interface {
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
 }
 
+// TokenChecker 用于判断令牌是否已被吊销的接口.
+type TokenChecker interface {
+	// Revoked 判断令牌是否已被吊销，例如已退出登录或已修改密码
+	Revoked(ctx context.Context, claims *token.Claims) (bool, error)
+}
+
 // AuthnMiddleware 是一个认证中间件，用于从 gin.Context 中提取 token 并验证 token 是否合法.
-func AuthnMiddleware(retriever UserRetriever) gin.HandlerFunc {
+func AuthnMiddleware(retriever UserRetriever, checker TokenChecker) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 解析 JWT Token
-		userID, err := token.ParseRequest(c)
+		claims, err := token.ParseRequestClaims(c)
 		if err != nil {
 			core.WriteResponse(c, nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error()))
 			c.Abort()
 			return
 		}
+		userID := claims.Identity
 
 		log.Debugw("Token parsing successful", "userID", userID)
 
+		// 无法确认令牌是否已被吊销时拒绝请求
+		if checker != nil {
+			revoked, err := checker.Revoked(c, claims)
+			if err != nil {
+				log.W(c).Errorw("Failed to check token revocation", "err", err)
+				core.WriteResponse(c, nil, errno.ErrInternal)
+				c.Abort()
+				return
+			}
+			if revoked {
+				core.WriteResponse(c, nil, errno.ErrTokenRevoked)
+				c.Abort()
+				return
+			}
+		}
+
 		user, err := retriever.GetUser(c, userID)
 		if err != nil {
 			core.WriteResponse(c, nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error()))
@@ -45,6 +68,8 @@ func AuthnMiddleware(retriever UserRetriever) gin.HandlerFunc {
 
 		ctx := contextx.WithUserID(c.Request.Context(), user.UserID)
 		ctx = contextx.WithUsername(ctx, user.Username)
+		ctx = contextx.WithTokenID(ctx, claims.ID)
+		ctx = contextx.WithTokenExpireAt(ctx, claims.ExpiresAt)
 		c.Request = c.Request.WithContext(ctx)
 
 		c.Next()
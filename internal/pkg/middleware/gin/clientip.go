@@ -0,0 +1,42 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package gin
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	"github.com/clin211/miniblog-v2/pkg/clientip"
+	"github.com/clin211/miniblog-v2/pkg/geoip"
+)
+
+// ClientIPMiddleware 是一个 Gin 中间件，按受信任的代理解析客户端 IP，
+// 并通过 resolver 查询其地理位置，一并保存到请求的 context 中.
+// resolver 为 nil 时不解析地理位置. 客户端的 User-Agent 同时保存到 context 中，用于登录审计.
+func ClientIPMiddleware(extractor *clientip.Extractor, resolver geoip.Resolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+		ip := extractor.ClientIP(
+			c.Request.RemoteAddr,
+			c.Request.Header.Values(clientip.HeaderXForwardedFor),
+			c.Request.Header.Get(clientip.HeaderXRealIP),
+		)
+		ctx = contextx.WithClientIP(ctx, ip)
+		ctx = contextx.WithUserAgent(ctx, c.Request.UserAgent())
+
+		location, err := geoip.Lookup(ctx, resolver, ip)
+		if err != nil {
+			log.W(ctx).Warnw("Failed to resolve client location", "ip", ip, "err", err)
+		}
+		if location != nil {
+			ctx = contextx.WithClientLocation(ctx, location.String())
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
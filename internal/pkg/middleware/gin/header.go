@@ -22,11 +22,12 @@ func NoCache(c *gin.Context) {
 
 // Cors是一个 Gin 中间件，用于处理 CORS 请求.
 func Cors(c *gin.Context) {
-	// 处理预检请求
-	if c.Request.Method == http.MethodOptions {
+	// 处理预检请求；显式注册了 OPTIONS 路由的非预检请求（如 tus 协议发现）交给路由处理
+	if c.Request.Method == http.MethodOptions && (c.GetHeader("Access-Control-Request-Method") != "" || c.FullPath() == "") {
 		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "authorization, origin, content-type, accept")
+		c.Header("Access-Control-Allow-Methods", "GET, HEAD, POST, PUT, PATCH, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "authorization, origin, content-type, accept, "+
+			"tus-resumable, upload-length, upload-metadata, upload-offset, x-requested-with")
 		c.Header("Allow", "HEAD, GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		c.Header("Content-Type", "application/json")
 		c.AbortWithStatus(http.StatusOK)
@@ -11,21 +11,12 @@ import (
 
 	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
 	"github.com/clin211/miniblog-v2/internal/pkg/known"
-	"github.com/clin211/miniblog-v2/pkg/ipwho"
 )
 
 // RequestIDMiddleware 是一个 Gin 中间件，用于在每个 HTTP 请求的上下文和
 // 响应中注入 `x-request-id` 键值对.
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		client := ipwho.NewClient()
-		details, err := client.GetHostIPDetail(c)
-		ctx := contextx.WithClientIP(c.Request.Context(), c.ClientIP())
-		if err == nil {
-			// 将 IP 保存到 context.Contextx 中，以便后续程序使用
-			ctx = contextx.WithClientIP(c.Request.Context(), details.IP)
-		}
-
 		// 从请求头中获取 `x-request-id`，如果不存在则生成新的 UUID
 		requestID := c.Request.Header.Get(known.XRequestID)
 
@@ -34,7 +25,7 @@ func RequestIDMiddleware() gin.HandlerFunc {
 		}
 
 		// 将 RequestID 保存到 context.Context 中，以便后续程序使用
-		ctx = contextx.WithRequestID(ctx, requestID)
+		ctx := contextx.WithRequestID(c.Request.Context(), requestID)
 		c.Request = c.Request.WithContext(ctx)
 
 		// 将 RequestID 保存到 HTTP 返回头中，Header 的键为 `x-request-id`
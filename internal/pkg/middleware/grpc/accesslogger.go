@@ -66,6 +66,21 @@ func AccessLogger() grpc.UnaryServerInterceptor {
 	}
 }
 
+// AccessLoggerStream 是 AccessLogger 的流式版本. 流式调用的消息数量不定，只记录调用的开始与结束.
+func AccessLoggerStream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+		clientIP, userID, requestID := getClientIP(ctx), getUserID(ctx), contextx.RequestID(ctx)
+
+		accessLog(ctx, "access_start", 0, info.FullMethod, clientIP, userID, requestID, "", "", 0)
+		err := handler(srv, ss)
+		accessLog(ctx, "access_end", time.Since(start), info.FullMethod, clientIP, userID, requestID, "", "", getStatusCode(err))
+
+		return err
+	}
+}
+
 // getClientIP 从 context 中获取客户端 IP 地址
 // 优先从 contextx 中获取已经处理过的 IP 信息，如果没有则从 peer 中提取
 func getClientIP(ctx context.Context) string {
@@ -23,32 +23,73 @@ type UserRetriever interface {
 	GetUser(ctx context.Context, userID string) (*model.UserM, error)
 }
 
+// TokenChecker 用于判断令牌是否已被吊销的接口.
+type TokenChecker interface {
+	// Revoked 判断令牌是否已被吊销，例如已退出登录或已修改密码
+	Revoked(ctx context.Context, claims *token.Claims) (bool, error)
+}
+
 // AuthnInterceptor 是一个 gRPC 拦截器，用于进行认证.
-func AuthnInterceptor(retriever UserRetriever) grpc.UnaryServerInterceptor {
+func AuthnInterceptor(retriever UserRetriever, checker TokenChecker) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 解析 JWT Token
-		userID, err := token.ParseRequest(ctx)
+		ctx, err := authenticate(ctx, retriever, checker)
 		if err != nil {
-			log.Errorw("Failed to parse request", "err", err)
-			return nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error())
+			return nil, err
 		}
 
-		log.Debugw("Token parsing successful", "userID", userID)
+		// 继续处理请求
+		return handler(ctx, req)
+	}
+}
 
-		user, err := retriever.GetUser(ctx, userID)
+// AuthnStreamInterceptor 是 AuthnInterceptor 的流式版本.
+func AuthnStreamInterceptor(retriever UserRetriever, checker TokenChecker) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticate(ss.Context(), retriever, checker)
 		if err != nil {
-			return nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error())
+			return err
 		}
+		return handler(srv, wrapServerStream(ctx, ss))
+	}
+}
+
+// authenticate 解析请求中的 JWT Token，并将用户信息存入上下文.
+func authenticate(ctx context.Context, retriever UserRetriever, checker TokenChecker) (context.Context, error) {
+	// 解析 JWT Token
+	claims, err := token.ParseRequestClaims(ctx)
+	if err != nil {
+		log.Errorw("Failed to parse request", "err", err)
+		return nil, errno.ErrTokenInvalid.WithMessage("%s", err.Error())
+	}
+	userID := claims.Identity
 
-		// 将用户信息存入上下文
-		ctx = context.WithValue(ctx, known.XUsername, user.Username) //nolint:staticcheck
-		ctx = context.WithValue(ctx, known.XUserID, userID)          //nolint:staticcheck
+	log.Debugw("Token parsing successful", "userID", userID)
 
-		// 供 log 和 contextx 使用
-		ctx = contextx.WithUserID(ctx, user.UserID)
-		ctx = contextx.WithUsername(ctx, user.Username)
+	// 无法确认令牌是否已被吊销时拒绝请求
+	if checker != nil {
+		revoked, err := checker.Revoked(ctx, claims)
+		if err != nil {
+			log.W(ctx).Errorw("Failed to check token revocation", "err", err)
+			return nil, errno.ErrInternal
+		}
+		if revoked {
+			return nil, errno.ErrTokenRevoked
+		}
+	}
 
-		// 继续处理请求
-		return handler(ctx, req)
+	user, err := retriever.GetUser(ctx, userID)
+	if err != nil {
+		return nil, errno.ErrUnauthenticated.WithMessage("%s", err.Error())
 	}
+
+	// 将用户信息存入上下文
+	ctx = context.WithValue(ctx, known.XUsername, user.Username) //nolint:staticcheck
+	ctx = context.WithValue(ctx, known.XUserID, userID)          //nolint:staticcheck
+
+	// 供 log 和 contextx 使用
+	ctx = contextx.WithUserID(ctx, user.UserID)
+	ctx = contextx.WithUsername(ctx, user.Username)
+	ctx = contextx.WithTokenID(ctx, claims.ID)
+	ctx = contextx.WithTokenExpireAt(ctx, claims.ExpiresAt)
+	return ctx, nil
 }
@@ -23,25 +23,43 @@ type Authorizer interface {
 // AuthzInterceptor 是一个 gRPC 拦截器，用于进行请求授权.
 func AuthzInterceptor(authorizer Authorizer) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		subject := contextx.UserID(ctx) // 获取用户ID
-		object := info.FullMethod       // 获取请求资源
-		action := "CALL"                // 默认操作
-
-		// 记录授权上下文信息
-		log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
-
-		// 调用授权接口进行验证
-		if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
-			return nil, errno.ErrPermissionDenied.WithMessage(
-				"access denied: subject=%s, object=%s, action=%s, reason=%v",
-				subject,
-				object,
-				action,
-				err,
-			)
+		if err := authorize(ctx, authorizer, info.FullMethod); err != nil {
+			return nil, err
 		}
 
 		// 继续处理请求
 		return handler(ctx, req)
 	}
 }
+
+// AuthzStreamInterceptor 是 AuthzInterceptor 的流式版本.
+func AuthzStreamInterceptor(authorizer Authorizer) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), authorizer, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize 校验当前用户是否有权调用 fullMethod.
+func authorize(ctx context.Context, authorizer Authorizer, fullMethod string) error {
+	subject := contextx.UserID(ctx) // 获取用户ID
+	object := fullMethod            // 获取请求资源
+	action := "CALL"                // 默认操作
+
+	// 记录授权上下文信息
+	log.Debugw("Build authorize context", "subject", subject, "object", object, "action", action)
+
+	// 调用授权接口进行验证
+	if allowed, err := authorizer.Authorize(subject, object, action); err != nil || !allowed {
+		return errno.ErrPermissionDenied.WithMessage(
+			"access denied: subject=%s, object=%s, action=%s, reason=%v",
+			subject,
+			object,
+			action,
+			err,
+		)
+	}
+	return nil
+}
@@ -18,22 +18,32 @@ import (
 // AuthnBypasswInterceptor 是一个 gRPC 拦截器，模拟所有请求都通过认证。
 func AuthnBypasswInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
-		// 从请求头中获取用户ID
-		userID := "user-000001" // 默认用户ID
-		if md, ok := metadata.FromIncomingContext(ctx); ok {
-			// 获取header中指定的用户ID，假设Header名为"x-user-id"
-			if values := md.Get(known.XUserID); len(values) > 0 {
-				userID = values[0]
-			}
-		}
-
-		// 将默认的用户信息存入上下文
-		ctx = context.WithValue(ctx, known.XUserID, userID) //nolint:staticcheck
+		// 继续处理请求
+		return handler(bypass(ctx), req)
+	}
+}
 
-		// 为 log 和 contextx 提供用户上下文支持
-		ctx = contextx.WithUserID(ctx, userID)
+// AuthnBypassStreamInterceptor 是 AuthnBypasswInterceptor 的流式版本.
+func AuthnBypassStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, wrapServerStream(bypass(ss.Context()), ss))
+	}
+}
 
-		// 继续处理请求
-		return handler(ctx, req)
+// bypass 将请求头 x-user-id 中的用户 ID（缺省为 user-000001）存入上下文.
+func bypass(ctx context.Context) context.Context {
+	// 从请求头中获取用户ID
+	userID := "user-000001" // 默认用户ID
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		// 获取header中指定的用户ID，假设Header名为"x-user-id"
+		if values := md.Get(known.XUserID); len(values) > 0 {
+			userID = values[0]
+		}
 	}
+
+	// 将默认的用户信息存入上下文
+	ctx = context.WithValue(ctx, known.XUserID, userID) //nolint:staticcheck
+
+	// 为 log 和 contextx 提供用户上下文支持
+	return contextx.WithUserID(ctx, userID)
 }
@@ -0,0 +1,71 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/contextx"
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+	"github.com/clin211/miniblog-v2/pkg/clientip"
+	"github.com/clin211/miniblog-v2/pkg/geoip"
+)
+
+// ClientIPInterceptor 是一个 gRPC 拦截器，按受信任的代理解析客户端 IP，
+// 并通过 resolver 查询其地理位置，一并保存到 context 中.
+// grpc-gateway 会将 HTTP 客户端地址追加到 x-forwarded-for 元数据中，因此网关所在地址需要被信任.
+// 客户端的 User-Agent 同时保存到 context 中，用于登录审计.
+func ClientIPInterceptor(extractor *clientip.Extractor, resolver geoip.Resolver) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(withClientIP(ctx, extractor, resolver), req)
+	}
+}
+
+// ClientIPStreamInterceptor 是 ClientIPInterceptor 的流式版本.
+func ClientIPStreamInterceptor(extractor *clientip.Extractor, resolver geoip.Resolver) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, wrapServerStream(withClientIP(ss.Context(), extractor, resolver), ss))
+	}
+}
+
+// withClientIP 将客户端 IP、地理位置与 User-Agent 保存到 context 中.
+func withClientIP(ctx context.Context, extractor *clientip.Extractor, resolver geoip.Resolver) context.Context {
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	var xRealIP string
+	if values := md.Get(clientip.HeaderXRealIP); len(values) > 0 {
+		xRealIP = values[0]
+	}
+	ip := extractor.ClientIP(remoteAddr, md.Get(clientip.HeaderXForwardedFor), xRealIP)
+	ctx = contextx.WithClientIP(ctx, ip)
+	ctx = contextx.WithUserAgent(ctx, userAgent(md))
+
+	location, err := geoip.Lookup(ctx, resolver, ip)
+	if err != nil {
+		log.W(ctx).Warnw("Failed to resolve client location", "ip", ip, "err", err)
+	}
+	if location != nil {
+		ctx = contextx.WithClientLocation(ctx, location.String())
+	}
+	return ctx
+}
+
+// userAgent 返回客户端的 User-Agent，经 grpc-gateway 转发的请求优先使用原始 HTTP 请求头.
+func userAgent(md metadata.MD) string {
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,61 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// serverStream 包装 grpc.ServerStream，用于替换流的 context 并在接收消息后执行钩子.
+type serverStream struct {
+	grpc.ServerStream
+
+	ctx context.Context
+	// onRecv 在每条消息成功接收后调用，返回错误时中止接收
+	onRecv func(ctx context.Context, msg any) error
+}
+
+// wrapServerStream 返回 context 为 ctx 的 ServerStream.
+func wrapServerStream(ctx context.Context, ss grpc.ServerStream) *serverStream {
+	return &serverStream{ServerStream: ss, ctx: ctx}
+}
+
+// Context 返回包装后的 context.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// RecvMsg 接收消息并执行 onRecv 钩子.
+func (s *serverStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.onRecv != nil {
+		return s.onRecv(s.ctx, m)
+	}
+	return nil
+}
+
+// DefaulterStreamInterceptor 是 DefaulterInterceptor 的流式版本，对客户端发送的每条消息设置默认值.
+func DefaulterStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ss.Context(), onRecv: func(_ context.Context, msg any) error {
+			if defaulter, ok := msg.(interface{ Default() }); ok {
+				defaulter.Default()
+			}
+			return nil
+		}})
+	}
+}
+
+// ValidatorStreamInterceptor 是 ValidatorInterceptor 的流式版本，对客户端发送的每条消息进行校验.
+func ValidatorStreamInterceptor(validator RequestValidator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ss.Context(), onRecv: validator.Validate})
+	}
+}
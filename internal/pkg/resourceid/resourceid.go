@@ -20,6 +20,8 @@ const (
 	CategoryID ResourceID = "category"
 	// TagID 定义标签资源标识符.
 	TagID ResourceID = "tag"
+	// FileID 定义上传文件资源标识符.
+	FileID ResourceID = "file"
 )
 
 // String 将资源标识符转换为字符串.
@@ -0,0 +1,166 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// 访问控制注解，用于声明 RPC 方法是否需要认证与授权
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.0
+// source: apiserver/v1/access.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Access 表示 RPC 方法的访问级别
+type Access int32
+
+const (
+	// ACCESS_PRIVATE 需要认证与授权，未声明 access 的方法均为该级别
+	Access_ACCESS_PRIVATE Access = 0
+	// ACCESS_PUBLIC 无需认证与授权
+	Access_ACCESS_PUBLIC Access = 1
+	// ACCESS_AUTHENTICATED 只需认证，不进行授权
+	Access_ACCESS_AUTHENTICATED Access = 2
+)
+
+// Enum value maps for Access.
+var (
+	Access_name = map[int32]string{
+		0: "ACCESS_PRIVATE",
+		1: "ACCESS_PUBLIC",
+		2: "ACCESS_AUTHENTICATED",
+	}
+	Access_value = map[string]int32{
+		"ACCESS_PRIVATE":       0,
+		"ACCESS_PUBLIC":        1,
+		"ACCESS_AUTHENTICATED": 2,
+	}
+)
+
+func (x Access) Enum() *Access {
+	p := new(Access)
+	*p = x
+	return p
+}
+
+func (x Access) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Access) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiserver_v1_access_proto_enumTypes[0].Descriptor()
+}
+
+func (Access) Type() protoreflect.EnumType {
+	return &file_apiserver_v1_access_proto_enumTypes[0]
+}
+
+func (x Access) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Access.Descriptor instead.
+func (Access) EnumDescriptor() ([]byte, []int) {
+	return file_apiserver_v1_access_proto_rawDescGZIP(), []int{0}
+}
+
+var file_apiserver_v1_access_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Access)(nil),
+		Field:         51001,
+		Name:          "v1.access",
+		Tag:           "varint,51001,opt,name=access,enum=v1.Access",
+		Filename:      "apiserver/v1/access.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// access 声明方法的访问级别，服务端据此决定是否执行认证与授权拦截器
+	//
+	// optional v1.Access access = 51001;
+	E_Access = &file_apiserver_v1_access_proto_extTypes[0]
+)
+
+var File_apiserver_v1_access_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_access_proto_rawDesc = "" +
+	"\n" +
+	"\x19apiserver/v1/access.proto\x12\x02v1\x1a google/protobuf/descriptor.proto*I\n" +
+	"\x06Access\x12\x12\n" +
+	"\x0eACCESS_PRIVATE\x10\x00\x12\x11\n" +
+	"\rACCESS_PUBLIC\x10\x01\x12\x18\n" +
+	"\x14ACCESS_AUTHENTICATED\x10\x02:D\n" +
+	"\x06access\x12\x1e.google.protobuf.MethodOptions\x18\xb9\x8e\x03 \x01(\x0e2\n" +
+	".v1.AccessR\x06accessB8Z6github.com/clin211/miniblog-v2/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_access_proto_rawDescOnce sync.Once
+	file_apiserver_v1_access_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_access_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_access_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_access_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_access_proto_rawDesc), len(file_apiserver_v1_access_proto_rawDesc)))
+	})
+	return file_apiserver_v1_access_proto_rawDescData
+}
+
+var file_apiserver_v1_access_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_apiserver_v1_access_proto_goTypes = []any{
+	(Access)(0),                        // 0: v1.Access
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_apiserver_v1_access_proto_depIdxs = []int32{
+	1, // 0: v1.access:extendee -> google.protobuf.MethodOptions
+	0, // 1: v1.access:type_name -> v1.Access
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_access_proto_init() }
+func file_apiserver_v1_access_proto_init() {
+	if File_apiserver_v1_access_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_access_proto_rawDesc), len(file_apiserver_v1_access_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_access_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_access_proto_depIdxs,
+		EnumInfos:         file_apiserver_v1_access_proto_enumTypes,
+		ExtensionInfos:    file_apiserver_v1_access_proto_extTypes,
+	}.Build()
+	File_apiserver_v1_access_proto = out.File
+	file_apiserver_v1_access_proto_goTypes = nil
+	file_apiserver_v1_access_proto_depIdxs = nil
+}
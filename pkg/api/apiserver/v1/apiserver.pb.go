@@ -32,10 +32,10 @@ var File_apiserver_v1_apiserver_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\n" +
-	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/post.proto\x1a\x17apiserver/v1/user.proto\x1a\x1bapiserver/v1/category.proto\x1a\x16apiserver/v1/tag.proto\x1a\x1bapiserver/v1/post_tag.proto\x1a\x1eapiserver/v1/upload_file.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xcb2\n" +
-	"\bMiniBlog\x12v\n" +
-	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x13.v1.HealthzResponse\">\x92A+\n" +
-	"\f服务治理\x12\x12服务健康检查*\aHealthz\x82\xd3\xe4\x93\x02\n" +
+	"\x1capiserver/v1/apiserver.proto\x12\x02v1\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a\x19apiserver/v1/access.proto\x1a\x1aapiserver/v1/healthz.proto\x1a\x17apiserver/v1/post.proto\x1a\x17apiserver/v1/user.proto\x1a\x1bapiserver/v1/category.proto\x1a\x16apiserver/v1/tag.proto\x1a\x1bapiserver/v1/post_tag.proto\x1a\x1eapiserver/v1/upload_file.proto\x1a\x18apiserver/v1/media.proto\x1a.protoc-gen-openapiv2/options/annotations.proto2\xeeF\n" +
+	"\bMiniBlog\x12z\n" +
+	"\aHealthz\x12\x16.google.protobuf.Empty\x1a\x13.v1.HealthzResponse\"B\x92A+\n" +
+	"\f服务治理\x12\x12服务健康检查*\aHealthz\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\n" +
 	"\x12\b/healthz\x12\x8f\x01\n" +
 	"\n" +
 	"UploadFile\x12\x15.v1.UploadFileRequest\x1a\x12.v1.UploadedObject\"V\x92A2\n" +
@@ -54,17 +54,35 @@ const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\x11CompleteMultipart\x12\x1c.v1.CompleteMultipartRequest\x1a\x1d.v1.CompleteMultipartResponse\"n\x92A<\n" +
 	"\x13system/文件上传\x12\x12完成分片上传*\x11CompleteMultipart\x82\xd3\xe4\x93\x02):\x01*\"$/v1/system/upload/multipart/complete\x12\xb1\x01\n" +
 	"\x0eAbortMultipart\x12\x19.v1.AbortMultipartRequest\x1a\x1a.v1.AbortMultipartResponse\"h\x92A9\n" +
-	"\x13system/文件上传\x12\x12中止分片上传*\x0eAbortMultipart\x82\xd3\xe4\x93\x02&:\x01**!/v1/system/upload/multipart/abort\x12{\n" +
-	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"M\x92A*\n" +
-	"\x13system/用户管理\x12\f用户登录*\x05Login\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/system/auth/login\x12\x9f\x01\n" +
-	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"\\\x92A1\n" +
-	"\x13system/用户管理\x12\f刷新令牌*\fRefreshToken\x82\xd3\xe4\x93\x02\":\x01*\x1a\x1d/v1/system/auth/refresh-token\x12\xb3\x01\n" +
+	"\x13system/文件上传\x12\x12中止分片上传*\x0eAbortMultipart\x82\xd3\xe4\x93\x02&:\x01**!/v1/system/upload/multipart/abort\x12\x86\x01\n" +
+	"\tListMedia\x12\x14.v1.ListMediaRequest\x1a\x15.v1.ListMediaResponse\"L\x92A1\n" +
+	"\x10system/媒体库\x12\x12媒体文件列表*\tListMedia\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/system/media\x12\x8b\x01\n" +
+	"\bGetMedia\x12\x13.v1.GetMediaRequest\x1a\x14.v1.GetMediaResponse\"T\x92A0\n" +
+	"\x10system/媒体库\x12\x12媒体文件详情*\bGetMedia\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/system/media/{fileID}\x12\x97\x01\n" +
+	"\vDeleteMedia\x12\x16.v1.DeleteMediaRequest\x1a\x17.v1.DeleteMediaResponse\"W\x92A3\n" +
+	"\x10system/媒体库\x12\x12删除媒体文件*\vDeleteMedia\x82\xd3\xe4\x93\x02\x1b*\x19/v1/system/media/{fileID}\x12\x7f\n" +
+	"\x05Login\x12\x10.v1.LoginRequest\x1a\x11.v1.LoginResponse\"Q\x92A*\n" +
+	"\x13system/用户管理\x12\f用户登录*\x05Login\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/system/auth/login\x12\xc4\x02\n" +
+	"\fRefreshToken\x12\x17.v1.RefreshTokenRequest\x1a\x18.v1.RefreshTokenResponse\"\x80\x02\x92A\xd0\x01\n" +
+	"\x13system/用户管理\x12\f刷新令牌\x1a\x9c\x01使用刷新令牌换取新的访问令牌与刷新令牌。刷新令牌只能使用一次，重复使用会吊销同一次登录签发的全部刷新令牌*\fRefreshToken\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\":\x01*\x1a\x1d/v1/system/auth/refresh-token\x12\xf0\x01\n" +
+	"\x06Logout\x12\x11.v1.LogoutRequest\x1a\x12.v1.LogoutResponse\"\xbe\x01\x92A\x99\x01\n" +
+	"\x13system/用户管理\x12\f退出登录\x1al吊销当前访问令牌；请求中携带刷新令牌时，同一次登录签发的刷新令牌一并吊销*\x06Logout\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/v1/system/auth/logout\x12\xb3\x01\n" +
 	"\x0eChangePassword\x12\x19.v1.ChangePasswordRequest\x1a\x1a.v1.ChangePasswordResponse\"j\x92A3\n" +
-	"\x13system/用户管理\x12\f修改密码*\x0eChangePassword\x82\xd3\xe4\x93\x02.:\x01*\x1a)/v1/system/users/{userID}/change-password\x12\x8a\x01\n" +
+	"\x13system/用户管理\x12\f修改密码*\x0eChangePassword\x82\xd3\xe4\x93\x02.:\x01*\x1a)/v1/system/users/{userID}/change-password\x12\x9d\x02\n" +
+	"\x10RevokeUserTokens\x12\x1b.v1.RevokeUserTokensRequest\x1a\x1c.v1.RevokeUserTokensResponse\"\xcd\x01\x92A\x97\x01\n" +
+	"\x13system/用户管理\x12\x1b吊销用户的全部令牌\x1aQ仅管理员可用，用户此前签发的访问令牌与刷新令牌全部失效*\x10RevokeUserTokens\x82\xd3\xe4\x93\x02,:\x01*\"'/v1/system/users/{userID}/revoke-tokens\x12\xbb\x02\n" +
+	"\x15SendVerificationEmail\x12 .v1.SendVerificationEmailRequest\x1a!.v1.SendVerificationEmailResponse\"\xdc\x01\x92A\x9c\x01\n" +
+	"\x13system/用户管理\x12\x18发送邮箱验证邮件\x1aT向当前邮箱发送一次性的验证链接，重新发送后此前的链接失效*\x15SendVerificationEmail\x82\xd3\xe4\x93\x026:\x01*\"1/v1/system/users/{userID}/send-verification-email\x12\x95\x02\n" +
+	"\x18ConfirmEmailVerification\x12#.v1.ConfirmEmailVerificationRequest\x1a$.v1.ConfirmEmailVerificationResponse\"\xad\x01\x92A~\n" +
+	"\x13system/用户管理\x12\x12确认邮箱验证\x1a9提交验证邮件中的令牌，令牌只能使用一次*\x18ConfirmEmailVerification\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/v1/system/auth/confirm-email\x12\xac\x02\n" +
+	"\x0eForgotPassword\x12\x19.v1.ForgotPasswordRequest\x1a\x1a.v1.ForgotPasswordResponse\"\xe2\x01\x92A\xb0\x01\n" +
+	"\x13system/用户管理\x12\f找回密码\x1a{向邮箱发送一次性的重置密码链接。为避免泄露邮箱是否已注册，邮箱不存在时同样返回成功*\x0eForgotPassword\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/v1/system/auth/forgot-password\x12\xa1\x02\n" +
+	"\rResetPassword\x12\x18.v1.ResetPasswordRequest\x1a\x19.v1.ResetPasswordResponse\"\xda\x01\x92A\xa9\x01\n" +
+	"\x13system/用户管理\x12\f重置密码\x1au提交重置密码邮件中的令牌与新密码，令牌只能使用一次；重置后用户的全部令牌被吊销*\rResetPassword\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02#:\x01*\"\x1e/v1/system/auth/reset-password\x12\x8e\x01\n" +
 	"\n" +
-	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"M\x92A/\n" +
+	"CreateUser\x12\x15.v1.CreateUserRequest\x1a\x16.v1.CreateUserResponse\"Q\x92A/\n" +
 	"\x13system/用户管理\x12\f创建用户*\n" +
-	"CreateUser\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/system/users\x12\x99\x01\n" +
+	"CreateUser\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x15:\x01*\"\x10/v1/system/users\x12\x99\x01\n" +
 	"\n" +
 	"UpdateUser\x12\x15.v1.UpdateUserRequest\x1a\x16.v1.UpdateUserResponse\"\\\x92A5\n" +
 	"\x13system/用户管理\x12\x12更新用户信息*\n" +
@@ -92,7 +110,11 @@ const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\aGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"V\x92A2\n" +
 	"\x13system/博客管理\x12\x12获取文章信息*\aGetPost\x82\xd3\xe4\x93\x02\x1b\x12\x19/v1/system/posts/{postID}\x12\x85\x01\n" +
 	"\bListPost\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"N\x92A3\n" +
-	"\x13system/博客管理\x12\x12列出所有文章*\bListPost\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/system/posts\x12\x9f\x01\n" +
+	"\x13system/博客管理\x12\x12列出所有文章*\bListPost\x82\xd3\xe4\x93\x02\x12\x12\x10/v1/system/posts\x12\xed\x01\n" +
+	"\n" +
+	"WatchPosts\x12\x15.v1.WatchPostsRequest\x1a\r.v1.PostEvent\"\xb6\x01\x92A\x94\x01\n" +
+	"\x13system/博客管理\x12\x18订阅文章变更事件\x1aW服务端流式接口，通过 HTTP 访问时以换行分隔的 JSON 持续返回事件*\n" +
+	"WatchPosts\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/system/post-events0\x01\x12\x9f\x01\n" +
 	"\x0eCreateCategory\x12\x19.v1.CreateCategoryRequest\x1a\x1a.v1.CreateCategoryResponse\"V\x92A3\n" +
 	"\x13system/分类管理\x12\f创建分类*\x0eCreateCategory\x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/v1/system/categories\x12\xac\x01\n" +
 	"\x0eUpdateCategory\x12\x19.v1.UpdateCategoryRequest\x1a\x1a.v1.UpdateCategoryResponse\"c\x92A3\n" +
@@ -122,19 +144,19 @@ const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\x13BatchCreatePostTags\x12\x1e.v1.BatchCreatePostTagsRequest\x1a\x1f.v1.BatchCreatePostTagsResponse\"x\x92AP\n" +
 	"\x19system/文章标签管理\x12\x1e批量创建文章标签关联*\x13BatchCreatePostTags\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/v1/system/post-tags/batch\x12\xd0\x01\n" +
 	"\x13BatchDeletePostTags\x12\x1e.v1.BatchDeletePostTagsRequest\x1a\x1f.v1.BatchDeletePostTagsResponse\"x\x92AP\n" +
-	"\x19system/文章标签管理\x12\x1e批量删除文章标签关联*\x13BatchDeletePostTags\x82\xd3\xe4\x93\x02\x1f:\x01**\x1a/v1/system/post-tags/batch\x12\x7f\n" +
-	"\vAppPostList\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"E\x92A-\n" +
-	"\x10app/博客管理\x12\f列出文章*\vAppPostList\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/app/posts\x12\x8a\x01\n" +
+	"\x19system/文章标签管理\x12\x1e批量删除文章标签关联*\x13BatchDeletePostTags\x82\xd3\xe4\x93\x02\x1f:\x01**\x1a/v1/system/post-tags/batch\x12\x83\x01\n" +
+	"\vAppPostList\x12\x13.v1.ListPostRequest\x1a\x14.v1.ListPostResponse\"I\x92A-\n" +
+	"\x10app/博客管理\x12\f列出文章*\vAppPostList\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x0f\x12\r/v1/app/posts\x12\x8e\x01\n" +
 	"\n" +
-	"AppGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"S\x92A2\n" +
+	"AppGetPost\x12\x12.v1.GetPostRequest\x1a\x13.v1.GetPostResponse\"W\x92A2\n" +
 	"\x10app/博客管理\x12\x12获取文章信息*\n" +
-	"AppGetPost\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/app/posts/{postID}\x12\xa5\x01\n" +
-	"\x10BatchAppGetPosts\x12\x18.v1.BatchGetPostsRequest\x1a\x19.v1.BatchGetPostsResponse\"\\\x92A>\n" +
-	"\x10app/博客管理\x12\x18批量获取文章信息*\x10BatchAppGetPosts\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/app/posts/batch\x12\xa0\x01\n" +
-	"\x0eAppGetCategory\x12\x16.v1.GetCategoryRequest\x1a\x17.v1.GetCategoryResponse\"]\x92A3\n" +
-	"\x10app/分类管理\x12\x12获取分类信息*\vGetCategory\x82\xd3\xe4\x93\x02!\x12\x1f/v1/app/categories/{categoryID}\x12\x97\x01\n" +
-	"\x0fAppListCategory\x12\x17.v1.ListCategoryRequest\x1a\x18.v1.ListCategoryResponse\"Q\x92A4\n" +
-	"\x10app/分类管理\x12\x12列出所有分类*\fListCategory\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/app/categoriesB\xbe\x04\x92A\x82\x04\x12\xd8\x03\n" +
+	"AppGetPost\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x18\x12\x16/v1/app/posts/{postID}\x12\xa9\x01\n" +
+	"\x10BatchAppGetPosts\x12\x18.v1.BatchGetPostsRequest\x1a\x19.v1.BatchGetPostsResponse\"`\x92A>\n" +
+	"\x10app/博客管理\x12\x18批量获取文章信息*\x10BatchAppGetPosts\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x15\x12\x13/v1/app/posts/batch\x12\xa4\x01\n" +
+	"\x0eAppGetCategory\x12\x16.v1.GetCategoryRequest\x1a\x17.v1.GetCategoryResponse\"a\x92A3\n" +
+	"\x10app/分类管理\x12\x12获取分类信息*\vGetCategory\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02!\x12\x1f/v1/app/categories/{categoryID}\x12\x9b\x01\n" +
+	"\x0fAppListCategory\x12\x17.v1.ListCategoryRequest\x1a\x18.v1.ListCategoryResponse\"U\x92A4\n" +
+	"\x10app/分类管理\x12\x12列出所有分类*\fListCategory\xc8\xf3\x18\x01\x82\xd3\xe4\x93\x02\x14\x12\x12/v1/app/categoriesB\xbe\x04\x92A\x82\x04\x12\xd8\x03\n" +
 	"\vminiblog v2\x12\x9d\x02MiniBlog 是一个基于 gRPC 的博客系统 API 服务，提供完整的博客管理功能，包括：\n" +
 	"- 用户认证与管理\n" +
 	"- 博客文章管理\n" +
@@ -147,80 +169,100 @@ const file_apiserver_v1_apiserver_proto_rawDesc = "" +
 	"\vMIT License\x12:https://github.com/clin211/miniblog-v2/blob/master/LICENSE2\x031.0*\x01\x022\x10application/json:\x10application/jsonZ6github.com/clin211/miniblog-v2/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var file_apiserver_v1_apiserver_proto_goTypes = []any{
-	(*emptypb.Empty)(nil),               // 0: google.protobuf.Empty
-	(*UploadFileRequest)(nil),           // 1: v1.UploadFileRequest
-	(*InitMultipartRequest)(nil),        // 2: v1.InitMultipartRequest
-	(*PresignPartsRequest)(nil),         // 3: v1.PresignPartsRequest
-	(*UploadPartRequest)(nil),           // 4: v1.UploadPartRequest
-	(*ListPartsRequest)(nil),            // 5: v1.ListPartsRequest
-	(*CompleteMultipartRequest)(nil),    // 6: v1.CompleteMultipartRequest
-	(*AbortMultipartRequest)(nil),       // 7: v1.AbortMultipartRequest
-	(*LoginRequest)(nil),                // 8: v1.LoginRequest
-	(*RefreshTokenRequest)(nil),         // 9: v1.RefreshTokenRequest
-	(*ChangePasswordRequest)(nil),       // 10: v1.ChangePasswordRequest
-	(*CreateUserRequest)(nil),           // 11: v1.CreateUserRequest
-	(*UpdateUserRequest)(nil),           // 12: v1.UpdateUserRequest
-	(*DeleteUserRequest)(nil),           // 13: v1.DeleteUserRequest
-	(*GetUserRequest)(nil),              // 14: v1.GetUserRequest
-	(*ListUserRequest)(nil),             // 15: v1.ListUserRequest
-	(*CreatePostRequest)(nil),           // 16: v1.CreatePostRequest
-	(*UpdatePostRequest)(nil),           // 17: v1.UpdatePostRequest
-	(*DeletePostRequest)(nil),           // 18: v1.DeletePostRequest
-	(*GetPostRequest)(nil),              // 19: v1.GetPostRequest
-	(*ListPostRequest)(nil),             // 20: v1.ListPostRequest
-	(*CreateCategoryRequest)(nil),       // 21: v1.CreateCategoryRequest
-	(*UpdateCategoryRequest)(nil),       // 22: v1.UpdateCategoryRequest
-	(*DeleteCategoryRequest)(nil),       // 23: v1.DeleteCategoryRequest
-	(*GetCategoryRequest)(nil),          // 24: v1.GetCategoryRequest
-	(*ListCategoryRequest)(nil),         // 25: v1.ListCategoryRequest
-	(*CreateTagRequest)(nil),            // 26: v1.CreateTagRequest
-	(*UpdateTagRequest)(nil),            // 27: v1.UpdateTagRequest
-	(*DeleteTagRequest)(nil),            // 28: v1.DeleteTagRequest
-	(*GetTagRequest)(nil),               // 29: v1.GetTagRequest
-	(*ListTagRequest)(nil),              // 30: v1.ListTagRequest
-	(*CreatePostTagRequest)(nil),        // 31: v1.CreatePostTagRequest
-	(*DeletePostTagRequest)(nil),        // 32: v1.DeletePostTagRequest
-	(*ListPostTagsRequest)(nil),         // 33: v1.ListPostTagsRequest
-	(*BatchCreatePostTagsRequest)(nil),  // 34: v1.BatchCreatePostTagsRequest
-	(*BatchDeletePostTagsRequest)(nil),  // 35: v1.BatchDeletePostTagsRequest
-	(*BatchGetPostsRequest)(nil),        // 36: v1.BatchGetPostsRequest
-	(*HealthzResponse)(nil),             // 37: v1.HealthzResponse
-	(*UploadedObject)(nil),              // 38: v1.UploadedObject
-	(*InitMultipartResponse)(nil),       // 39: v1.InitMultipartResponse
-	(*PresignPartsResponse)(nil),        // 40: v1.PresignPartsResponse
-	(*UploadPartResponse)(nil),          // 41: v1.UploadPartResponse
-	(*ListPartsResponse)(nil),           // 42: v1.ListPartsResponse
-	(*CompleteMultipartResponse)(nil),   // 43: v1.CompleteMultipartResponse
-	(*AbortMultipartResponse)(nil),      // 44: v1.AbortMultipartResponse
-	(*LoginResponse)(nil),               // 45: v1.LoginResponse
-	(*RefreshTokenResponse)(nil),        // 46: v1.RefreshTokenResponse
-	(*ChangePasswordResponse)(nil),      // 47: v1.ChangePasswordResponse
-	(*CreateUserResponse)(nil),          // 48: v1.CreateUserResponse
-	(*UpdateUserResponse)(nil),          // 49: v1.UpdateUserResponse
-	(*DeleteUserResponse)(nil),          // 50: v1.DeleteUserResponse
-	(*GetUserResponse)(nil),             // 51: v1.GetUserResponse
-	(*ListUserResponse)(nil),            // 52: v1.ListUserResponse
-	(*CreatePostResponse)(nil),          // 53: v1.CreatePostResponse
-	(*UpdatePostResponse)(nil),          // 54: v1.UpdatePostResponse
-	(*DeletePostResponse)(nil),          // 55: v1.DeletePostResponse
-	(*GetPostResponse)(nil),             // 56: v1.GetPostResponse
-	(*ListPostResponse)(nil),            // 57: v1.ListPostResponse
-	(*CreateCategoryResponse)(nil),      // 58: v1.CreateCategoryResponse
-	(*UpdateCategoryResponse)(nil),      // 59: v1.UpdateCategoryResponse
-	(*DeleteCategoryResponse)(nil),      // 60: v1.DeleteCategoryResponse
-	(*GetCategoryResponse)(nil),         // 61: v1.GetCategoryResponse
-	(*ListCategoryResponse)(nil),        // 62: v1.ListCategoryResponse
-	(*CreateTagResponse)(nil),           // 63: v1.CreateTagResponse
-	(*UpdateTagResponse)(nil),           // 64: v1.UpdateTagResponse
-	(*DeleteTagResponse)(nil),           // 65: v1.DeleteTagResponse
-	(*GetTagResponse)(nil),              // 66: v1.GetTagResponse
-	(*ListTagResponse)(nil),             // 67: v1.ListTagResponse
-	(*CreatePostTagResponse)(nil),       // 68: v1.CreatePostTagResponse
-	(*DeletePostTagResponse)(nil),       // 69: v1.DeletePostTagResponse
-	(*ListPostTagsResponse)(nil),        // 70: v1.ListPostTagsResponse
-	(*BatchCreatePostTagsResponse)(nil), // 71: v1.BatchCreatePostTagsResponse
-	(*BatchDeletePostTagsResponse)(nil), // 72: v1.BatchDeletePostTagsResponse
-	(*BatchGetPostsResponse)(nil),       // 73: v1.BatchGetPostsResponse
+	(*emptypb.Empty)(nil),                    // 0: google.protobuf.Empty
+	(*UploadFileRequest)(nil),                // 1: v1.UploadFileRequest
+	(*InitMultipartRequest)(nil),             // 2: v1.InitMultipartRequest
+	(*PresignPartsRequest)(nil),              // 3: v1.PresignPartsRequest
+	(*UploadPartRequest)(nil),                // 4: v1.UploadPartRequest
+	(*ListPartsRequest)(nil),                 // 5: v1.ListPartsRequest
+	(*CompleteMultipartRequest)(nil),         // 6: v1.CompleteMultipartRequest
+	(*AbortMultipartRequest)(nil),            // 7: v1.AbortMultipartRequest
+	(*ListMediaRequest)(nil),                 // 8: v1.ListMediaRequest
+	(*GetMediaRequest)(nil),                  // 9: v1.GetMediaRequest
+	(*DeleteMediaRequest)(nil),               // 10: v1.DeleteMediaRequest
+	(*LoginRequest)(nil),                     // 11: v1.LoginRequest
+	(*RefreshTokenRequest)(nil),              // 12: v1.RefreshTokenRequest
+	(*LogoutRequest)(nil),                    // 13: v1.LogoutRequest
+	(*ChangePasswordRequest)(nil),            // 14: v1.ChangePasswordRequest
+	(*RevokeUserTokensRequest)(nil),          // 15: v1.RevokeUserTokensRequest
+	(*SendVerificationEmailRequest)(nil),     // 16: v1.SendVerificationEmailRequest
+	(*ConfirmEmailVerificationRequest)(nil),  // 17: v1.ConfirmEmailVerificationRequest
+	(*ForgotPasswordRequest)(nil),            // 18: v1.ForgotPasswordRequest
+	(*ResetPasswordRequest)(nil),             // 19: v1.ResetPasswordRequest
+	(*CreateUserRequest)(nil),                // 20: v1.CreateUserRequest
+	(*UpdateUserRequest)(nil),                // 21: v1.UpdateUserRequest
+	(*DeleteUserRequest)(nil),                // 22: v1.DeleteUserRequest
+	(*GetUserRequest)(nil),                   // 23: v1.GetUserRequest
+	(*ListUserRequest)(nil),                  // 24: v1.ListUserRequest
+	(*CreatePostRequest)(nil),                // 25: v1.CreatePostRequest
+	(*UpdatePostRequest)(nil),                // 26: v1.UpdatePostRequest
+	(*DeletePostRequest)(nil),                // 27: v1.DeletePostRequest
+	(*GetPostRequest)(nil),                   // 28: v1.GetPostRequest
+	(*ListPostRequest)(nil),                  // 29: v1.ListPostRequest
+	(*WatchPostsRequest)(nil),                // 30: v1.WatchPostsRequest
+	(*CreateCategoryRequest)(nil),            // 31: v1.CreateCategoryRequest
+	(*UpdateCategoryRequest)(nil),            // 32: v1.UpdateCategoryRequest
+	(*DeleteCategoryRequest)(nil),            // 33: v1.DeleteCategoryRequest
+	(*GetCategoryRequest)(nil),               // 34: v1.GetCategoryRequest
+	(*ListCategoryRequest)(nil),              // 35: v1.ListCategoryRequest
+	(*CreateTagRequest)(nil),                 // 36: v1.CreateTagRequest
+	(*UpdateTagRequest)(nil),                 // 37: v1.UpdateTagRequest
+	(*DeleteTagRequest)(nil),                 // 38: v1.DeleteTagRequest
+	(*GetTagRequest)(nil),                    // 39: v1.GetTagRequest
+	(*ListTagRequest)(nil),                   // 40: v1.ListTagRequest
+	(*CreatePostTagRequest)(nil),             // 41: v1.CreatePostTagRequest
+	(*DeletePostTagRequest)(nil),             // 42: v1.DeletePostTagRequest
+	(*ListPostTagsRequest)(nil),              // 43: v1.ListPostTagsRequest
+	(*BatchCreatePostTagsRequest)(nil),       // 44: v1.BatchCreatePostTagsRequest
+	(*BatchDeletePostTagsRequest)(nil),       // 45: v1.BatchDeletePostTagsRequest
+	(*BatchGetPostsRequest)(nil),             // 46: v1.BatchGetPostsRequest
+	(*HealthzResponse)(nil),                  // 47: v1.HealthzResponse
+	(*UploadedObject)(nil),                   // 48: v1.UploadedObject
+	(*InitMultipartResponse)(nil),            // 49: v1.InitMultipartResponse
+	(*PresignPartsResponse)(nil),             // 50: v1.PresignPartsResponse
+	(*UploadPartResponse)(nil),               // 51: v1.UploadPartResponse
+	(*ListPartsResponse)(nil),                // 52: v1.ListPartsResponse
+	(*CompleteMultipartResponse)(nil),        // 53: v1.CompleteMultipartResponse
+	(*AbortMultipartResponse)(nil),           // 54: v1.AbortMultipartResponse
+	(*ListMediaResponse)(nil),                // 55: v1.ListMediaResponse
+	(*GetMediaResponse)(nil),                 // 56: v1.GetMediaResponse
+	(*DeleteMediaResponse)(nil),              // 57: v1.DeleteMediaResponse
+	(*LoginResponse)(nil),                    // 58: v1.LoginResponse
+	(*RefreshTokenResponse)(nil),             // 59: v1.RefreshTokenResponse
+	(*LogoutResponse)(nil),                   // 60: v1.LogoutResponse
+	(*ChangePasswordResponse)(nil),           // 61: v1.ChangePasswordResponse
+	(*RevokeUserTokensResponse)(nil),         // 62: v1.RevokeUserTokensResponse
+	(*SendVerificationEmailResponse)(nil),    // 63: v1.SendVerificationEmailResponse
+	(*ConfirmEmailVerificationResponse)(nil), // 64: v1.ConfirmEmailVerificationResponse
+	(*ForgotPasswordResponse)(nil),           // 65: v1.ForgotPasswordResponse
+	(*ResetPasswordResponse)(nil),            // 66: v1.ResetPasswordResponse
+	(*CreateUserResponse)(nil),               // 67: v1.CreateUserResponse
+	(*UpdateUserResponse)(nil),               // 68: v1.UpdateUserResponse
+	(*DeleteUserResponse)(nil),               // 69: v1.DeleteUserResponse
+	(*GetUserResponse)(nil),                  // 70: v1.GetUserResponse
+	(*ListUserResponse)(nil),                 // 71: v1.ListUserResponse
+	(*CreatePostResponse)(nil),               // 72: v1.CreatePostResponse
+	(*UpdatePostResponse)(nil),               // 73: v1.UpdatePostResponse
+	(*DeletePostResponse)(nil),               // 74: v1.DeletePostResponse
+	(*GetPostResponse)(nil),                  // 75: v1.GetPostResponse
+	(*ListPostResponse)(nil),                 // 76: v1.ListPostResponse
+	(*PostEvent)(nil),                        // 77: v1.PostEvent
+	(*CreateCategoryResponse)(nil),           // 78: v1.CreateCategoryResponse
+	(*UpdateCategoryResponse)(nil),           // 79: v1.UpdateCategoryResponse
+	(*DeleteCategoryResponse)(nil),           // 80: v1.DeleteCategoryResponse
+	(*GetCategoryResponse)(nil),              // 81: v1.GetCategoryResponse
+	(*ListCategoryResponse)(nil),             // 82: v1.ListCategoryResponse
+	(*CreateTagResponse)(nil),                // 83: v1.CreateTagResponse
+	(*UpdateTagResponse)(nil),                // 84: v1.UpdateTagResponse
+	(*DeleteTagResponse)(nil),                // 85: v1.DeleteTagResponse
+	(*GetTagResponse)(nil),                   // 86: v1.GetTagResponse
+	(*ListTagResponse)(nil),                  // 87: v1.ListTagResponse
+	(*CreatePostTagResponse)(nil),            // 88: v1.CreatePostTagResponse
+	(*DeletePostTagResponse)(nil),            // 89: v1.DeletePostTagResponse
+	(*ListPostTagsResponse)(nil),             // 90: v1.ListPostTagsResponse
+	(*BatchCreatePostTagsResponse)(nil),      // 91: v1.BatchCreatePostTagsResponse
+	(*BatchDeletePostTagsResponse)(nil),      // 92: v1.BatchDeletePostTagsResponse
+	(*BatchGetPostsResponse)(nil),            // 93: v1.BatchGetPostsResponse
 }
 var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	0,  // 0: v1.MiniBlog.Healthz:input_type -> google.protobuf.Empty
@@ -231,82 +273,102 @@ var file_apiserver_v1_apiserver_proto_depIdxs = []int32{
 	5,  // 5: v1.MiniBlog.ListParts:input_type -> v1.ListPartsRequest
 	6,  // 6: v1.MiniBlog.CompleteMultipart:input_type -> v1.CompleteMultipartRequest
 	7,  // 7: v1.MiniBlog.AbortMultipart:input_type -> v1.AbortMultipartRequest
-	8,  // 8: v1.MiniBlog.Login:input_type -> v1.LoginRequest
-	9,  // 9: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
-	10, // 10: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
-	11, // 11: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
-	12, // 12: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
-	13, // 13: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
-	14, // 14: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
-	15, // 15: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
-	16, // 16: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
-	17, // 17: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
-	18, // 18: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
-	19, // 19: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
-	20, // 20: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
-	21, // 21: v1.MiniBlog.CreateCategory:input_type -> v1.CreateCategoryRequest
-	22, // 22: v1.MiniBlog.UpdateCategory:input_type -> v1.UpdateCategoryRequest
-	23, // 23: v1.MiniBlog.DeleteCategory:input_type -> v1.DeleteCategoryRequest
-	24, // 24: v1.MiniBlog.GetCategory:input_type -> v1.GetCategoryRequest
-	25, // 25: v1.MiniBlog.ListCategory:input_type -> v1.ListCategoryRequest
-	26, // 26: v1.MiniBlog.CreateTag:input_type -> v1.CreateTagRequest
-	27, // 27: v1.MiniBlog.UpdateTag:input_type -> v1.UpdateTagRequest
-	28, // 28: v1.MiniBlog.DeleteTag:input_type -> v1.DeleteTagRequest
-	29, // 29: v1.MiniBlog.GetTag:input_type -> v1.GetTagRequest
-	30, // 30: v1.MiniBlog.ListTag:input_type -> v1.ListTagRequest
-	31, // 31: v1.MiniBlog.CreatePostTag:input_type -> v1.CreatePostTagRequest
-	32, // 32: v1.MiniBlog.DeletePostTag:input_type -> v1.DeletePostTagRequest
-	33, // 33: v1.MiniBlog.ListPostTags:input_type -> v1.ListPostTagsRequest
-	34, // 34: v1.MiniBlog.BatchCreatePostTags:input_type -> v1.BatchCreatePostTagsRequest
-	35, // 35: v1.MiniBlog.BatchDeletePostTags:input_type -> v1.BatchDeletePostTagsRequest
-	20, // 36: v1.MiniBlog.AppPostList:input_type -> v1.ListPostRequest
-	19, // 37: v1.MiniBlog.AppGetPost:input_type -> v1.GetPostRequest
-	36, // 38: v1.MiniBlog.BatchAppGetPosts:input_type -> v1.BatchGetPostsRequest
-	24, // 39: v1.MiniBlog.AppGetCategory:input_type -> v1.GetCategoryRequest
-	25, // 40: v1.MiniBlog.AppListCategory:input_type -> v1.ListCategoryRequest
-	37, // 41: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
-	38, // 42: v1.MiniBlog.UploadFile:output_type -> v1.UploadedObject
-	39, // 43: v1.MiniBlog.InitMultipart:output_type -> v1.InitMultipartResponse
-	40, // 44: v1.MiniBlog.PresignParts:output_type -> v1.PresignPartsResponse
-	41, // 45: v1.MiniBlog.UploadPart:output_type -> v1.UploadPartResponse
-	42, // 46: v1.MiniBlog.ListParts:output_type -> v1.ListPartsResponse
-	43, // 47: v1.MiniBlog.CompleteMultipart:output_type -> v1.CompleteMultipartResponse
-	44, // 48: v1.MiniBlog.AbortMultipart:output_type -> v1.AbortMultipartResponse
-	45, // 49: v1.MiniBlog.Login:output_type -> v1.LoginResponse
-	46, // 50: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
-	47, // 51: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
-	48, // 52: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
-	49, // 53: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
-	50, // 54: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
-	51, // 55: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
-	52, // 56: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
-	53, // 57: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
-	54, // 58: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
-	55, // 59: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
-	56, // 60: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
-	57, // 61: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
-	58, // 62: v1.MiniBlog.CreateCategory:output_type -> v1.CreateCategoryResponse
-	59, // 63: v1.MiniBlog.UpdateCategory:output_type -> v1.UpdateCategoryResponse
-	60, // 64: v1.MiniBlog.DeleteCategory:output_type -> v1.DeleteCategoryResponse
-	61, // 65: v1.MiniBlog.GetCategory:output_type -> v1.GetCategoryResponse
-	62, // 66: v1.MiniBlog.ListCategory:output_type -> v1.ListCategoryResponse
-	63, // 67: v1.MiniBlog.CreateTag:output_type -> v1.CreateTagResponse
-	64, // 68: v1.MiniBlog.UpdateTag:output_type -> v1.UpdateTagResponse
-	65, // 69: v1.MiniBlog.DeleteTag:output_type -> v1.DeleteTagResponse
-	66, // 70: v1.MiniBlog.GetTag:output_type -> v1.GetTagResponse
-	67, // 71: v1.MiniBlog.ListTag:output_type -> v1.ListTagResponse
-	68, // 72: v1.MiniBlog.CreatePostTag:output_type -> v1.CreatePostTagResponse
-	69, // 73: v1.MiniBlog.DeletePostTag:output_type -> v1.DeletePostTagResponse
-	70, // 74: v1.MiniBlog.ListPostTags:output_type -> v1.ListPostTagsResponse
-	71, // 75: v1.MiniBlog.BatchCreatePostTags:output_type -> v1.BatchCreatePostTagsResponse
-	72, // 76: v1.MiniBlog.BatchDeletePostTags:output_type -> v1.BatchDeletePostTagsResponse
-	57, // 77: v1.MiniBlog.AppPostList:output_type -> v1.ListPostResponse
-	56, // 78: v1.MiniBlog.AppGetPost:output_type -> v1.GetPostResponse
-	73, // 79: v1.MiniBlog.BatchAppGetPosts:output_type -> v1.BatchGetPostsResponse
-	61, // 80: v1.MiniBlog.AppGetCategory:output_type -> v1.GetCategoryResponse
-	62, // 81: v1.MiniBlog.AppListCategory:output_type -> v1.ListCategoryResponse
-	41, // [41:82] is the sub-list for method output_type
-	0,  // [0:41] is the sub-list for method input_type
+	8,  // 8: v1.MiniBlog.ListMedia:input_type -> v1.ListMediaRequest
+	9,  // 9: v1.MiniBlog.GetMedia:input_type -> v1.GetMediaRequest
+	10, // 10: v1.MiniBlog.DeleteMedia:input_type -> v1.DeleteMediaRequest
+	11, // 11: v1.MiniBlog.Login:input_type -> v1.LoginRequest
+	12, // 12: v1.MiniBlog.RefreshToken:input_type -> v1.RefreshTokenRequest
+	13, // 13: v1.MiniBlog.Logout:input_type -> v1.LogoutRequest
+	14, // 14: v1.MiniBlog.ChangePassword:input_type -> v1.ChangePasswordRequest
+	15, // 15: v1.MiniBlog.RevokeUserTokens:input_type -> v1.RevokeUserTokensRequest
+	16, // 16: v1.MiniBlog.SendVerificationEmail:input_type -> v1.SendVerificationEmailRequest
+	17, // 17: v1.MiniBlog.ConfirmEmailVerification:input_type -> v1.ConfirmEmailVerificationRequest
+	18, // 18: v1.MiniBlog.ForgotPassword:input_type -> v1.ForgotPasswordRequest
+	19, // 19: v1.MiniBlog.ResetPassword:input_type -> v1.ResetPasswordRequest
+	20, // 20: v1.MiniBlog.CreateUser:input_type -> v1.CreateUserRequest
+	21, // 21: v1.MiniBlog.UpdateUser:input_type -> v1.UpdateUserRequest
+	22, // 22: v1.MiniBlog.DeleteUser:input_type -> v1.DeleteUserRequest
+	23, // 23: v1.MiniBlog.GetUser:input_type -> v1.GetUserRequest
+	24, // 24: v1.MiniBlog.ListUser:input_type -> v1.ListUserRequest
+	25, // 25: v1.MiniBlog.CreatePost:input_type -> v1.CreatePostRequest
+	26, // 26: v1.MiniBlog.UpdatePost:input_type -> v1.UpdatePostRequest
+	27, // 27: v1.MiniBlog.DeletePost:input_type -> v1.DeletePostRequest
+	28, // 28: v1.MiniBlog.GetPost:input_type -> v1.GetPostRequest
+	29, // 29: v1.MiniBlog.ListPost:input_type -> v1.ListPostRequest
+	30, // 30: v1.MiniBlog.WatchPosts:input_type -> v1.WatchPostsRequest
+	31, // 31: v1.MiniBlog.CreateCategory:input_type -> v1.CreateCategoryRequest
+	32, // 32: v1.MiniBlog.UpdateCategory:input_type -> v1.UpdateCategoryRequest
+	33, // 33: v1.MiniBlog.DeleteCategory:input_type -> v1.DeleteCategoryRequest
+	34, // 34: v1.MiniBlog.GetCategory:input_type -> v1.GetCategoryRequest
+	35, // 35: v1.MiniBlog.ListCategory:input_type -> v1.ListCategoryRequest
+	36, // 36: v1.MiniBlog.CreateTag:input_type -> v1.CreateTagRequest
+	37, // 37: v1.MiniBlog.UpdateTag:input_type -> v1.UpdateTagRequest
+	38, // 38: v1.MiniBlog.DeleteTag:input_type -> v1.DeleteTagRequest
+	39, // 39: v1.MiniBlog.GetTag:input_type -> v1.GetTagRequest
+	40, // 40: v1.MiniBlog.ListTag:input_type -> v1.ListTagRequest
+	41, // 41: v1.MiniBlog.CreatePostTag:input_type -> v1.CreatePostTagRequest
+	42, // 42: v1.MiniBlog.DeletePostTag:input_type -> v1.DeletePostTagRequest
+	43, // 43: v1.MiniBlog.ListPostTags:input_type -> v1.ListPostTagsRequest
+	44, // 44: v1.MiniBlog.BatchCreatePostTags:input_type -> v1.BatchCreatePostTagsRequest
+	45, // 45: v1.MiniBlog.BatchDeletePostTags:input_type -> v1.BatchDeletePostTagsRequest
+	29, // 46: v1.MiniBlog.AppPostList:input_type -> v1.ListPostRequest
+	28, // 47: v1.MiniBlog.AppGetPost:input_type -> v1.GetPostRequest
+	46, // 48: v1.MiniBlog.BatchAppGetPosts:input_type -> v1.BatchGetPostsRequest
+	34, // 49: v1.MiniBlog.AppGetCategory:input_type -> v1.GetCategoryRequest
+	35, // 50: v1.MiniBlog.AppListCategory:input_type -> v1.ListCategoryRequest
+	47, // 51: v1.MiniBlog.Healthz:output_type -> v1.HealthzResponse
+	48, // 52: v1.MiniBlog.UploadFile:output_type -> v1.UploadedObject
+	49, // 53: v1.MiniBlog.InitMultipart:output_type -> v1.InitMultipartResponse
+	50, // 54: v1.MiniBlog.PresignParts:output_type -> v1.PresignPartsResponse
+	51, // 55: v1.MiniBlog.UploadPart:output_type -> v1.UploadPartResponse
+	52, // 56: v1.MiniBlog.ListParts:output_type -> v1.ListPartsResponse
+	53, // 57: v1.MiniBlog.CompleteMultipart:output_type -> v1.CompleteMultipartResponse
+	54, // 58: v1.MiniBlog.AbortMultipart:output_type -> v1.AbortMultipartResponse
+	55, // 59: v1.MiniBlog.ListMedia:output_type -> v1.ListMediaResponse
+	56, // 60: v1.MiniBlog.GetMedia:output_type -> v1.GetMediaResponse
+	57, // 61: v1.MiniBlog.DeleteMedia:output_type -> v1.DeleteMediaResponse
+	58, // 62: v1.MiniBlog.Login:output_type -> v1.LoginResponse
+	59, // 63: v1.MiniBlog.RefreshToken:output_type -> v1.RefreshTokenResponse
+	60, // 64: v1.MiniBlog.Logout:output_type -> v1.LogoutResponse
+	61, // 65: v1.MiniBlog.ChangePassword:output_type -> v1.ChangePasswordResponse
+	62, // 66: v1.MiniBlog.RevokeUserTokens:output_type -> v1.RevokeUserTokensResponse
+	63, // 67: v1.MiniBlog.SendVerificationEmail:output_type -> v1.SendVerificationEmailResponse
+	64, // 68: v1.MiniBlog.ConfirmEmailVerification:output_type -> v1.ConfirmEmailVerificationResponse
+	65, // 69: v1.MiniBlog.ForgotPassword:output_type -> v1.ForgotPasswordResponse
+	66, // 70: v1.MiniBlog.ResetPassword:output_type -> v1.ResetPasswordResponse
+	67, // 71: v1.MiniBlog.CreateUser:output_type -> v1.CreateUserResponse
+	68, // 72: v1.MiniBlog.UpdateUser:output_type -> v1.UpdateUserResponse
+	69, // 73: v1.MiniBlog.DeleteUser:output_type -> v1.DeleteUserResponse
+	70, // 74: v1.MiniBlog.GetUser:output_type -> v1.GetUserResponse
+	71, // 75: v1.MiniBlog.ListUser:output_type -> v1.ListUserResponse
+	72, // 76: v1.MiniBlog.CreatePost:output_type -> v1.CreatePostResponse
+	73, // 77: v1.MiniBlog.UpdatePost:output_type -> v1.UpdatePostResponse
+	74, // 78: v1.MiniBlog.DeletePost:output_type -> v1.DeletePostResponse
+	75, // 79: v1.MiniBlog.GetPost:output_type -> v1.GetPostResponse
+	76, // 80: v1.MiniBlog.ListPost:output_type -> v1.ListPostResponse
+	77, // 81: v1.MiniBlog.WatchPosts:output_type -> v1.PostEvent
+	78, // 82: v1.MiniBlog.CreateCategory:output_type -> v1.CreateCategoryResponse
+	79, // 83: v1.MiniBlog.UpdateCategory:output_type -> v1.UpdateCategoryResponse
+	80, // 84: v1.MiniBlog.DeleteCategory:output_type -> v1.DeleteCategoryResponse
+	81, // 85: v1.MiniBlog.GetCategory:output_type -> v1.GetCategoryResponse
+	82, // 86: v1.MiniBlog.ListCategory:output_type -> v1.ListCategoryResponse
+	83, // 87: v1.MiniBlog.CreateTag:output_type -> v1.CreateTagResponse
+	84, // 88: v1.MiniBlog.UpdateTag:output_type -> v1.UpdateTagResponse
+	85, // 89: v1.MiniBlog.DeleteTag:output_type -> v1.DeleteTagResponse
+	86, // 90: v1.MiniBlog.GetTag:output_type -> v1.GetTagResponse
+	87, // 91: v1.MiniBlog.ListTag:output_type -> v1.ListTagResponse
+	88, // 92: v1.MiniBlog.CreatePostTag:output_type -> v1.CreatePostTagResponse
+	89, // 93: v1.MiniBlog.DeletePostTag:output_type -> v1.DeletePostTagResponse
+	90, // 94: v1.MiniBlog.ListPostTags:output_type -> v1.ListPostTagsResponse
+	91, // 95: v1.MiniBlog.BatchCreatePostTags:output_type -> v1.BatchCreatePostTagsResponse
+	92, // 96: v1.MiniBlog.BatchDeletePostTags:output_type -> v1.BatchDeletePostTagsResponse
+	76, // 97: v1.MiniBlog.AppPostList:output_type -> v1.ListPostResponse
+	75, // 98: v1.MiniBlog.AppGetPost:output_type -> v1.GetPostResponse
+	93, // 99: v1.MiniBlog.BatchAppGetPosts:output_type -> v1.BatchGetPostsResponse
+	81, // 100: v1.MiniBlog.AppGetCategory:output_type -> v1.GetCategoryResponse
+	82, // 101: v1.MiniBlog.AppListCategory:output_type -> v1.ListCategoryResponse
+	51, // [51:102] is the sub-list for method output_type
+	0,  // [0:51] is the sub-list for method input_type
 	0,  // [0:0] is the sub-list for extension type_name
 	0,  // [0:0] is the sub-list for extension extendee
 	0,  // [0:0] is the sub-list for field type_name
@@ -317,6 +379,7 @@ func file_apiserver_v1_apiserver_proto_init() {
 	if File_apiserver_v1_apiserver_proto != nil {
 		return
 	}
+	file_apiserver_v1_access_proto_init()
 	file_apiserver_v1_healthz_proto_init()
 	file_apiserver_v1_post_proto_init()
 	file_apiserver_v1_user_proto_init()
@@ -324,6 +387,7 @@ func file_apiserver_v1_apiserver_proto_init() {
 	file_apiserver_v1_tag_proto_init()
 	file_apiserver_v1_post_tag_proto_init()
 	file_apiserver_v1_upload_file_proto_init()
+	file_apiserver_v1_media_proto_init()
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
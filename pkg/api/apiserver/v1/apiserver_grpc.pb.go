@@ -25,47 +25,57 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MiniBlog_Healthz_FullMethodName             = "/v1.MiniBlog/Healthz"
-	MiniBlog_UploadFile_FullMethodName          = "/v1.MiniBlog/UploadFile"
-	MiniBlog_InitMultipart_FullMethodName       = "/v1.MiniBlog/InitMultipart"
-	MiniBlog_PresignParts_FullMethodName        = "/v1.MiniBlog/PresignParts"
-	MiniBlog_UploadPart_FullMethodName          = "/v1.MiniBlog/UploadPart"
-	MiniBlog_ListParts_FullMethodName           = "/v1.MiniBlog/ListParts"
-	MiniBlog_CompleteMultipart_FullMethodName   = "/v1.MiniBlog/CompleteMultipart"
-	MiniBlog_AbortMultipart_FullMethodName      = "/v1.MiniBlog/AbortMultipart"
-	MiniBlog_Login_FullMethodName               = "/v1.MiniBlog/Login"
-	MiniBlog_RefreshToken_FullMethodName        = "/v1.MiniBlog/RefreshToken"
-	MiniBlog_ChangePassword_FullMethodName      = "/v1.MiniBlog/ChangePassword"
-	MiniBlog_CreateUser_FullMethodName          = "/v1.MiniBlog/CreateUser"
-	MiniBlog_UpdateUser_FullMethodName          = "/v1.MiniBlog/UpdateUser"
-	MiniBlog_DeleteUser_FullMethodName          = "/v1.MiniBlog/DeleteUser"
-	MiniBlog_GetUser_FullMethodName             = "/v1.MiniBlog/GetUser"
-	MiniBlog_ListUser_FullMethodName            = "/v1.MiniBlog/ListUser"
-	MiniBlog_CreatePost_FullMethodName          = "/v1.MiniBlog/CreatePost"
-	MiniBlog_UpdatePost_FullMethodName          = "/v1.MiniBlog/UpdatePost"
-	MiniBlog_DeletePost_FullMethodName          = "/v1.MiniBlog/DeletePost"
-	MiniBlog_GetPost_FullMethodName             = "/v1.MiniBlog/GetPost"
-	MiniBlog_ListPost_FullMethodName            = "/v1.MiniBlog/ListPost"
-	MiniBlog_CreateCategory_FullMethodName      = "/v1.MiniBlog/CreateCategory"
-	MiniBlog_UpdateCategory_FullMethodName      = "/v1.MiniBlog/UpdateCategory"
-	MiniBlog_DeleteCategory_FullMethodName      = "/v1.MiniBlog/DeleteCategory"
-	MiniBlog_GetCategory_FullMethodName         = "/v1.MiniBlog/GetCategory"
-	MiniBlog_ListCategory_FullMethodName        = "/v1.MiniBlog/ListCategory"
-	MiniBlog_CreateTag_FullMethodName           = "/v1.MiniBlog/CreateTag"
-	MiniBlog_UpdateTag_FullMethodName           = "/v1.MiniBlog/UpdateTag"
-	MiniBlog_DeleteTag_FullMethodName           = "/v1.MiniBlog/DeleteTag"
-	MiniBlog_GetTag_FullMethodName              = "/v1.MiniBlog/GetTag"
-	MiniBlog_ListTag_FullMethodName             = "/v1.MiniBlog/ListTag"
-	MiniBlog_CreatePostTag_FullMethodName       = "/v1.MiniBlog/CreatePostTag"
-	MiniBlog_DeletePostTag_FullMethodName       = "/v1.MiniBlog/DeletePostTag"
-	MiniBlog_ListPostTags_FullMethodName        = "/v1.MiniBlog/ListPostTags"
-	MiniBlog_BatchCreatePostTags_FullMethodName = "/v1.MiniBlog/BatchCreatePostTags"
-	MiniBlog_BatchDeletePostTags_FullMethodName = "/v1.MiniBlog/BatchDeletePostTags"
-	MiniBlog_AppPostList_FullMethodName         = "/v1.MiniBlog/AppPostList"
-	MiniBlog_AppGetPost_FullMethodName          = "/v1.MiniBlog/AppGetPost"
-	MiniBlog_BatchAppGetPosts_FullMethodName    = "/v1.MiniBlog/BatchAppGetPosts"
-	MiniBlog_AppGetCategory_FullMethodName      = "/v1.MiniBlog/AppGetCategory"
-	MiniBlog_AppListCategory_FullMethodName     = "/v1.MiniBlog/AppListCategory"
+	MiniBlog_Healthz_FullMethodName                  = "/v1.MiniBlog/Healthz"
+	MiniBlog_UploadFile_FullMethodName               = "/v1.MiniBlog/UploadFile"
+	MiniBlog_InitMultipart_FullMethodName            = "/v1.MiniBlog/InitMultipart"
+	MiniBlog_PresignParts_FullMethodName             = "/v1.MiniBlog/PresignParts"
+	MiniBlog_UploadPart_FullMethodName               = "/v1.MiniBlog/UploadPart"
+	MiniBlog_ListParts_FullMethodName                = "/v1.MiniBlog/ListParts"
+	MiniBlog_CompleteMultipart_FullMethodName        = "/v1.MiniBlog/CompleteMultipart"
+	MiniBlog_AbortMultipart_FullMethodName           = "/v1.MiniBlog/AbortMultipart"
+	MiniBlog_ListMedia_FullMethodName                = "/v1.MiniBlog/ListMedia"
+	MiniBlog_GetMedia_FullMethodName                 = "/v1.MiniBlog/GetMedia"
+	MiniBlog_DeleteMedia_FullMethodName              = "/v1.MiniBlog/DeleteMedia"
+	MiniBlog_Login_FullMethodName                    = "/v1.MiniBlog/Login"
+	MiniBlog_RefreshToken_FullMethodName             = "/v1.MiniBlog/RefreshToken"
+	MiniBlog_Logout_FullMethodName                   = "/v1.MiniBlog/Logout"
+	MiniBlog_ChangePassword_FullMethodName           = "/v1.MiniBlog/ChangePassword"
+	MiniBlog_RevokeUserTokens_FullMethodName         = "/v1.MiniBlog/RevokeUserTokens"
+	MiniBlog_SendVerificationEmail_FullMethodName    = "/v1.MiniBlog/SendVerificationEmail"
+	MiniBlog_ConfirmEmailVerification_FullMethodName = "/v1.MiniBlog/ConfirmEmailVerification"
+	MiniBlog_ForgotPassword_FullMethodName           = "/v1.MiniBlog/ForgotPassword"
+	MiniBlog_ResetPassword_FullMethodName            = "/v1.MiniBlog/ResetPassword"
+	MiniBlog_CreateUser_FullMethodName               = "/v1.MiniBlog/CreateUser"
+	MiniBlog_UpdateUser_FullMethodName               = "/v1.MiniBlog/UpdateUser"
+	MiniBlog_DeleteUser_FullMethodName               = "/v1.MiniBlog/DeleteUser"
+	MiniBlog_GetUser_FullMethodName                  = "/v1.MiniBlog/GetUser"
+	MiniBlog_ListUser_FullMethodName                 = "/v1.MiniBlog/ListUser"
+	MiniBlog_CreatePost_FullMethodName               = "/v1.MiniBlog/CreatePost"
+	MiniBlog_UpdatePost_FullMethodName               = "/v1.MiniBlog/UpdatePost"
+	MiniBlog_DeletePost_FullMethodName               = "/v1.MiniBlog/DeletePost"
+	MiniBlog_GetPost_FullMethodName                  = "/v1.MiniBlog/GetPost"
+	MiniBlog_ListPost_FullMethodName                 = "/v1.MiniBlog/ListPost"
+	MiniBlog_WatchPosts_FullMethodName               = "/v1.MiniBlog/WatchPosts"
+	MiniBlog_CreateCategory_FullMethodName           = "/v1.MiniBlog/CreateCategory"
+	MiniBlog_UpdateCategory_FullMethodName           = "/v1.MiniBlog/UpdateCategory"
+	MiniBlog_DeleteCategory_FullMethodName           = "/v1.MiniBlog/DeleteCategory"
+	MiniBlog_GetCategory_FullMethodName              = "/v1.MiniBlog/GetCategory"
+	MiniBlog_ListCategory_FullMethodName             = "/v1.MiniBlog/ListCategory"
+	MiniBlog_CreateTag_FullMethodName                = "/v1.MiniBlog/CreateTag"
+	MiniBlog_UpdateTag_FullMethodName                = "/v1.MiniBlog/UpdateTag"
+	MiniBlog_DeleteTag_FullMethodName                = "/v1.MiniBlog/DeleteTag"
+	MiniBlog_GetTag_FullMethodName                   = "/v1.MiniBlog/GetTag"
+	MiniBlog_ListTag_FullMethodName                  = "/v1.MiniBlog/ListTag"
+	MiniBlog_CreatePostTag_FullMethodName            = "/v1.MiniBlog/CreatePostTag"
+	MiniBlog_DeletePostTag_FullMethodName            = "/v1.MiniBlog/DeletePostTag"
+	MiniBlog_ListPostTags_FullMethodName             = "/v1.MiniBlog/ListPostTags"
+	MiniBlog_BatchCreatePostTags_FullMethodName      = "/v1.MiniBlog/BatchCreatePostTags"
+	MiniBlog_BatchDeletePostTags_FullMethodName      = "/v1.MiniBlog/BatchDeletePostTags"
+	MiniBlog_AppPostList_FullMethodName              = "/v1.MiniBlog/AppPostList"
+	MiniBlog_AppGetPost_FullMethodName               = "/v1.MiniBlog/AppGetPost"
+	MiniBlog_BatchAppGetPosts_FullMethodName         = "/v1.MiniBlog/BatchAppGetPosts"
+	MiniBlog_AppGetCategory_FullMethodName           = "/v1.MiniBlog/AppGetCategory"
+	MiniBlog_AppListCategory_FullMethodName          = "/v1.MiniBlog/AppListCategory"
 )
 
 // MiniBlogClient is the client API for MiniBlog service.
@@ -91,12 +101,31 @@ type MiniBlogClient interface {
 	CompleteMultipart(ctx context.Context, in *CompleteMultipartRequest, opts ...grpc.CallOption) (*CompleteMultipartResponse, error)
 	// AbortMultipart 放弃分片上传并清理。
 	AbortMultipart(ctx context.Context, in *AbortMultipartRequest, opts ...grpc.CallOption) (*AbortMultipartResponse, error)
+	// --------------------------- 媒体库（system） ---------------------------
+	// ListMedia 列出已上传的文件，普通用户仅能看到自己上传的文件。
+	ListMedia(ctx context.Context, in *ListMediaRequest, opts ...grpc.CallOption) (*ListMediaResponse, error)
+	// GetMedia 获取文件详情及引用该文件的文章与用户。
+	GetMedia(ctx context.Context, in *GetMediaRequest, opts ...grpc.CallOption) (*GetMediaResponse, error)
+	// DeleteMedia 删除文件；文件仍被引用时需指定 force。
+	DeleteMedia(ctx context.Context, in *DeleteMediaRequest, opts ...grpc.CallOption) (*DeleteMediaResponse, error)
 	// Login 用户登录
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
 	// RefreshToken 刷新令牌
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*RefreshTokenResponse, error)
+	// Logout 退出登录
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
 	// ChangePassword 修改密码
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	// RevokeUserTokens 吊销用户的全部令牌
+	RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error)
+	// SendVerificationEmail 发送邮箱验证邮件
+	SendVerificationEmail(ctx context.Context, in *SendVerificationEmailRequest, opts ...grpc.CallOption) (*SendVerificationEmailResponse, error)
+	// ConfirmEmailVerification 确认邮箱验证
+	ConfirmEmailVerification(ctx context.Context, in *ConfirmEmailVerificationRequest, opts ...grpc.CallOption) (*ConfirmEmailVerificationResponse, error)
+	// ForgotPassword 找回密码
+	ForgotPassword(ctx context.Context, in *ForgotPasswordRequest, opts ...grpc.CallOption) (*ForgotPasswordResponse, error)
+	// ResetPassword 重置密码
+	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
 	// CreateUser 创建用户
 	CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error)
 	// UpdateUser 更新用户信息
@@ -117,6 +146,9 @@ type MiniBlogClient interface {
 	GetPost(ctx context.Context, in *GetPostRequest, opts ...grpc.CallOption) (*GetPostResponse, error)
 	// ListPost 列出所有文章
 	ListPost(ctx context.Context, in *ListPostRequest, opts ...grpc.CallOption) (*ListPostResponse, error)
+	// WatchPosts 订阅文章变更事件，服务端持续推送创建、更新、发布与删除事件
+	// 事件经 Redis 在多个副本之间转发；未配置 Redis 时只能收到同一副本上发生的变更
+	WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error)
 	// CreateCategory 创建分类
 	CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error)
 	// UpdateCategory 更新分类
@@ -247,6 +279,36 @@ func (c *miniBlogClient) AbortMultipart(ctx context.Context, in *AbortMultipartR
 	return out, nil
 }
 
+func (c *miniBlogClient) ListMedia(ctx context.Context, in *ListMediaRequest, opts ...grpc.CallOption) (*ListMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMediaResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ListMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) GetMedia(ctx context.Context, in *GetMediaRequest, opts ...grpc.CallOption) (*GetMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMediaResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_GetMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) DeleteMedia(ctx context.Context, in *DeleteMediaRequest, opts ...grpc.CallOption) (*DeleteMediaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteMediaResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_DeleteMedia_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *miniBlogClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LoginResponse)
@@ -267,6 +329,16 @@ func (c *miniBlogClient) RefreshToken(ctx context.Context, in *RefreshTokenReque
 	return out, nil
 }
 
+func (c *miniBlogClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_Logout_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *miniBlogClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ChangePasswordResponse)
@@ -277,6 +349,56 @@ func (c *miniBlogClient) ChangePassword(ctx context.Context, in *ChangePasswordR
 	return out, nil
 }
 
+func (c *miniBlogClient) RevokeUserTokens(ctx context.Context, in *RevokeUserTokensRequest, opts ...grpc.CallOption) (*RevokeUserTokensResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RevokeUserTokensResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_RevokeUserTokens_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) SendVerificationEmail(ctx context.Context, in *SendVerificationEmailRequest, opts ...grpc.CallOption) (*SendVerificationEmailResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SendVerificationEmailResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_SendVerificationEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ConfirmEmailVerification(ctx context.Context, in *ConfirmEmailVerificationRequest, opts ...grpc.CallOption) (*ConfirmEmailVerificationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmEmailVerificationResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ConfirmEmailVerification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ForgotPassword(ctx context.Context, in *ForgotPasswordRequest, opts ...grpc.CallOption) (*ForgotPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForgotPasswordResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ForgotPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *miniBlogClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetPasswordResponse)
+	err := c.cc.Invoke(ctx, MiniBlog_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *miniBlogClient) CreateUser(ctx context.Context, in *CreateUserRequest, opts ...grpc.CallOption) (*CreateUserResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateUserResponse)
@@ -377,6 +499,25 @@ func (c *miniBlogClient) ListPost(ctx context.Context, in *ListPostRequest, opts
 	return out, nil
 }
 
+func (c *miniBlogClient) WatchPosts(ctx context.Context, in *WatchPostsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[PostEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MiniBlog_ServiceDesc.Streams[0], MiniBlog_WatchPosts_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPostsRequest, PostEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MiniBlog_WatchPostsClient = grpc.ServerStreamingClient[PostEvent]
+
 func (c *miniBlogClient) CreateCategory(ctx context.Context, in *CreateCategoryRequest, opts ...grpc.CallOption) (*CreateCategoryResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CreateCategoryResponse)
@@ -600,12 +741,31 @@ type MiniBlogServer interface {
 	CompleteMultipart(context.Context, *CompleteMultipartRequest) (*CompleteMultipartResponse, error)
 	// AbortMultipart 放弃分片上传并清理。
 	AbortMultipart(context.Context, *AbortMultipartRequest) (*AbortMultipartResponse, error)
+	// --------------------------- 媒体库（system） ---------------------------
+	// ListMedia 列出已上传的文件，普通用户仅能看到自己上传的文件。
+	ListMedia(context.Context, *ListMediaRequest) (*ListMediaResponse, error)
+	// GetMedia 获取文件详情及引用该文件的文章与用户。
+	GetMedia(context.Context, *GetMediaRequest) (*GetMediaResponse, error)
+	// DeleteMedia 删除文件；文件仍被引用时需指定 force。
+	DeleteMedia(context.Context, *DeleteMediaRequest) (*DeleteMediaResponse, error)
 	// Login 用户登录
 	Login(context.Context, *LoginRequest) (*LoginResponse, error)
 	// RefreshToken 刷新令牌
 	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// Logout 退出登录
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
 	// ChangePassword 修改密码
 	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	// RevokeUserTokens 吊销用户的全部令牌
+	RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error)
+	// SendVerificationEmail 发送邮箱验证邮件
+	SendVerificationEmail(context.Context, *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error)
+	// ConfirmEmailVerification 确认邮箱验证
+	ConfirmEmailVerification(context.Context, *ConfirmEmailVerificationRequest) (*ConfirmEmailVerificationResponse, error)
+	// ForgotPassword 找回密码
+	ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error)
+	// ResetPassword 重置密码
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
 	// CreateUser 创建用户
 	CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 	// UpdateUser 更新用户信息
@@ -626,6 +786,9 @@ type MiniBlogServer interface {
 	GetPost(context.Context, *GetPostRequest) (*GetPostResponse, error)
 	// ListPost 列出所有文章
 	ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error)
+	// WatchPosts 订阅文章变更事件，服务端持续推送创建、更新、发布与删除事件
+	// 事件经 Redis 在多个副本之间转发；未配置 Redis 时只能收到同一副本上发生的变更
+	WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error
 	// CreateCategory 创建分类
 	CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error)
 	// UpdateCategory 更新分类
@@ -700,15 +863,42 @@ func (UnimplementedMiniBlogServer) CompleteMultipart(context.Context, *CompleteM
 func (UnimplementedMiniBlogServer) AbortMultipart(context.Context, *AbortMultipartRequest) (*AbortMultipartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AbortMultipart not implemented")
 }
+func (UnimplementedMiniBlogServer) ListMedia(context.Context, *ListMediaRequest) (*ListMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMedia not implemented")
+}
+func (UnimplementedMiniBlogServer) GetMedia(context.Context, *GetMediaRequest) (*GetMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMedia not implemented")
+}
+func (UnimplementedMiniBlogServer) DeleteMedia(context.Context, *DeleteMediaRequest) (*DeleteMediaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteMedia not implemented")
+}
 func (UnimplementedMiniBlogServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
 func (UnimplementedMiniBlogServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
 }
+func (UnimplementedMiniBlogServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
 func (UnimplementedMiniBlogServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
 }
+func (UnimplementedMiniBlogServer) RevokeUserTokens(context.Context, *RevokeUserTokensRequest) (*RevokeUserTokensResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeUserTokens not implemented")
+}
+func (UnimplementedMiniBlogServer) SendVerificationEmail(context.Context, *SendVerificationEmailRequest) (*SendVerificationEmailResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendVerificationEmail not implemented")
+}
+func (UnimplementedMiniBlogServer) ConfirmEmailVerification(context.Context, *ConfirmEmailVerificationRequest) (*ConfirmEmailVerificationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmEmailVerification not implemented")
+}
+func (UnimplementedMiniBlogServer) ForgotPassword(context.Context, *ForgotPasswordRequest) (*ForgotPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForgotPassword not implemented")
+}
+func (UnimplementedMiniBlogServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
 func (UnimplementedMiniBlogServer) CreateUser(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateUser not implemented")
 }
@@ -739,6 +929,9 @@ func (UnimplementedMiniBlogServer) GetPost(context.Context, *GetPostRequest) (*G
 func (UnimplementedMiniBlogServer) ListPost(context.Context, *ListPostRequest) (*ListPostResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListPost not implemented")
 }
+func (UnimplementedMiniBlogServer) WatchPosts(*WatchPostsRequest, grpc.ServerStreamingServer[PostEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPosts not implemented")
+}
 func (UnimplementedMiniBlogServer) CreateCategory(context.Context, *CreateCategoryRequest) (*CreateCategoryResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
 }
@@ -964,6 +1157,60 @@ func _MiniBlog_AbortMultipart_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MiniBlog_ListMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ListMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ListMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ListMedia(ctx, req.(*ListMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_GetMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).GetMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_GetMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).GetMedia(ctx, req.(*GetMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_DeleteMedia_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteMediaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).DeleteMedia(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_DeleteMedia_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).DeleteMedia(ctx, req.(*DeleteMediaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MiniBlog_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LoginRequest)
 	if err := dec(in); err != nil {
@@ -1000,6 +1247,24 @@ func _MiniBlog_RefreshToken_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MiniBlog_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MiniBlog_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ChangePasswordRequest)
 	if err := dec(in); err != nil {
@@ -1018,6 +1283,96 @@ func _MiniBlog_ChangePassword_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MiniBlog_RevokeUserTokens_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeUserTokensRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).RevokeUserTokens(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_RevokeUserTokens_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).RevokeUserTokens(ctx, req.(*RevokeUserTokensRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_SendVerificationEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendVerificationEmailRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).SendVerificationEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_SendVerificationEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).SendVerificationEmail(ctx, req.(*SendVerificationEmailRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ConfirmEmailVerification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmEmailVerificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ConfirmEmailVerification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ConfirmEmailVerification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ConfirmEmailVerification(ctx, req.(*ConfirmEmailVerificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ForgotPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForgotPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ForgotPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ForgotPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ForgotPassword(ctx, req.(*ForgotPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MiniBlog_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MiniBlogServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MiniBlog_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MiniBlogServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MiniBlog_CreateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateUserRequest)
 	if err := dec(in); err != nil {
@@ -1198,6 +1553,17 @@ func _MiniBlog_ListPost_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MiniBlog_WatchPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MiniBlogServer).WatchPosts(m, &grpc.GenericServerStream[WatchPostsRequest, PostEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MiniBlog_WatchPostsServer = grpc.ServerStreamingServer[PostEvent]
+
 func _MiniBlog_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateCategoryRequest)
 	if err := dec(in); err != nil {
@@ -1597,6 +1963,18 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AbortMultipart",
 			Handler:    _MiniBlog_AbortMultipart_Handler,
 		},
+		{
+			MethodName: "ListMedia",
+			Handler:    _MiniBlog_ListMedia_Handler,
+		},
+		{
+			MethodName: "GetMedia",
+			Handler:    _MiniBlog_GetMedia_Handler,
+		},
+		{
+			MethodName: "DeleteMedia",
+			Handler:    _MiniBlog_DeleteMedia_Handler,
+		},
 		{
 			MethodName: "Login",
 			Handler:    _MiniBlog_Login_Handler,
@@ -1605,10 +1983,34 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RefreshToken",
 			Handler:    _MiniBlog_RefreshToken_Handler,
 		},
+		{
+			MethodName: "Logout",
+			Handler:    _MiniBlog_Logout_Handler,
+		},
 		{
 			MethodName: "ChangePassword",
 			Handler:    _MiniBlog_ChangePassword_Handler,
 		},
+		{
+			MethodName: "RevokeUserTokens",
+			Handler:    _MiniBlog_RevokeUserTokens_Handler,
+		},
+		{
+			MethodName: "SendVerificationEmail",
+			Handler:    _MiniBlog_SendVerificationEmail_Handler,
+		},
+		{
+			MethodName: "ConfirmEmailVerification",
+			Handler:    _MiniBlog_ConfirmEmailVerification_Handler,
+		},
+		{
+			MethodName: "ForgotPassword",
+			Handler:    _MiniBlog_ForgotPassword_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _MiniBlog_ResetPassword_Handler,
+		},
 		{
 			MethodName: "CreateUser",
 			Handler:    _MiniBlog_CreateUser_Handler,
@@ -1730,6 +2132,12 @@ var MiniBlog_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _MiniBlog_AppListCategory_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPosts",
+			Handler:       _MiniBlog_WatchPosts_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "apiserver/v1/apiserver.proto",
 }
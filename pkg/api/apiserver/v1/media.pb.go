@@ -0,0 +1,677 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Media API 定义，包含媒体库（已上传文件）的请求和响应消息
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.0
+// source: apiserver/v1/media.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// MediaFile 表示媒体库中的一个已上传文件
+type MediaFile struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fileID 表示文件 ID
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty"`
+	// userID 表示上传者 ID
+	UserID string `protobuf:"bytes,2,opt,name=userID,proto3" json:"userID,omitempty"`
+	// provider 表示存储后端：local | s3 | alioss
+	Provider string `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	// key 表示对象键
+	Key string `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	// url 表示访问 URL
+	Url string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	// size 表示字节数
+	Size int64 `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	// mime 表示 MIME 类型
+	Mime string `protobuf:"bytes,7,opt,name=mime,proto3" json:"mime,omitempty"`
+	// hash 表示内容 sha256
+	Hash string `protobuf:"bytes,8,opt,name=hash,proto3" json:"hash,omitempty"`
+	// scene 表示上传场景
+	Scene string `protobuf:"bytes,9,opt,name=scene,proto3" json:"scene,omitempty"`
+	// filename 表示原始文件名
+	Filename string `protobuf:"bytes,10,opt,name=filename,proto3" json:"filename,omitempty"`
+	// metadata 表示附加元数据，如图片尺寸与衍生图 URL
+	Metadata map[string]string `protobuf:"bytes,11,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// createdAt 表示上传时间（Unix 时间戳）
+	CreatedAt     int64 `protobuf:"varint,12,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaFile) Reset() {
+	*x = MediaFile{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaFile) ProtoMessage() {}
+
+func (x *MediaFile) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaFile.ProtoReflect.Descriptor instead.
+func (*MediaFile) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *MediaFile) GetFileID() string {
+	if x != nil {
+		return x.FileID
+	}
+	return ""
+}
+
+func (x *MediaFile) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *MediaFile) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *MediaFile) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *MediaFile) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *MediaFile) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *MediaFile) GetMime() string {
+	if x != nil {
+		return x.Mime
+	}
+	return ""
+}
+
+func (x *MediaFile) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *MediaFile) GetScene() string {
+	if x != nil {
+		return x.Scene
+	}
+	return ""
+}
+
+func (x *MediaFile) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *MediaFile) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *MediaFile) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+// MediaReference 表示引用了文件的资源
+type MediaReference struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// kind 表示引用位置：post.cover | post.content | user.avatar
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// resourceID 表示引用资源的 ID（postID 或 userID）
+	ResourceID string `protobuf:"bytes,2,opt,name=resourceID,proto3" json:"resourceID,omitempty"`
+	// title 表示引用资源的名称（文章标题或用户名）
+	Title         string `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaReference) Reset() {
+	*x = MediaReference{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaReference) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaReference) ProtoMessage() {}
+
+func (x *MediaReference) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaReference.ProtoReflect.Descriptor instead.
+func (*MediaReference) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *MediaReference) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *MediaReference) GetResourceID() string {
+	if x != nil {
+		return x.ResourceID
+	}
+	return ""
+}
+
+func (x *MediaReference) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+// ListMediaRequest 表示获取媒体文件列表请求
+type ListMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// offset 表示偏移量
+	// @gotags: form:"offset"
+	Offset int64 `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty" form:"offset"`
+	// limit 表示每页数量
+	// @gotags: form:"limit"
+	Limit int64 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty" form:"limit"`
+	// scene 表示按上传场景过滤
+	// @gotags: form:"scene"
+	Scene *string `protobuf:"bytes,3,opt,name=scene,proto3,oneof" json:"scene,omitempty" form:"scene"`
+	// mime 表示按 MIME 类型过滤，支持 image/* 通配
+	// @gotags: form:"mime"
+	Mime          *string `protobuf:"bytes,4,opt,name=mime,proto3,oneof" json:"mime,omitempty" form:"mime"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMediaRequest) Reset() {
+	*x = ListMediaRequest{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMediaRequest) ProtoMessage() {}
+
+func (x *ListMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMediaRequest.ProtoReflect.Descriptor instead.
+func (*ListMediaRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListMediaRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListMediaRequest) GetLimit() int64 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListMediaRequest) GetScene() string {
+	if x != nil && x.Scene != nil {
+		return *x.Scene
+	}
+	return ""
+}
+
+func (x *ListMediaRequest) GetMime() string {
+	if x != nil && x.Mime != nil {
+		return *x.Mime
+	}
+	return ""
+}
+
+// ListMediaResponse 表示获取媒体文件列表响应
+type ListMediaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// total 表示符合条件的总数量
+	Total int64 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	// files 表示文件列表
+	Files         []*MediaFile `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMediaResponse) Reset() {
+	*x = ListMediaResponse{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMediaResponse) ProtoMessage() {}
+
+func (x *ListMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMediaResponse.ProtoReflect.Descriptor instead.
+func (*ListMediaResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListMediaResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *ListMediaResponse) GetFiles() []*MediaFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+// GetMediaRequest 表示获取媒体文件详情请求
+type GetMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fileID 表示文件 ID
+	// @gotags: uri:"fileID"
+	FileID        string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty" uri:"fileID"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMediaRequest) Reset() {
+	*x = GetMediaRequest{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMediaRequest) ProtoMessage() {}
+
+func (x *GetMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMediaRequest.ProtoReflect.Descriptor instead.
+func (*GetMediaRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetMediaRequest) GetFileID() string {
+	if x != nil {
+		return x.FileID
+	}
+	return ""
+}
+
+// GetMediaResponse 表示获取媒体文件详情响应
+type GetMediaResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// file 表示文件信息
+	File *MediaFile `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	// references 表示引用了该文件的文章与用户
+	References    []*MediaReference `protobuf:"bytes,2,rep,name=references,proto3" json:"references,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMediaResponse) Reset() {
+	*x = GetMediaResponse{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMediaResponse) ProtoMessage() {}
+
+func (x *GetMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMediaResponse.ProtoReflect.Descriptor instead.
+func (*GetMediaResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetMediaResponse) GetFile() *MediaFile {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+func (x *GetMediaResponse) GetReferences() []*MediaReference {
+	if x != nil {
+		return x.References
+	}
+	return nil
+}
+
+// DeleteMediaRequest 表示删除媒体文件请求
+type DeleteMediaRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// fileID 表示文件 ID
+	// @gotags: uri:"fileID"
+	FileID string `protobuf:"bytes,1,opt,name=fileID,proto3" json:"fileID,omitempty" uri:"fileID"`
+	// force 为 true 时即使文件仍被引用也删除
+	// @gotags: form:"force"
+	Force         bool `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty" form:"force"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMediaRequest) Reset() {
+	*x = DeleteMediaRequest{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMediaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMediaRequest) ProtoMessage() {}
+
+func (x *DeleteMediaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMediaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteMediaRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DeleteMediaRequest) GetFileID() string {
+	if x != nil {
+		return x.FileID
+	}
+	return ""
+}
+
+func (x *DeleteMediaRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+// DeleteMediaResponse 表示删除媒体文件响应
+type DeleteMediaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteMediaResponse) Reset() {
+	*x = DeleteMediaResponse{}
+	mi := &file_apiserver_v1_media_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteMediaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteMediaResponse) ProtoMessage() {}
+
+func (x *DeleteMediaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_media_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteMediaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteMediaResponse) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_media_proto_rawDescGZIP(), []int{7}
+}
+
+var File_apiserver_v1_media_proto protoreflect.FileDescriptor
+
+const file_apiserver_v1_media_proto_rawDesc = "" +
+	"\n" +
+	"\x18apiserver/v1/media.proto\x12\x02v1\"\xfd\x02\n" +
+	"\tMediaFile\x12\x16\n" +
+	"\x06fileID\x18\x01 \x01(\tR\x06fileID\x12\x16\n" +
+	"\x06userID\x18\x02 \x01(\tR\x06userID\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mime\x18\a \x01(\tR\x04mime\x12\x12\n" +
+	"\x04hash\x18\b \x01(\tR\x04hash\x12\x14\n" +
+	"\x05scene\x18\t \x01(\tR\x05scene\x12\x1a\n" +
+	"\bfilename\x18\n" +
+	" \x01(\tR\bfilename\x127\n" +
+	"\bmetadata\x18\v \x03(\v2\x1b.v1.MediaFile.MetadataEntryR\bmetadata\x12\x1c\n" +
+	"\tcreatedAt\x18\f \x01(\x03R\tcreatedAt\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"Z\n" +
+	"\x0eMediaReference\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x1e\n" +
+	"\n" +
+	"resourceID\x18\x02 \x01(\tR\n" +
+	"resourceID\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\"\x87\x01\n" +
+	"\x10ListMediaRequest\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x03R\x05limit\x12\x19\n" +
+	"\x05scene\x18\x03 \x01(\tH\x00R\x05scene\x88\x01\x01\x12\x17\n" +
+	"\x04mime\x18\x04 \x01(\tH\x01R\x04mime\x88\x01\x01B\b\n" +
+	"\x06_sceneB\a\n" +
+	"\x05_mime\"N\n" +
+	"\x11ListMediaResponse\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x03R\x05total\x12#\n" +
+	"\x05files\x18\x02 \x03(\v2\r.v1.MediaFileR\x05files\")\n" +
+	"\x0fGetMediaRequest\x12\x16\n" +
+	"\x06fileID\x18\x01 \x01(\tR\x06fileID\"i\n" +
+	"\x10GetMediaResponse\x12!\n" +
+	"\x04file\x18\x01 \x01(\v2\r.v1.MediaFileR\x04file\x122\n" +
+	"\n" +
+	"references\x18\x02 \x03(\v2\x12.v1.MediaReferenceR\n" +
+	"references\"B\n" +
+	"\x12DeleteMediaRequest\x12\x16\n" +
+	"\x06fileID\x18\x01 \x01(\tR\x06fileID\x12\x14\n" +
+	"\x05force\x18\x02 \x01(\bR\x05force\"\x15\n" +
+	"\x13DeleteMediaResponseB8Z6github.com/clin211/miniblog-v2/pkg/api/apiserver/v1;v1b\x06proto3"
+
+var (
+	file_apiserver_v1_media_proto_rawDescOnce sync.Once
+	file_apiserver_v1_media_proto_rawDescData []byte
+)
+
+func file_apiserver_v1_media_proto_rawDescGZIP() []byte {
+	file_apiserver_v1_media_proto_rawDescOnce.Do(func() {
+		file_apiserver_v1_media_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_apiserver_v1_media_proto_rawDesc), len(file_apiserver_v1_media_proto_rawDesc)))
+	})
+	return file_apiserver_v1_media_proto_rawDescData
+}
+
+var file_apiserver_v1_media_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_apiserver_v1_media_proto_goTypes = []any{
+	(*MediaFile)(nil),           // 0: v1.MediaFile
+	(*MediaReference)(nil),      // 1: v1.MediaReference
+	(*ListMediaRequest)(nil),    // 2: v1.ListMediaRequest
+	(*ListMediaResponse)(nil),   // 3: v1.ListMediaResponse
+	(*GetMediaRequest)(nil),     // 4: v1.GetMediaRequest
+	(*GetMediaResponse)(nil),    // 5: v1.GetMediaResponse
+	(*DeleteMediaRequest)(nil),  // 6: v1.DeleteMediaRequest
+	(*DeleteMediaResponse)(nil), // 7: v1.DeleteMediaResponse
+	nil,                         // 8: v1.MediaFile.MetadataEntry
+}
+var file_apiserver_v1_media_proto_depIdxs = []int32{
+	8, // 0: v1.MediaFile.metadata:type_name -> v1.MediaFile.MetadataEntry
+	0, // 1: v1.ListMediaResponse.files:type_name -> v1.MediaFile
+	0, // 2: v1.GetMediaResponse.file:type_name -> v1.MediaFile
+	1, // 3: v1.GetMediaResponse.references:type_name -> v1.MediaReference
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_apiserver_v1_media_proto_init() }
+func file_apiserver_v1_media_proto_init() {
+	if File_apiserver_v1_media_proto != nil {
+		return
+	}
+	file_apiserver_v1_media_proto_msgTypes[2].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_media_proto_rawDesc), len(file_apiserver_v1_media_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_apiserver_v1_media_proto_goTypes,
+		DependencyIndexes: file_apiserver_v1_media_proto_depIdxs,
+		MessageInfos:      file_apiserver_v1_media_proto_msgTypes,
+	}.Build()
+	File_apiserver_v1_media_proto = out.File
+	file_apiserver_v1_media_proto_goTypes = nil
+	file_apiserver_v1_media_proto_depIdxs = nil
+}
@@ -134,6 +134,62 @@ func (PostStatus) EnumDescriptor() ([]byte, []int) {
 	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{1}
 }
 
+// PostEventType 表示文章变更事件类型
+type PostEventType int32
+
+const (
+	PostEventType_POST_EVENT_TYPE_UNSPECIFIED PostEventType = 0 // 未指定
+	PostEventType_POST_EVENT_TYPE_CREATED     PostEventType = 1 // 创建
+	PostEventType_POST_EVENT_TYPE_UPDATED     PostEventType = 2 // 更新
+	PostEventType_POST_EVENT_TYPE_PUBLISHED   PostEventType = 3 // 发布（状态变为已发布）
+	PostEventType_POST_EVENT_TYPE_DELETED     PostEventType = 4 // 删除
+)
+
+// Enum value maps for PostEventType.
+var (
+	PostEventType_name = map[int32]string{
+		0: "POST_EVENT_TYPE_UNSPECIFIED",
+		1: "POST_EVENT_TYPE_CREATED",
+		2: "POST_EVENT_TYPE_UPDATED",
+		3: "POST_EVENT_TYPE_PUBLISHED",
+		4: "POST_EVENT_TYPE_DELETED",
+	}
+	PostEventType_value = map[string]int32{
+		"POST_EVENT_TYPE_UNSPECIFIED": 0,
+		"POST_EVENT_TYPE_CREATED":     1,
+		"POST_EVENT_TYPE_UPDATED":     2,
+		"POST_EVENT_TYPE_PUBLISHED":   3,
+		"POST_EVENT_TYPE_DELETED":     4,
+	}
+)
+
+func (x PostEventType) Enum() *PostEventType {
+	p := new(PostEventType)
+	*p = x
+	return p
+}
+
+func (x PostEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PostEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_apiserver_v1_post_proto_enumTypes[2].Descriptor()
+}
+
+func (PostEventType) Type() protoreflect.EnumType {
+	return &file_apiserver_v1_post_proto_enumTypes[2]
+}
+
+func (x PostEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PostEventType.Descriptor instead.
+func (PostEventType) EnumDescriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{2}
+}
+
 // Post 表示博客文章
 type Post struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -1125,6 +1181,164 @@ func (x *ListPostResponse) GetPosts() []*Post {
 	return nil
 }
 
+// WatchPostsRequest 表示订阅文章变更事件请求，过滤条件之间为“与”关系
+type WatchPostsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// categoryID 表示只接收该分类下文章的事件
+	// @gotags: form:"categoryID"
+	CategoryID *int32 `protobuf:"varint,1,opt,name=categoryID,proto3,oneof" json:"categoryID,omitempty" form:"categoryID"`
+	// userID 表示只接收该作者文章的事件，非管理员只能订阅自己的文章
+	// @gotags: form:"userID"
+	UserID *string `protobuf:"bytes,2,opt,name=userID,proto3,oneof" json:"userID,omitempty" form:"userID"`
+	// types 表示只接收这些类型的事件，为空时接收全部类型
+	// @gotags: form:"types"
+	Types         []PostEventType `protobuf:"varint,3,rep,packed,name=types,proto3,enum=v1.PostEventType" json:"types,omitempty" form:"types"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPostsRequest) Reset() {
+	*x = WatchPostsRequest{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPostsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPostsRequest) ProtoMessage() {}
+
+func (x *WatchPostsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPostsRequest.ProtoReflect.Descriptor instead.
+func (*WatchPostsRequest) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchPostsRequest) GetCategoryID() int32 {
+	if x != nil && x.CategoryID != nil {
+		return *x.CategoryID
+	}
+	return 0
+}
+
+func (x *WatchPostsRequest) GetUserID() string {
+	if x != nil && x.UserID != nil {
+		return *x.UserID
+	}
+	return ""
+}
+
+func (x *WatchPostsRequest) GetTypes() []PostEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+// PostEvent 表示文章变更事件
+type PostEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// type 表示事件类型
+	Type PostEventType `protobuf:"varint,1,opt,name=type,proto3,enum=v1.PostEventType" json:"type,omitempty"`
+	// postID 表示文章 ID
+	PostID string `protobuf:"bytes,2,opt,name=postID,proto3" json:"postID,omitempty"`
+	// userID 表示文章作者 ID
+	UserID string `protobuf:"bytes,3,opt,name=userID,proto3" json:"userID,omitempty"`
+	// categoryID 表示文章分类 ID
+	CategoryID *int32 `protobuf:"varint,4,opt,name=categoryID,proto3,oneof" json:"categoryID,omitempty"`
+	// post 表示变更后的文章，删除事件为空
+	Post *Post `protobuf:"bytes,5,opt,name=post,proto3,oneof" json:"post,omitempty"`
+	// occurredAt 表示事件发生时间（Unix 时间戳）
+	OccurredAt    int64 `protobuf:"varint,6,opt,name=occurredAt,proto3" json:"occurredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostEvent) Reset() {
+	*x = PostEvent{}
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostEvent) ProtoMessage() {}
+
+func (x *PostEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_apiserver_v1_post_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostEvent.ProtoReflect.Descriptor instead.
+func (*PostEvent) Descriptor() ([]byte, []int) {
+	return file_apiserver_v1_post_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PostEvent) GetType() PostEventType {
+	if x != nil {
+		return x.Type
+	}
+	return PostEventType_POST_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *PostEvent) GetPostID() string {
+	if x != nil {
+		return x.PostID
+	}
+	return ""
+}
+
+func (x *PostEvent) GetUserID() string {
+	if x != nil {
+		return x.UserID
+	}
+	return ""
+}
+
+func (x *PostEvent) GetCategoryID() int32 {
+	if x != nil && x.CategoryID != nil {
+		return *x.CategoryID
+	}
+	return 0
+}
+
+func (x *PostEvent) GetPost() *Post {
+	if x != nil {
+		return x.Post
+	}
+	return nil
+}
+
+func (x *PostEvent) GetOccurredAt() int64 {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return 0
+}
+
 var File_apiserver_v1_post_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_post_proto_rawDesc = "" +
@@ -1241,7 +1455,28 @@ const file_apiserver_v1_post_proto_rawDesc = "" +
 	"\x10ListPostResponse\x12\x1f\n" +
 	"\vtotal_count\x18\x01 \x01(\x03R\n" +
 	"totalCount\x12\x1e\n" +
-	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05posts*o\n" +
+	"\x05posts\x18\x02 \x03(\v2\b.v1.PostR\x05posts\"\x98\x01\n" +
+	"\x11WatchPostsRequest\x12#\n" +
+	"\n" +
+	"categoryID\x18\x01 \x01(\x05H\x00R\n" +
+	"categoryID\x88\x01\x01\x12\x1b\n" +
+	"\x06userID\x18\x02 \x01(\tH\x01R\x06userID\x88\x01\x01\x12'\n" +
+	"\x05types\x18\x03 \x03(\x0e2\x11.v1.PostEventTypeR\x05typesB\r\n" +
+	"\v_categoryIDB\t\n" +
+	"\a_userID\"\xe2\x01\n" +
+	"\tPostEvent\x12%\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x11.v1.PostEventTypeR\x04type\x12\x16\n" +
+	"\x06postID\x18\x02 \x01(\tR\x06postID\x12\x16\n" +
+	"\x06userID\x18\x03 \x01(\tR\x06userID\x12#\n" +
+	"\n" +
+	"categoryID\x18\x04 \x01(\x05H\x00R\n" +
+	"categoryID\x88\x01\x01\x12!\n" +
+	"\x04post\x18\x05 \x01(\v2\b.v1.PostH\x01R\x04post\x88\x01\x01\x12\x1e\n" +
+	"\n" +
+	"occurredAt\x18\x06 \x01(\x03R\n" +
+	"occurredAtB\r\n" +
+	"\v_categoryIDB\a\n" +
+	"\x05_post*o\n" +
 	"\bPostType\x12\x19\n" +
 	"\x15POST_TYPE_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12POST_TYPE_ORIGINAL\x10\x01\x12\x14\n" +
@@ -1252,7 +1487,13 @@ const file_apiserver_v1_post_proto_rawDesc = "" +
 	"\x17POST_STATUS_UNSPECIFIED\x10\x00\x12\x15\n" +
 	"\x11POST_STATUS_DRAFT\x10\x01\x12\x19\n" +
 	"\x15POST_STATUS_PUBLISHED\x10\x02\x12\x18\n" +
-	"\x14POST_STATUS_ARCHIVED\x10\x03B8Z6github.com/clin211/miniblog-v2/pkg/api/apiserver/v1;v1b\x06proto3"
+	"\x14POST_STATUS_ARCHIVED\x10\x03*\xa6\x01\n" +
+	"\rPostEventType\x12\x1f\n" +
+	"\x1bPOST_EVENT_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17POST_EVENT_TYPE_CREATED\x10\x01\x12\x1b\n" +
+	"\x17POST_EVENT_TYPE_UPDATED\x10\x02\x12\x1d\n" +
+	"\x19POST_EVENT_TYPE_PUBLISHED\x10\x03\x12\x1b\n" +
+	"\x17POST_EVENT_TYPE_DELETED\x10\x04B8Z6github.com/clin211/miniblog-v2/pkg/api/apiserver/v1;v1b\x06proto3"
 
 var (
 	file_apiserver_v1_post_proto_rawDescOnce sync.Once
@@ -1266,44 +1507,50 @@ func file_apiserver_v1_post_proto_rawDescGZIP() []byte {
 	return file_apiserver_v1_post_proto_rawDescData
 }
 
-var file_apiserver_v1_post_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_apiserver_v1_post_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_apiserver_v1_post_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_apiserver_v1_post_proto_goTypes = []any{
 	(PostType)(0),                 // 0: v1.PostType
 	(PostStatus)(0),               // 1: v1.PostStatus
-	(*Post)(nil),                  // 2: v1.Post
-	(*CreatePostRequest)(nil),     // 3: v1.CreatePostRequest
-	(*CreatePostResponse)(nil),    // 4: v1.CreatePostResponse
-	(*UpdatePostRequest)(nil),     // 5: v1.UpdatePostRequest
-	(*UpdatePostResponse)(nil),    // 6: v1.UpdatePostResponse
-	(*DeletePostRequest)(nil),     // 7: v1.DeletePostRequest
-	(*DeletePostResponse)(nil),    // 8: v1.DeletePostResponse
-	(*GetPostRequest)(nil),        // 9: v1.GetPostRequest
-	(*GetPostResponse)(nil),       // 10: v1.GetPostResponse
-	(*BatchGetPostsRequest)(nil),  // 11: v1.BatchGetPostsRequest
-	(*BatchGetPostsResponse)(nil), // 12: v1.BatchGetPostsResponse
-	(*ListPostRequest)(nil),       // 13: v1.ListPostRequest
-	(*ListPostResponse)(nil),      // 14: v1.ListPostResponse
-	(*Category)(nil),              // 15: v1.Category
-	(*Tag)(nil),                   // 16: v1.Tag
+	(PostEventType)(0),            // 2: v1.PostEventType
+	(*Post)(nil),                  // 3: v1.Post
+	(*CreatePostRequest)(nil),     // 4: v1.CreatePostRequest
+	(*CreatePostResponse)(nil),    // 5: v1.CreatePostResponse
+	(*UpdatePostRequest)(nil),     // 6: v1.UpdatePostRequest
+	(*UpdatePostResponse)(nil),    // 7: v1.UpdatePostResponse
+	(*DeletePostRequest)(nil),     // 8: v1.DeletePostRequest
+	(*DeletePostResponse)(nil),    // 9: v1.DeletePostResponse
+	(*GetPostRequest)(nil),        // 10: v1.GetPostRequest
+	(*GetPostResponse)(nil),       // 11: v1.GetPostResponse
+	(*BatchGetPostsRequest)(nil),  // 12: v1.BatchGetPostsRequest
+	(*BatchGetPostsResponse)(nil), // 13: v1.BatchGetPostsResponse
+	(*ListPostRequest)(nil),       // 14: v1.ListPostRequest
+	(*ListPostResponse)(nil),      // 15: v1.ListPostResponse
+	(*WatchPostsRequest)(nil),     // 16: v1.WatchPostsRequest
+	(*PostEvent)(nil),             // 17: v1.PostEvent
+	(*Category)(nil),              // 18: v1.Category
+	(*Tag)(nil),                   // 19: v1.Tag
 }
 var file_apiserver_v1_post_proto_depIdxs = []int32{
 	0,  // 0: v1.Post.postType:type_name -> v1.PostType
 	1,  // 1: v1.Post.status:type_name -> v1.PostStatus
-	15, // 2: v1.Post.category:type_name -> v1.Category
-	16, // 3: v1.Post.tags:type_name -> v1.Tag
+	18, // 2: v1.Post.category:type_name -> v1.Category
+	19, // 3: v1.Post.tags:type_name -> v1.Tag
 	0,  // 4: v1.CreatePostRequest.postType:type_name -> v1.PostType
 	1,  // 5: v1.CreatePostRequest.status:type_name -> v1.PostStatus
 	0,  // 6: v1.UpdatePostRequest.postType:type_name -> v1.PostType
 	1,  // 7: v1.UpdatePostRequest.status:type_name -> v1.PostStatus
-	2,  // 8: v1.GetPostResponse.post:type_name -> v1.Post
-	2,  // 9: v1.BatchGetPostsResponse.posts:type_name -> v1.Post
-	2,  // 10: v1.ListPostResponse.posts:type_name -> v1.Post
-	11, // [11:11] is the sub-list for method output_type
-	11, // [11:11] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	3,  // 8: v1.GetPostResponse.post:type_name -> v1.Post
+	3,  // 9: v1.BatchGetPostsResponse.posts:type_name -> v1.Post
+	3,  // 10: v1.ListPostResponse.posts:type_name -> v1.Post
+	2,  // 11: v1.WatchPostsRequest.types:type_name -> v1.PostEventType
+	2,  // 12: v1.PostEvent.type:type_name -> v1.PostEventType
+	3,  // 13: v1.PostEvent.post:type_name -> v1.Post
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_post_proto_init() }
@@ -1317,13 +1564,15 @@ func file_apiserver_v1_post_proto_init() {
 	file_apiserver_v1_post_proto_msgTypes[1].OneofWrappers = []any{}
 	file_apiserver_v1_post_proto_msgTypes[3].OneofWrappers = []any{}
 	file_apiserver_v1_post_proto_msgTypes[11].OneofWrappers = []any{}
+	file_apiserver_v1_post_proto_msgTypes[13].OneofWrappers = []any{}
+	file_apiserver_v1_post_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_apiserver_v1_post_proto_rawDesc), len(file_apiserver_v1_post_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   13,
+			NumEnums:      3,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -290,9 +290,11 @@ func (*DeletePostTagResponse) Descriptor() ([]byte, []int) {
 type ListPostTagsRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// postID 表示可选的文章 ID 过滤
-	PostID *string `protobuf:"bytes,1,opt,name=postID,proto3,oneof" json:"postID,omitempty"`
+	// @gotags: form:"postID"
+	PostID *string `protobuf:"bytes,1,opt,name=postID,proto3,oneof" json:"postID,omitempty" form:"postID"`
 	// tagID 表示可选的标签 ID 过滤
-	TagID         *int32 `protobuf:"varint,2,opt,name=tagID,proto3,oneof" json:"tagID,omitempty"`
+	// @gotags: form:"tagID"
+	TagID         *int32 `protobuf:"varint,2,opt,name=tagID,proto3,oneof" json:"tagID,omitempty" form:"tagID"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
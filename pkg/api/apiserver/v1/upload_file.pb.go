@@ -82,13 +82,14 @@ func (MultipartMode) EnumDescriptor() ([]byte, []int) {
 // UploadedObject 为上传成功后的对象信息，单文件上传与分片合并统一返回该结构。
 type UploadedObject struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                                                                           // local | alioss
+	Provider      string                 `protobuf:"bytes,1,opt,name=provider,proto3" json:"provider,omitempty"`                                                                           // local | s3 | alioss
 	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                                                                                     // 对象键
 	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`                                                                                     // 可访问 URL（或代理/签名 URL）
 	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`                                                                                  // 字节数
 	Mime          string                 `protobuf:"bytes,5,opt,name=mime,proto3" json:"mime,omitempty"`                                                                                   // MIME 类型
 	Hash          string                 `protobuf:"bytes,6,opt,name=hash,proto3" json:"hash,omitempty"`                                                                                   // 如 sha256:xxxx
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 附加元数据，如 scene
+	FileID        string                 `protobuf:"bytes,8,opt,name=fileID,proto3" json:"fileID,omitempty"`                                                                               // 上传文件记录 ID
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -172,13 +173,24 @@ func (x *UploadedObject) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *UploadedObject) GetFileID() string {
+	if x != nil {
+		return x.FileID
+	}
+	return ""
+}
+
 type UploadFileRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Scene         string                 `protobuf:"bytes,1,opt,name=scene,proto3" json:"scene,omitempty"`       // 上传场景标签（可选）
-	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"` // 建议文件名（可选）
-	Mime          string                 `protobuf:"bytes,3,opt,name=mime,proto3" json:"mime,omitempty"`         // MIME（可选）
-	Size          int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`        // 文件大小（可选，服务端可校验）
-	File          []byte                 `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// @gotags: form:"scene"
+	Scene string `protobuf:"bytes,1,opt,name=scene,proto3" json:"scene,omitempty" form:"scene"` // 上传场景标签（可选），用于选择场景级的大小与类型限制
+	// @gotags: form:"filename"
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty" form:"filename"` // 建议文件名（可选）
+	// @gotags: form:"mime"
+	Mime string `protobuf:"bytes,3,opt,name=mime,proto3" json:"mime,omitempty" form:"mime"` // MIME（可选，服务端以内容嗅探结果为准）
+	// @gotags: form:"size"
+	Size          int64  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty" form:"size"` // 文件大小（可选，服务端可校验）
+	File          []byte `protobuf:"bytes,5,opt,name=file,proto3" json:"file,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -340,6 +352,7 @@ type InitMultipartResponse struct {
 	PartSize              int64                  `protobuf:"varint,4,opt,name=partSize,proto3" json:"partSize,omitempty"`                           // 服务端最终确定的分片大小
 	PresignExpiresSeconds int64                  `protobuf:"varint,5,opt,name=presignExpiresSeconds,proto3" json:"presignExpiresSeconds,omitempty"` // 直传 URL 过期（秒），仅 direct 有意义
 	Duplicated            bool                   `protobuf:"varint,6,opt,name=duplicated,proto3" json:"duplicated,omitempty"`                       // 若命中去重直接复用（则无需再上传）
+	Object                *UploadedObject        `protobuf:"bytes,7,opt,name=object,proto3" json:"object,omitempty"`                                // 命中去重时返回已存在的对象信息
 	unknownFields         protoimpl.UnknownFields
 	sizeCache             protoimpl.SizeCache
 }
@@ -416,6 +429,13 @@ func (x *InitMultipartResponse) GetDuplicated() bool {
 	return false
 }
 
+func (x *InitMultipartResponse) GetObject() *UploadedObject {
+	if x != nil {
+		return x.Object
+	}
+	return nil
+}
+
 type PresignPartsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UploadID      string                 `protobuf:"bytes,1,opt,name=uploadID,proto3" json:"uploadID,omitempty"`
@@ -581,11 +601,14 @@ func (x *PresignPartsResponse) GetItems() []*PresignedPart {
 }
 
 type UploadPartRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UploadID      string                 `protobuf:"bytes,1,opt,name=uploadID,proto3" json:"uploadID,omitempty"`      // 会话 ID
-	PartNumber    int32                  `protobuf:"varint,2,opt,name=partNumber,proto3" json:"partNumber,omitempty"` // 分片号（1..maxParts）
-	Content       []byte                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	Checksum      string                 `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty"` // 可选：分片校验（如 sha256:xxxx 或 Content-MD5）
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// @gotags: form:"uploadID"
+	UploadID string `protobuf:"bytes,1,opt,name=uploadID,proto3" json:"uploadID,omitempty" form:"uploadID"` // 会话 ID
+	// @gotags: form:"partNumber"
+	PartNumber int32  `protobuf:"varint,2,opt,name=partNumber,proto3" json:"partNumber,omitempty" form:"partNumber"` // 分片号（1..maxParts）
+	Content    []byte `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// @gotags: form:"checksum"
+	Checksum      string `protobuf:"bytes,4,opt,name=checksum,proto3" json:"checksum,omitempty" form:"checksum"` // 可选：分片校验（如 sha256:xxxx 或 Content-MD5）
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -717,8 +740,9 @@ func (x *UploadPartResponse) GetChecksum() string {
 }
 
 type ListPartsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UploadID      string                 `protobuf:"bytes,1,opt,name=uploadID,proto3" json:"uploadID,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// @gotags: uri:"uploadID"
+	UploadID      string `protobuf:"bytes,1,opt,name=uploadID,proto3" json:"uploadID,omitempty" uri:"uploadID"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1113,7 +1137,7 @@ var File_apiserver_v1_upload_file_proto protoreflect.FileDescriptor
 
 const file_apiserver_v1_upload_file_proto_rawDesc = "" +
 	"\n" +
-	"\x1eapiserver/v1/upload_file.proto\x12\x02v1\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x87\x02\n" +
+	"\x1eapiserver/v1/upload_file.proto\x12\x02v1\x1a.protoc-gen-openapiv2/options/annotations.proto\"\x9f\x02\n" +
 	"\x0eUploadedObject\x12\x1a\n" +
 	"\bprovider\x18\x01 \x01(\tR\bprovider\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12\x10\n" +
@@ -1121,7 +1145,8 @@ const file_apiserver_v1_upload_file_proto_rawDesc = "" +
 	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x12\n" +
 	"\x04mime\x18\x05 \x01(\tR\x04mime\x12\x12\n" +
 	"\x04hash\x18\x06 \x01(\tR\x04hash\x12<\n" +
-	"\bmetadata\x18\a \x03(\v2 .v1.UploadedObject.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2 .v1.UploadedObject.MetadataEntryR\bmetadata\x12\x16\n" +
+	"\x06fileID\x18\b \x01(\tR\x06fileID\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x93\x01\n" +
@@ -1137,7 +1162,7 @@ const file_apiserver_v1_upload_file_proto_rawDesc = "" +
 	"\x04mime\x18\x03 \x01(\tR\x04mime\x12\x14\n" +
 	"\x05scene\x18\x04 \x01(\tR\x05scene\x12\x16\n" +
 	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x1a\n" +
-	"\bpartSize\x18\x06 \x01(\x03R\bpartSize\"\xde\x01\n" +
+	"\bpartSize\x18\x06 \x01(\x03R\bpartSize\"\x8a\x02\n" +
 	"\x15InitMultipartResponse\x12\x1a\n" +
 	"\buploadID\x18\x01 \x01(\tR\buploadID\x12\x10\n" +
 	"\x03key\x18\x02 \x01(\tR\x03key\x12%\n" +
@@ -1146,7 +1171,8 @@ const file_apiserver_v1_upload_file_proto_rawDesc = "" +
 	"\x15presignExpiresSeconds\x18\x05 \x01(\x03R\x15presignExpiresSeconds\x12\x1e\n" +
 	"\n" +
 	"duplicated\x18\x06 \x01(\bR\n" +
-	"duplicated\"S\n" +
+	"duplicated\x12*\n" +
+	"\x06object\x18\a \x01(\v2\x12.v1.UploadedObjectR\x06object\"S\n" +
 	"\x13PresignPartsRequest\x12\x1a\n" +
 	"\buploadID\x18\x01 \x01(\tR\buploadID\x12 \n" +
 	"\vpartNumbers\x18\x02 \x03(\x05R\vpartNumbers\"\xd5\x01\n" +
@@ -1246,16 +1272,17 @@ var file_apiserver_v1_upload_file_proto_goTypes = []any{
 var file_apiserver_v1_upload_file_proto_depIdxs = []int32{
 	18, // 0: v1.UploadedObject.metadata:type_name -> v1.UploadedObject.MetadataEntry
 	0,  // 1: v1.InitMultipartResponse.mode:type_name -> v1.MultipartMode
-	19, // 2: v1.PresignedPart.headers:type_name -> v1.PresignedPart.HeadersEntry
-	6,  // 3: v1.PresignPartsResponse.items:type_name -> v1.PresignedPart
-	11, // 4: v1.ListPartsResponse.parts:type_name -> v1.UploadedPart
-	13, // 5: v1.CompleteMultipartRequest.parts:type_name -> v1.CompletedPart
-	1,  // 6: v1.CompleteMultipartResponse.object:type_name -> v1.UploadedObject
-	7,  // [7:7] is the sub-list for method output_type
-	7,  // [7:7] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	1,  // 2: v1.InitMultipartResponse.object:type_name -> v1.UploadedObject
+	19, // 3: v1.PresignedPart.headers:type_name -> v1.PresignedPart.HeadersEntry
+	6,  // 4: v1.PresignPartsResponse.items:type_name -> v1.PresignedPart
+	11, // 5: v1.ListPartsResponse.parts:type_name -> v1.UploadedPart
+	13, // 6: v1.CompleteMultipartRequest.parts:type_name -> v1.CompletedPart
+	1,  // 7: v1.CompleteMultipartResponse.object:type_name -> v1.UploadedObject
+	8,  // [8:8] is the sub-list for method output_type
+	8,  // [8:8] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_apiserver_v1_upload_file_proto_init() }
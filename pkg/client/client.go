@@ -0,0 +1,243 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package client 提供 MiniBlog API 的 Go 客户端，同时支持 gRPC 与 REST（Gin 或 gRPC-Gateway）两种传输方式.
+//
+// 两种传输方式暴露相同的 v1.MiniBlogClient 接口，并共享以下能力：
+//   - 使用用户名密码自动登录，令牌临近过期时自动刷新，认证失败时重新登录并重试一次；
+//   - 为非公开接口自动注入 Bearer 令牌；
+//   - 服务端返回 Unavailable 时按指数退避重试；
+//   - 透传或生成 x-request-id，重试期间保持不变.
+//
+// 使用示例:
+//
+//	c, err := client.NewGRPC("localhost:6666", client.WithCredentials("root", "miniblog1234"))
+//	if err != nil {
+//		return err
+//	}
+//	defer c.Close()
+//	resp, err := c.ListPost(ctx, &v1.ListPostRequest{Limit: 10})
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+// Options 定义了客户端的配置选项.
+type Options struct {
+	// Username 与 Password 用于自动登录，为空时不会自动登录
+	Username string
+	Password string
+	// Token 为预先获取的访问令牌
+	Token string
+	// TLSOptions 为 TLS 配置，与服务端的 TLSOptions 保持一致
+	TLSOptions *genericoptions.TLSOptions
+	// Timeout 为单次调用的默认超时时间，调用方的 context 已设置截止时间时不生效
+	Timeout time.Duration
+	// MaxRetries 为返回 Unavailable 时的最大重试次数，0 表示不重试
+	MaxRetries int
+	// Backoff 与 MaxBackoff 为重试的初始与最大退避时间
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// RefreshBefore 表示在令牌过期前多久主动刷新
+	RefreshBefore time.Duration
+	// HTTPClient 为 REST 传输使用的 HTTP 客户端，为空时根据 TLSOptions 创建
+	HTTPClient *http.Client
+	// DialOptions 为 gRPC 传输额外的拨号选项
+	DialOptions []grpc.DialOption
+}
+
+// WithCredentials 设置自动登录使用的用户名和密码.
+func WithCredentials(username, password string) func(*Options) {
+	return func(options *Options) {
+		options.Username = username
+		options.Password = password
+	}
+}
+
+// WithToken 设置预先获取的访问令牌.
+func WithToken(token string) func(*Options) {
+	return func(options *Options) {
+		options.Token = token
+	}
+}
+
+// WithTLSOptions 设置 TLS 配置.
+func WithTLSOptions(tlsOptions *genericoptions.TLSOptions) func(*Options) {
+	return func(options *Options) {
+		options.TLSOptions = tlsOptions
+	}
+}
+
+// WithTimeout 设置单次调用的默认超时时间.
+func WithTimeout(timeout time.Duration) func(*Options) {
+	return func(options *Options) {
+		if timeout > 0 {
+			options.Timeout = timeout
+		}
+	}
+}
+
+// WithRetry 设置 Unavailable 错误的最大重试次数与退避时间.
+func WithRetry(maxRetries int, backoff, maxBackoff time.Duration) func(*Options) {
+	return func(options *Options) {
+		options.MaxRetries = max(maxRetries, 0)
+		if backoff > 0 {
+			options.Backoff = backoff
+		}
+		if maxBackoff > 0 {
+			options.MaxBackoff = maxBackoff
+		}
+	}
+}
+
+// WithHTTPClient 设置 REST 传输使用的 HTTP 客户端.
+func WithHTTPClient(client *http.Client) func(*Options) {
+	return func(options *Options) {
+		if client != nil {
+			options.HTTPClient = client
+		}
+	}
+}
+
+// WithDialOptions 追加 gRPC 传输的拨号选项.
+func WithDialOptions(opts ...grpc.DialOption) func(*Options) {
+	return func(options *Options) {
+		options.DialOptions = append(options.DialOptions, opts...)
+	}
+}
+
+// newOptions 返回应用了默认值与配置函数的 Options.
+func newOptions(opts ...func(*Options)) *Options {
+	options := &Options{
+		Timeout:       30 * time.Second,
+		MaxRetries:    3,
+		Backoff:       100 * time.Millisecond,
+		MaxBackoff:    2 * time.Second,
+		RefreshBefore: 5 * time.Minute,
+	}
+	for _, f := range opts {
+		f(options)
+	}
+	return options
+}
+
+// Client 为 MiniBlog API 客户端，可直接调用 v1.MiniBlogClient 的全部方法.
+type Client struct {
+	v1.MiniBlogClient
+
+	tokens *tokenSource
+	close  func() error
+}
+
+// NewGRPC 创建通过 gRPC 访问 addr 的客户端.
+func NewGRPC(addr string, opts ...func(*Options)) (*Client, error) {
+	options := newOptions(opts...)
+
+	creds := insecure.NewCredentials()
+	if options.TLSOptions != nil && options.TLSOptions.UseTLS {
+		tlsConfig, err := options.TLSOptions.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	dialOptions := append([]grpc.DialOption{grpc.WithTransportCredentials(creds)}, options.DialOptions...)
+	conn, err := grpc.NewClient(addr, dialOptions...)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(conn, conn.Close, options), nil
+}
+
+// NewREST 创建通过 HTTP 访问 baseURL 的客户端，适用于 Gin 模式与 gRPC-Gateway 模式.
+// baseURL 不包含协议时根据 TLSOptions 选择 http 或 https.
+func NewREST(baseURL string, opts ...func(*Options)) (*Client, error) {
+	options := newOptions(opts...)
+
+	httpClient := options.HTTPClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if options.TLSOptions != nil && options.TLSOptions.UseTLS {
+			tlsConfig, err := options.TLSOptions.TLSConfig()
+			if err != nil {
+				return nil, err
+			}
+			transport.TLSClientConfig = tlsConfig
+		}
+		httpClient = &http.Client{Transport: transport}
+	}
+
+	if !strings.Contains(baseURL, "://") {
+		scheme := "http"
+		if options.TLSOptions != nil {
+			scheme = options.TLSOptions.Scheme()
+		}
+		baseURL = scheme + "://" + baseURL
+	}
+
+	conn := newRESTConn(strings.TrimSuffix(baseURL, "/"), httpClient)
+	return newClient(conn, func() error {
+		httpClient.CloseIdleConnections()
+		return nil
+	}, options), nil
+}
+
+// newClient 在底层连接上组装拦截器并创建客户端.
+func newClient(cc grpc.ClientConnInterface, closeFn func() error, options *Options) *Client {
+	// 登录与刷新令牌不经过认证拦截器，避免递归
+	base := &chainConn{
+		cc:      cc,
+		timeout: options.Timeout,
+		unary:   []grpc.UnaryClientInterceptor{requestIDInterceptor(), retryInterceptor(options)},
+		stream:  []grpc.StreamClientInterceptor{requestIDStreamInterceptor()},
+	}
+	tokens := newTokenSource(v1.NewMiniBlogClient(base), options)
+
+	conn := &chainConn{
+		cc:      cc,
+		timeout: options.Timeout,
+		unary:   append(slices.Clone(base.unary), authInterceptor(tokens)),
+		stream:  append(slices.Clone(base.stream), authStreamInterceptor(tokens)),
+	}
+	return &Client{MiniBlogClient: v1.NewMiniBlogClient(conn), tokens: tokens, close: closeFn}
+}
+
+// Login 使用用户名和密码登录，成功后后续调用自动携带令牌，并在令牌失效时使用该凭据重新登录.
+func (c *Client) Login(ctx context.Context, username, password string) error {
+	return c.tokens.login(ctx, username, password)
+}
+
+// Logout 退出登录，吊销当前的访问令牌与刷新令牌，并清除保存的凭据.
+func (c *Client) Logout(ctx context.Context) error {
+	token, refreshToken := c.tokens.reset()
+	if token == "" {
+		return nil
+	}
+	_, err := c.MiniBlogClient.Logout(withBearer(ctx, token), &v1.LogoutRequest{RefreshToken: refreshToken})
+	return err
+}
+
+// Token 返回当前的访问令牌.
+func (c *Client) Token() string {
+	return c.tokens.current()
+}
+
+// Close 关闭底层连接.
+func (c *Client) Close() error {
+	return c.close()
+}
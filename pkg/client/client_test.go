@@ -0,0 +1,181 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/errno"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+func TestRESTClient(t *testing.T) {
+	var logins, attempts atomic.Int32
+	var mu sync.Mutex
+	var requestIDs []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/system/auth/login", func(w http.ResponseWriter, r *http.Request) {
+		var rq v1.LoginRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&rq))
+		assert.Equal(t, "root", rq.GetUsername())
+		n := logins.Add(1)
+		_ = json.NewEncoder(w).Encode(&v1.LoginResponse{Token: fmt.Sprintf("token-%d", n), ExpireAt: time.Now().Add(time.Hour).Unix()})
+	})
+	mux.HandleFunc("GET /v1/system/users/{userID}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requestIDs = append(requestIDs, r.Header.Get("X-Request-ID"))
+		mu.Unlock()
+		switch {
+		case attempts.Add(1) == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case r.Header.Get("Authorization") == "Bearer token-1":
+			// 模拟令牌被吊销
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"reason":"Unauthenticated.TokenInvalid","message":"Token was invalid."}`))
+		case r.PathValue("userID") != "user-000001":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"reason":"NotFound.UserNotFound","message":"User not found."}`))
+		default:
+			_, _ = w.Write([]byte(`{"user":{"userID":"user-000001","username":"root","age":18}}`))
+		}
+	})
+	mux.HandleFunc("GET /v1/system/users", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "5", r.URL.Query().Get("offset"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		_, _ = w.Write([]byte(`{"totalCount":"1","users":[{"userID":"user-000001"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c, err := NewREST(srv.URL, WithCredentials("root", "miniblog1234"), WithRetry(2, time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	defer c.Close()
+
+	// 503 时重试，401 时重新登录后重试，整个过程使用相同的请求 ID
+	ctx := ContextWithRequestID(context.Background(), "request-1")
+	resp, err := c.GetUser(ctx, &v1.GetUserRequest{UserID: "user-000001"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(18), resp.GetUser().GetAge())
+	assert.Equal(t, "token-2", c.Token())
+	mu.Lock()
+	assert.Equal(t, []string{"request-1", "request-1", "request-1"}, requestIDs)
+	mu.Unlock()
+
+	_, err = c.GetUser(context.Background(), &v1.GetUserRequest{UserID: "user-000002"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "NotFound.UserNotFound", errno.FromError(err).Reason)
+	mu.Lock()
+	assert.NotEmpty(t, requestIDs[3])
+	mu.Unlock()
+
+	list, err := c.ListUser(context.Background(), &v1.ListUserRequest{Offset: 5, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.GetTotalCount())
+	assert.Equal(t, int32(2), logins.Load())
+
+	_, err = c.WatchPosts(context.Background(), &v1.WatchPostsRequest{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+// fakeServer 模拟 MiniBlog gRPC 服务，记录收到的 authorization.
+type fakeServer struct {
+	v1.UnimplementedMiniBlogServer
+	mu            sync.Mutex
+	authorization []string
+	refreshTokens []string
+}
+
+func (s *fakeServer) Healthz(ctx context.Context, _ *emptypb.Empty) (*v1.HealthzResponse, error) {
+	s.record(ctx)
+	return &v1.HealthzResponse{}, nil
+}
+
+func (s *fakeServer) Login(context.Context, *v1.LoginRequest) (*v1.LoginResponse, error) {
+	return &v1.LoginResponse{Token: "token", ExpireAt: time.Now().Add(time.Minute).Unix(), RefreshToken: "refresh-1"}, nil
+}
+
+func (s *fakeServer) RefreshToken(ctx context.Context, rq *v1.RefreshTokenRequest) (*v1.RefreshTokenResponse, error) {
+	s.record(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshTokens = append(s.refreshTokens, rq.GetRefreshToken())
+	return &v1.RefreshTokenResponse{Token: "refreshed", ExpireAt: time.Now().Add(time.Hour).Unix(), RefreshToken: "refresh-2"}, nil
+}
+
+func (s *fakeServer) Logout(ctx context.Context, rq *v1.LogoutRequest) (*v1.LogoutResponse, error) {
+	s.record(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.refreshTokens = append(s.refreshTokens, rq.GetRefreshToken())
+	return &v1.LogoutResponse{}, nil
+}
+
+func (s *fakeServer) ListPost(ctx context.Context, _ *v1.ListPostRequest) (*v1.ListPostResponse, error) {
+	s.record(ctx)
+	return &v1.ListPostResponse{}, nil
+}
+
+func (s *fakeServer) record(ctx context.Context) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.authorization = append(s.authorization, md.Get("authorization")...)
+}
+
+func TestGRPCClient(t *testing.T) {
+	lis := bufconn.Listen(1 << 20)
+	fake := &fakeServer{}
+	srv := grpc.NewServer()
+	v1.RegisterMiniBlogServer(srv, fake)
+	go func() { _ = srv.Serve(lis) }()
+	defer srv.Stop()
+
+	c, err := NewGRPC("passthrough:///bufnet",
+		WithCredentials("root", "miniblog1234"),
+		WithDialOptions(
+			grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	defer c.Close()
+
+	// 公开接口不携带令牌；登录返回的令牌临近过期，第二次调用前先使用刷新令牌刷新，刷新请求不携带访问令牌
+	_, err = c.Healthz(context.Background(), nil)
+	require.NoError(t, err)
+	for range 3 {
+		_, err = c.ListPost(context.Background(), &v1.ListPostRequest{})
+		require.NoError(t, err)
+	}
+
+	// 退出登录时携带当前的刷新令牌，随后清除令牌与凭据
+	require.NoError(t, c.Logout(context.Background()))
+	assert.Empty(t, c.Token())
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	assert.Equal(t, []string{"Bearer token", "Bearer refreshed", "Bearer refreshed", "Bearer refreshed"}, fake.authorization)
+	assert.Equal(t, []string{"refresh-1", "refresh-2"}, fake.refreshTokens)
+}
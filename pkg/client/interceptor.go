@@ -0,0 +1,196 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package client
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/known"
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// publicMethods 为 proto 中标注为 ACCESS_PUBLIC 的方法，调用时不携带令牌.
+var publicMethods = func() map[string]bool {
+	methods := make(map[string]bool)
+	services := v1.File_apiserver_v1_apiserver_proto.Services()
+	for i := range services.Len() {
+		service := services.Get(i)
+		for j := range service.Methods().Len() {
+			method := service.Methods().Get(j)
+			if proto.GetExtension(method.Options(), v1.E_Access).(v1.Access) == v1.Access_ACCESS_PUBLIC {
+				methods[fullMethodName(method)] = true
+			}
+		}
+	}
+	return methods
+}()
+
+// fullMethodName 返回 gRPC 调用使用的完整方法名，例如 /v1.MiniBlog/Login.
+func fullMethodName(method protoreflect.MethodDescriptor) string {
+	return "/" + string(method.Parent().FullName()) + "/" + string(method.Name())
+}
+
+// requestIDKey 用于在 context 中保存请求 ID.
+type requestIDKey struct{}
+
+// ContextWithRequestID 返回携带请求 ID 的 context，该 ID 会作为 x-request-id 发送给服务端.
+// 未设置时客户端为每次调用生成新的请求 ID.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// chainConn 在底层连接上依次执行拦截器，gRPC 与 REST 传输共用同一套拦截器.
+type chainConn struct {
+	cc      grpc.ClientConnInterface
+	timeout time.Duration
+	unary   []grpc.UnaryClientInterceptor
+	stream  []grpc.StreamClientInterceptor
+}
+
+// Invoke 实现 grpc.ClientConnInterface 接口.
+func (c *chainConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if _, ok := ctx.Deadline(); !ok && c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	invoker := func(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.cc.Invoke(ctx, method, args, reply, opts...)
+	}
+	for i := len(c.unary) - 1; i >= 0; i-- {
+		interceptor, next := c.unary[i], invoker
+		invoker = func(ctx context.Context, method string, args, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			return interceptor(ctx, method, args, reply, cc, next, opts...)
+		}
+	}
+	return invoker(ctx, method, args, reply, nil, opts...)
+}
+
+// NewStream 实现 grpc.ClientConnInterface 接口. 流式调用不设置默认超时.
+func (c *chainConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, _ *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return c.cc.NewStream(ctx, desc, method, opts...)
+	}
+	for i := len(c.stream) - 1; i >= 0; i-- {
+		interceptor, next := c.stream[i], streamer
+		streamer = func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return interceptor(ctx, desc, cc, method, next, opts...)
+		}
+	}
+	return streamer(ctx, desc, nil, method, opts...)
+}
+
+// requestIDInterceptor 为调用设置 x-request-id，放在重试之前以保证重试使用相同的请求 ID.
+func requestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// requestIDStreamInterceptor 为流式调用设置 x-request-id.
+func requestIDStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withRequestID 将请求 ID 写入 outgoing metadata，已存在时保持不变.
+func withRequestID(ctx context.Context) context.Context {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(known.XRequestID)) > 0 {
+		return ctx
+	}
+
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	return metadata.AppendToOutgoingContext(ctx, known.XRequestID, requestID)
+}
+
+// retryInterceptor 在服务端返回 Unavailable 时按指数退避重试.
+func retryInterceptor(options *Options) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		backoff := options.Backoff
+		for attempt := 0; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if status.Code(err) != codes.Unavailable || attempt >= options.MaxRetries {
+				return err
+			}
+
+			// 在 [backoff/2, backoff) 之间随机等待，避免多个客户端同时重试
+			wait := backoff/2 + rand.N(backoff/2+1)
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(wait):
+			}
+			backoff = min(backoff*2, options.MaxBackoff)
+		}
+	}
+}
+
+// authInterceptor 为非公开接口注入 Bearer 令牌，令牌被拒绝且可以重新登录时重试一次.
+func authInterceptor(ts *tokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if publicMethods[method] || hasAuthorization(ctx) {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		token, err := ts.Token(ctx)
+		if err != nil {
+			return err
+		}
+		err = invoker(withBearer(ctx, token), method, req, reply, cc, opts...)
+		if status.Code(err) != codes.Unauthenticated || !ts.Invalidate(token) {
+			return err
+		}
+
+		if token, err = ts.Token(ctx); err != nil {
+			return err
+		}
+		return invoker(withBearer(ctx, token), method, req, reply, cc, opts...)
+	}
+}
+
+// authStreamInterceptor 为流式调用注入 Bearer 令牌.
+func authStreamInterceptor(ts *tokenSource) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if publicMethods[method] || hasAuthorization(ctx) {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		token, err := ts.Token(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(withBearer(ctx, token), desc, cc, method, opts...)
+	}
+}
+
+// hasAuthorization 返回调用方是否已自行设置 authorization.
+func hasAuthorization(ctx context.Context) bool {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	return ok && len(md.Get("authorization")) > 0
+}
+
+// withBearer 将令牌写入 outgoing metadata，令牌为空时不做处理.
+func withBearer(ctx context.Context, token string) context.Context {
+	if token == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
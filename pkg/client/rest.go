@@ -0,0 +1,278 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	httpstatus "github.com/go-kratos/kratos/v2/transport/http/status"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// route 为 gRPC 方法对应的 HTTP 路由，来自 proto 中的 google.api.http 注解.
+type route struct {
+	method  string
+	pattern string
+	body    string
+}
+
+// routes 为完整方法名到 HTTP 路由的映射.
+var routes = func() map[string]route {
+	routes := make(map[string]route)
+	services := v1.File_apiserver_v1_apiserver_proto.Services()
+	for i := range services.Len() {
+		service := services.Get(i)
+		for j := range service.Methods().Len() {
+			method := service.Methods().Get(j)
+			rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+			if !ok || rule == nil {
+				continue
+			}
+
+			rt := route{body: rule.GetBody()}
+			switch pattern := rule.GetPattern().(type) {
+			case *annotations.HttpRule_Get:
+				rt.method, rt.pattern = http.MethodGet, pattern.Get
+			case *annotations.HttpRule_Post:
+				rt.method, rt.pattern = http.MethodPost, pattern.Post
+			case *annotations.HttpRule_Put:
+				rt.method, rt.pattern = http.MethodPut, pattern.Put
+			case *annotations.HttpRule_Delete:
+				rt.method, rt.pattern = http.MethodDelete, pattern.Delete
+			case *annotations.HttpRule_Patch:
+				rt.method, rt.pattern = http.MethodPatch, pattern.Patch
+			default:
+				continue
+			}
+			routes[fullMethodName(method)] = rt
+		}
+	}
+	return routes
+}()
+
+// errorResponse 为 Gin 模式下 core.WriteResponse 返回的错误格式.
+type errorResponse struct {
+	Reason   string            `json:"reason"`
+	Message  string            `json:"message"`
+	Metadata map[string]string `json:"metadata"`
+}
+
+// restConn 通过 HTTP 调用 MiniBlog API，实现 grpc.ClientConnInterface 接口.
+// 请求体使用 encoding/json 编码，以同时兼容 Gin 的绑定与 gRPC-Gateway 的 protojson 解析.
+type restConn struct {
+	baseURL string
+	client  *http.Client
+}
+
+// newRESTConn 创建 restConn.
+func newRESTConn(baseURL string, client *http.Client) *restConn {
+	return &restConn{baseURL: baseURL, client: client}
+}
+
+// Invoke 实现 grpc.ClientConnInterface 接口.
+// 返回的错误与 gRPC 传输一致，均为 gRPC status 错误，可通过 errno.FromError 转换.
+func (c *restConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	rt, ok := routes[method]
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "method %s has no HTTP binding", method)
+	}
+
+	req, err := c.newRequest(ctx, rt, args.(proto.Message))
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer resp.Body.Close()
+
+	for _, opt := range opts {
+		if header, ok := opt.(grpc.HeaderCallOption); ok {
+			*header.HeaderAddr = headerToMD(resp.Header)
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return decodeError(resp.StatusCode, body)
+	}
+	return decodeResponse(body, reply.(proto.Message))
+}
+
+// NewStream 实现 grpc.ClientConnInterface 接口，REST 传输不支持流式调用.
+func (c *restConn) NewStream(_ context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Errorf(codes.Unimplemented, "streaming method %s is not supported by the REST transport", method)
+}
+
+// newRequest 根据路由构造 HTTP 请求：路径参数取自请求消息，body 为 "*" 时消息编码为 JSON，否则其余字段作为查询参数.
+func (c *restConn) newRequest(ctx context.Context, rt route, msg proto.Message) (*http.Request, error) {
+	path, used, err := expandPath(rt.pattern, msg.ProtoReflect())
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if rt.body == "*" {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	} else if query := encodeQuery(msg.ProtoReflect(), used); len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, rt.method, c.baseURL+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	// authorization 与 x-request-id 等 outgoing metadata 作为请求头发送
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		for key, values := range md {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+	}
+	return req, nil
+}
+
+// expandPath 将路由模板中的 {field} 替换为消息字段的值，返回路径及已使用的字段.
+func expandPath(pattern string, msg protoreflect.Message) (string, map[protoreflect.Name]bool, error) {
+	used := make(map[protoreflect.Name]bool)
+	var b strings.Builder
+	for {
+		start := strings.IndexByte(pattern, '{')
+		if start < 0 {
+			b.WriteString(pattern)
+			return b.String(), used, nil
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end < 0 {
+			return "", nil, fmt.Errorf("invalid path template %q", pattern)
+		}
+
+		name := pattern[start+1 : start+end]
+		field := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return "", nil, fmt.Errorf("path parameter %q not found in %s", name, msg.Descriptor().FullName())
+		}
+		value := fmt.Sprint(msg.Get(field).Interface())
+		if value == "" {
+			return "", nil, fmt.Errorf("path parameter %q is empty", name)
+		}
+
+		b.WriteString(pattern[:start])
+		b.WriteString(url.PathEscape(value))
+		used[field.Name()] = true
+		pattern = pattern[start+end+1:]
+	}
+}
+
+// encodeQuery 将未出现在路径中的标量字段编码为查询参数，参数名使用字段的 JSON 名称.
+func encodeQuery(msg protoreflect.Message, used map[protoreflect.Name]bool) url.Values {
+	query := url.Values{}
+	msg.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if used[field.Name()] || field.Kind() == protoreflect.MessageKind || field.IsMap() {
+			return true
+		}
+		if field.IsList() {
+			list := value.List()
+			for i := range list.Len() {
+				query.Add(field.JSONName(), formatScalar(field, list.Get(i)))
+			}
+			return true
+		}
+		query.Set(field.JSONName(), formatScalar(field, value))
+		return true
+	})
+	return query
+}
+
+// formatScalar 将标量字段的值格式化为字符串，枚举使用数字表示.
+func formatScalar(field protoreflect.FieldDescriptor, value protoreflect.Value) string {
+	if field.Kind() == protoreflect.EnumKind {
+		return fmt.Sprint(int32(value.Enum()))
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// decodeResponse 解码成功响应. gRPC-Gateway 使用 protojson 编码，Gin 使用 encoding/json 编码，依次尝试.
+func decodeResponse(body []byte, reply proto.Message) error {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	err := protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(body, reply)
+	if err == nil {
+		return nil
+	}
+	proto.Reset(reply)
+	if jsonErr := json.Unmarshal(body, reply); jsonErr != nil {
+		return status.Errorf(codes.Internal, "failed to decode response: %v", errors.Join(err, jsonErr))
+	}
+	return nil
+}
+
+// decodeError 将错误响应转换为 gRPC status 错误.
+// 兼容 gRPC-Gateway 返回的 google.rpc.Status 与 Gin 返回的 {reason, message, metadata}.
+func decodeError(code int, body []byte) error {
+	var st spb.Status
+	if err := protojson.Unmarshal(body, &st); err == nil && st.GetCode() != 0 {
+		return status.ErrorProto(&st)
+	}
+
+	var rsp errorResponse
+	if err := json.Unmarshal(body, &rsp); err != nil || rsp.Message == "" {
+		rsp.Message = http.StatusText(code)
+	}
+	s := status.New(httpstatus.ToGRPCCode(code), rsp.Message)
+	if rsp.Reason != "" {
+		if ds, err := s.WithDetails(&errdetails.ErrorInfo{Reason: rsp.Reason, Metadata: rsp.Metadata}); err == nil {
+			s = ds
+		}
+	}
+	return s.Err()
+}
+
+// headerToMD 将 HTTP 响应头转换为 metadata，键统一为小写.
+func headerToMD(header http.Header) metadata.MD {
+	md := metadata.MD{}
+	for key, values := range header {
+		md.Append(strings.ToLower(key), values...)
+	}
+	return md
+}
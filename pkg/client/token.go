@@ -0,0 +1,134 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "github.com/clin211/miniblog-v2/pkg/api/apiserver/v1"
+)
+
+// tokenSource 维护访问令牌，负责登录与刷新.
+type tokenSource struct {
+	mu       sync.Mutex
+	api      v1.MiniBlogClient
+	username string
+	password string
+	token    string
+	expireAt time.Time
+	// refreshToken 为登录或上一次刷新返回的刷新令牌，每次刷新后更新
+	refreshToken string
+	// refreshBefore 表示在令牌过期前多久主动刷新
+	refreshBefore time.Duration
+}
+
+// newTokenSource 创建 tokenSource，api 不应包含认证拦截器.
+func newTokenSource(api v1.MiniBlogClient, options *Options) *tokenSource {
+	return &tokenSource{
+		api:           api,
+		username:      options.Username,
+		password:      options.Password,
+		token:         options.Token,
+		refreshBefore: options.RefreshBefore,
+	}
+}
+
+// current 返回当前的访问令牌.
+func (ts *tokenSource) current() string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return ts.token
+}
+
+// Token 返回可用的访问令牌.
+// 没有令牌时使用凭据登录；令牌临近过期时优先使用刷新令牌刷新，刷新失败再重新登录.
+func (ts *tokenSource) Token(ctx context.Context) (string, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.token != "" && (ts.expireAt.IsZero() || time.Until(ts.expireAt) > ts.refreshBefore) {
+		return ts.token, nil
+	}
+
+	if ts.refreshToken != "" {
+		resp, err := ts.api.RefreshToken(ctx, &v1.RefreshTokenRequest{RefreshToken: ts.refreshToken})
+		if err == nil {
+			ts.set(resp.GetToken(), resp.GetExpireAt())
+			ts.refreshToken = resp.GetRefreshToken()
+			return ts.token, nil
+		}
+		// 刷新令牌已失效，不再重试
+		ts.refreshToken = ""
+	}
+
+	if ts.username == "" {
+		// 没有凭据时继续使用现有令牌，由服务端决定是否放行
+		return ts.token, nil
+	}
+	if err := ts.loginLocked(ctx); err != nil {
+		return "", err
+	}
+	return ts.token, nil
+}
+
+// Invalidate 在令牌被服务端拒绝时调用，返回是否可以通过重新登录恢复.
+func (ts *tokenSource) Invalidate(token string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if ts.username == "" {
+		return false
+	}
+	// 令牌已被其他调用更新时无需清除
+	if ts.token == token {
+		ts.token = ""
+		ts.expireAt = time.Time{}
+		ts.refreshToken = ""
+	}
+	return true
+}
+
+// login 使用给定的凭据登录并保存凭据.
+func (ts *tokenSource) login(ctx context.Context, username, password string) error {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	ts.username, ts.password = username, password
+	return ts.loginLocked(ctx)
+}
+
+// loginLocked 使用已保存的凭据登录，调用方必须持有锁.
+func (ts *tokenSource) loginLocked(ctx context.Context) error {
+	resp, err := ts.api.Login(ctx, &v1.LoginRequest{Username: ts.username, Password: ts.password})
+	if err != nil {
+		return err
+	}
+	ts.set(resp.GetToken(), resp.GetExpireAt())
+	ts.refreshToken = resp.GetRefreshToken()
+	return nil
+}
+
+// reset 清除令牌与凭据，返回清除前的访问令牌与刷新令牌.
+func (ts *tokenSource) reset() (string, string) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token, refreshToken := ts.token, ts.refreshToken
+	ts.username, ts.password = "", ""
+	ts.token, ts.expireAt, ts.refreshToken = "", time.Time{}, ""
+	return token, refreshToken
+}
+
+// set 保存令牌及其过期时间.
+func (ts *tokenSource) set(token string, expireAt int64) {
+	ts.token = token
+	ts.expireAt = time.Time{}
+	if expireAt > 0 {
+		ts.expireAt = time.Unix(expireAt, 0)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package clientip 根据对端地址与 X-Forwarded-For、X-Real-IP 请求头解析真实的客户端 IP.
+// 只有当对端属于受信任的代理时才会采信请求头，避免客户端伪造来源 IP.
+package clientip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+const (
+	// HeaderXForwardedFor 为代理追加客户端地址的请求头.
+	HeaderXForwardedFor = "X-Forwarded-For"
+	// HeaderXRealIP 为部分代理（如 Nginx）设置的客户端地址请求头.
+	HeaderXRealIP = "X-Real-IP"
+)
+
+// DefaultTrustedProxies 为默认信任的代理网段，仅包含回环地址，
+// 覆盖 grpc-gateway 通过本机转发到 gRPC 服务的场景.
+var DefaultTrustedProxies = []string{"127.0.0.0/8", "::1/128"}
+
+// Extractor 根据受信任的代理网段解析客户端 IP.
+type Extractor struct {
+	trusted []netip.Prefix
+}
+
+// New 创建一个 Extractor，proxies 可以是 CIDR 或单个 IP 地址.
+func New(proxies []string) (*Extractor, error) {
+	e := &Extractor{}
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !strings.Contains(proxy, "/") {
+			addr, err := netip.ParseAddr(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			e.trusted = append(e.trusted, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		e.trusted = append(e.trusted, prefix.Masked())
+	}
+	return e, nil
+}
+
+// Trusted 返回 ip 是否属于受信任的代理.
+func (e *Extractor) Trusted(ip string) bool {
+	if e == nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range e.trusted {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP 返回真实的客户端 IP. remoteAddr 为对端地址（可带端口），
+// xForwardedFor 与 xRealIP 为对应请求头的值.
+//
+// 对端不受信任时直接返回对端地址；否则从右向左遍历 X-Forwarded-For，
+// 返回第一个不受信任的地址，全部受信任时返回最左侧的地址。
+// 没有 X-Forwarded-For 时回退到 X-Real-IP.
+func (e *Extractor) ClientIP(remoteAddr string, xForwardedFor []string, xRealIP string) string {
+	remoteIP := hostIP(remoteAddr)
+	if !e.Trusted(remoteIP) {
+		return remoteIP
+	}
+
+	var hops []string
+	for _, header := range xForwardedFor {
+		for _, hop := range strings.Split(header, ",") {
+			if hop = strings.TrimSpace(hop); hop != "" {
+				hops = append(hops, hop)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := hostIP(hops[i])
+		if ip == "" {
+			// 无法解析的地址之前的内容不可信
+			break
+		}
+		if !e.Trusted(ip) || i == 0 {
+			return ip
+		}
+	}
+
+	if ip := hostIP(strings.TrimSpace(xRealIP)); ip != "" {
+		return ip
+	}
+	return remoteIP
+}
+
+// hostIP 从可能带端口的地址中解析出 IP，无法解析时返回空字符串.
+func hostIP(addr string) string {
+	if addr == "" {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		addr = host
+	}
+	ip, err := netip.ParseAddr(strings.Trim(addr, "[]"))
+	if err != nil {
+		return ""
+	}
+	return ip.Unmap().String()
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package clientip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New([]string{"10.0.0.0/8", "192.168.1.1", " ", "::1/128"})
+	require.NoError(t, err)
+
+	_, err = New([]string{"not-an-ip"})
+	assert.Error(t, err)
+
+	_, err = New([]string{"10.0.0.0/33"})
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	e, err := New([]string{"10.0.0.0/8", "127.0.0.1"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		xRealIP    string
+		expected   string
+	}{
+		{
+			name:       "untrusted peer ignores headers",
+			remoteAddr: "203.0.113.7:5000",
+			xff:        []string{"1.2.3.4"},
+			xRealIP:    "5.6.7.8",
+			expected:   "203.0.113.7",
+		},
+		{
+			name:       "trusted peer without headers",
+			remoteAddr: "10.1.2.3:5000",
+			expected:   "10.1.2.3",
+		},
+		{
+			name:       "trusted peer uses last untrusted hop",
+			remoteAddr: "10.1.2.3:5000",
+			xff:        []string{"1.1.1.1, 2.2.2.2", "10.0.0.5"},
+			expected:   "2.2.2.2",
+		},
+		{
+			name:       "all hops trusted returns leftmost",
+			remoteAddr: "127.0.0.1:5000",
+			xff:        []string{"10.0.0.9, 10.0.0.5"},
+			expected:   "10.0.0.9",
+		},
+		{
+			name:       "falls back to x-real-ip",
+			remoteAddr: "127.0.0.1:5000",
+			xRealIP:    "198.51.100.1",
+			expected:   "198.51.100.1",
+		},
+		{
+			name:       "ipv6 peer with port",
+			remoteAddr: "[2001:db8::1]:443",
+			xff:        []string{"1.1.1.1"},
+			expected:   "2001:db8::1",
+		},
+		{
+			name:       "ipv4-mapped trusted peer",
+			remoteAddr: "[::ffff:10.0.0.1]:80",
+			xff:        []string{"1.1.1.1"},
+			expected:   "1.1.1.1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, e.ClientIP(tt.remoteAddr, tt.xff, tt.xRealIP))
+		})
+	}
+}
+
+func TestNilExtractor(t *testing.T) {
+	var e *Extractor
+	assert.Equal(t, "1.2.3.4", e.ClientIP("1.2.3.4:80", []string{"5.6.7.8"}, ""))
+}
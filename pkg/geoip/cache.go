@@ -0,0 +1,112 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package geoip
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// 确保 *Cache 实现了 Resolver 接口.
+var _ Resolver = (*Cache)(nil)
+
+// Cache 为 Resolver 增加 LRU+TTL 缓存. 查询不到的结果同样会被缓存，
+// 解析出错时不缓存，下次请求会重新查询.
+type Cache struct {
+	resolver Resolver
+	size     int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	ll      *list.List
+	entries map[string]*list.Element
+}
+
+// cacheEntry 为缓存链表中的元素.
+type cacheEntry struct {
+	ip       string
+	location *Location
+	expireAt time.Time
+}
+
+// NewCache 创建一个最多缓存 size 个 IP、每条缓存有效期为 ttl 的解析器.
+func NewCache(resolver Resolver, size int, ttl time.Duration) *Cache {
+	if size <= 0 {
+		size = 1
+	}
+	return &Cache{
+		resolver: resolver,
+		size:     size,
+		ttl:      ttl,
+		now:      time.Now,
+		ll:       list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Resolve 实现 Resolver 接口，命中且未过期时直接返回缓存结果.
+func (c *Cache) Resolve(ctx context.Context, ip string) (*Location, error) {
+	if location, ok := c.get(ip); ok {
+		return location, nil
+	}
+
+	location, err := c.resolver.Resolve(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	c.add(ip, location)
+	return location, nil
+}
+
+// Len 返回当前缓存的条目数.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ll.Len()
+}
+
+// get 查询缓存，过期的条目会被移除.
+func (c *Cache) get(ip string) (*Location, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[ip]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*cacheEntry)
+	if c.ttl > 0 && !c.now().Before(entry.expireAt) {
+		c.ll.Remove(elem)
+		delete(c.entries, ip)
+		return nil, false
+	}
+	c.ll.MoveToFront(elem)
+	return entry.location, true
+}
+
+// add 写入缓存，超出容量时淘汰最久未使用的条目.
+func (c *Cache) add(ip string, location *Location) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expireAt := c.now().Add(c.ttl)
+	if elem, ok := c.entries[ip]; ok {
+		entry := elem.Value.(*cacheEntry)
+		entry.location, entry.expireAt = location, expireAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.entries[ip] = c.ll.PushFront(&cacheEntry{ip: ip, location: location, expireAt: expireAt})
+	for c.ll.Len() > c.size {
+		oldest := c.ll.Back()
+		c.ll.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).ip)
+	}
+}
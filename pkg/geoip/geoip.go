@@ -0,0 +1,62 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package geoip 提供客户端 IP 的地理位置解析，支持离线的 MaxMind/DB-IP mmdb 数据库
+// 与在线的 ipwho.is 接口，并可通过 LRU+TTL 缓存减少重复查询.
+package geoip
+
+import (
+	"context"
+	"net/netip"
+	"strings"
+)
+
+// Location 表示 IP 地址的地理位置.
+type Location struct {
+	// Country 为国家/地区名称
+	Country string
+	// CountryCode 为 ISO 3166-1 两位国家代码
+	CountryCode string
+	// City 为城市名称
+	City string
+}
+
+// String 返回 "国家/城市" 形式的位置描述，缺失的部分会被省略.
+func (l *Location) String() string {
+	if l == nil {
+		return ""
+	}
+	var parts []string
+	for _, part := range []string{l.Country, l.City} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Resolver 定义 IP 地理位置解析器.
+// 查询不到位置时返回 nil, nil；只有解析器本身出错时才返回错误.
+type Resolver interface {
+	Resolve(ctx context.Context, ip string) (*Location, error)
+}
+
+// Lookup 解析 ip 的地理位置. resolver 为 nil、ip 无效或为内网、回环等非公网地址时直接返回 nil.
+func Lookup(ctx context.Context, resolver Resolver, ip string) (*Location, error) {
+	if resolver == nil || !IsPublic(ip) {
+		return nil, nil
+	}
+	return resolver.Resolve(ctx, ip)
+}
+
+// IsPublic 返回 ip 是否为可解析地理位置的公网地址.
+func IsPublic(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate()
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package geoip
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeResolver 记录查询次数，返回预设的结果.
+type fakeResolver struct {
+	calls     map[string]int
+	locations map[string]*Location
+	err       error
+}
+
+func (f *fakeResolver) Resolve(_ context.Context, ip string) (*Location, error) {
+	f.calls[ip]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.locations[ip], nil
+}
+
+func newFakeResolver() *fakeResolver {
+	return &fakeResolver{
+		calls: map[string]int{},
+		locations: map[string]*Location{
+			"8.8.8.8": {Country: "United States", CountryCode: "US", City: "Mountain View"},
+			"1.1.1.1": {Country: "Australia", CountryCode: "AU"},
+		},
+	}
+}
+
+func TestLocationString(t *testing.T) {
+	assert.Equal(t, "United States/Mountain View", (&Location{Country: "United States", City: "Mountain View"}).String())
+	assert.Equal(t, "Australia", (&Location{Country: "Australia"}).String())
+	assert.Equal(t, "", (*Location)(nil).String())
+}
+
+func TestLookupSkipsNonPublicIP(t *testing.T) {
+	r := newFakeResolver()
+	for _, ip := range []string{"", "invalid", "127.0.0.1", "10.0.0.1", "192.168.1.1", "::1", "fe80::1"} {
+		location, err := Lookup(context.Background(), r, ip)
+		require.NoError(t, err)
+		assert.Nil(t, location, ip)
+	}
+	assert.Empty(t, r.calls)
+
+	location, err := Lookup(context.Background(), r, "8.8.8.8")
+	require.NoError(t, err)
+	assert.Equal(t, "US", location.CountryCode)
+
+	location, err = Lookup(context.Background(), nil, "8.8.8.8")
+	require.NoError(t, err)
+	assert.Nil(t, location)
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	r := newFakeResolver()
+	c := NewCache(r, 2, time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	// 命中缓存时不再查询
+	for range 3 {
+		location, err := c.Resolve(ctx, "8.8.8.8")
+		require.NoError(t, err)
+		assert.Equal(t, "Mountain View", location.City)
+	}
+	assert.Equal(t, 1, r.calls["8.8.8.8"])
+
+	// 查询不到的结果同样被缓存
+	for range 2 {
+		location, err := c.Resolve(ctx, "9.9.9.9")
+		require.NoError(t, err)
+		assert.Nil(t, location)
+	}
+	assert.Equal(t, 1, r.calls["9.9.9.9"])
+
+	// 超出容量时淘汰最久未使用的条目
+	_, err := c.Resolve(ctx, "1.1.1.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Len())
+	_, err = c.Resolve(ctx, "8.8.8.8")
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.calls["8.8.8.8"])
+
+	// 过期后重新查询
+	now = now.Add(time.Minute)
+	_, err = c.Resolve(ctx, "1.1.1.1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, r.calls["1.1.1.1"])
+}
+
+func TestCacheDoesNotCacheErrors(t *testing.T) {
+	r := newFakeResolver()
+	r.err = errors.New("network unreachable")
+	c := NewCache(r, 10, time.Minute)
+
+	for range 2 {
+		_, err := c.Resolve(context.Background(), "8.8.8.8")
+		assert.Error(t, err)
+	}
+	assert.Equal(t, 2, r.calls["8.8.8.8"])
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestNewMMDBResolverMissingFile(t *testing.T) {
+	_, err := NewMMDBResolver("testdata/missing.mmdb", "en")
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package geoip
+
+import (
+	"context"
+
+	"github.com/clin211/miniblog-v2/pkg/ipwho"
+)
+
+// 确保 *IPWhoResolver 实现了 Resolver 接口.
+var _ Resolver = (*IPWhoResolver)(nil)
+
+// IPWhoResolver 通过 ipwho.is 接口在线解析地理位置. 每次查询都是一次外部 HTTP 请求，
+// 建议通过 NewCache 包装后使用.
+type IPWhoResolver struct {
+	client *ipwho.Client
+}
+
+// NewIPWhoResolver 创建一个基于 ipwho.is 的解析器.
+func NewIPWhoResolver(client *ipwho.Client) *IPWhoResolver {
+	return &IPWhoResolver{client: client}
+}
+
+// Resolve 实现 Resolver 接口.
+func (r *IPWhoResolver) Resolve(ctx context.Context, ip string) (*Location, error) {
+	detail, err := r.client.GetIPDetail(ctx, ip)
+	if err != nil {
+		return nil, err
+	}
+	if !detail.Success {
+		return nil, nil
+	}
+	return &Location{
+		Country:     detail.Country,
+		CountryCode: detail.CountryCode,
+		City:        detail.City,
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// 确保 *MMDBResolver 实现了 Resolver 接口.
+var _ Resolver = (*MMDBResolver)(nil)
+
+// MMDBResolver 基于本地 mmdb 文件解析地理位置，兼容 MaxMind GeoLite2/GeoIP2 City、Country
+// 与 DB-IP Lite 等使用相同数据结构的数据库，查询不依赖网络.
+type MMDBResolver struct {
+	reader   *maxminddb.Reader
+	language string
+}
+
+// mmdbRecord 为 City/Country 数据库中需要的字段.
+type mmdbRecord struct {
+	Country struct {
+		ISOCode string            `maxminddb:"iso_code"`
+		Names   map[string]string `maxminddb:"names"`
+	} `maxminddb:"country"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+}
+
+// NewMMDBResolver 打开 path 指定的 mmdb 文件. language 为名称的语言（如 en、zh-CN），
+// 数据库中缺少该语言时回退到英文.
+func NewMMDBResolver(path string, language string) (*MMDBResolver, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open mmdb %q: %w", path, err)
+	}
+	if language == "" {
+		language = "en"
+	}
+	return &MMDBResolver{reader: reader, language: language}, nil
+}
+
+// Resolve 实现 Resolver 接口.
+func (r *MMDBResolver) Resolve(_ context.Context, ip string) (*Location, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("invalid ip address %q", ip)
+	}
+
+	var record mmdbRecord
+	_, ok, err := r.reader.LookupNetwork(parsed, &record)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &Location{
+		Country:     r.name(record.Country.Names),
+		CountryCode: record.Country.ISOCode,
+		City:        r.name(record.City.Names),
+	}, nil
+}
+
+// Close 关闭 mmdb 文件.
+func (r *MMDBResolver) Close() error {
+	return r.reader.Close()
+}
+
+// name 按配置的语言选取名称，缺失时回退到英文.
+func (r *MMDBResolver) name(names map[string]string) string {
+	if name, ok := names[r.language]; ok {
+		return name
+	}
+	return names["en"]
+}
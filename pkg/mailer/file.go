@@ -0,0 +1,50 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package mailer
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// 确保 *File 实现了 Mailer 接口.
+var _ Mailer = (*File)(nil)
+
+// File 将邮件以 .eml 文件的形式写入目录，用于本地开发时查看邮件内容.
+type File struct {
+	dir  string
+	from string
+}
+
+// NewFile 创建一个写入 dir 目录的发件器，目录不存在时自动创建.
+func NewFile(dir string, from string) (*File, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &File{dir: dir, from: from}, nil
+}
+
+// Send 实现 Mailer 接口.
+func (m *File) Send(_ context.Context, msg *Message) error {
+	msg = withDefaultFrom(msg, m.from)
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	// 文件名按时间排序，随机后缀避免同一时刻的邮件互相覆盖
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	name := time.Now().Format("20060102T150405.000000000") + "-" + hex.EncodeToString(b) + ".eml"
+	return os.WriteFile(filepath.Join(m.dir, name), data, 0o600)
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+// Package mailer 定义发送邮件的抽象，并提供 SMTP、文件与内存三种实现.
+// 文件与内存实现不真正发送邮件，分别用于本地开发与测试.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+)
+
+// Message 为一封待发送的邮件. Text 与 HTML 至少设置一个，同时设置时以 multipart/alternative 发送.
+type Message struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Mailer 为发送邮件的接口.
+type Mailer interface {
+	// Send 发送邮件，msg.From 为空时使用实现中配置的默认发件人
+	Send(ctx context.Context, msg *Message) error
+}
+
+// ErrNoRecipients 表示邮件没有收件人.
+var ErrNoRecipients = errors.New("mailer: message has no recipients")
+
+// validate 校验邮件的发件人与收件人地址.
+func (msg *Message) validate() error {
+	if len(msg.To) == 0 {
+		return ErrNoRecipients
+	}
+	if _, err := mail.ParseAddress(msg.From); err != nil {
+		return fmt.Errorf("mailer: invalid from address %q: %w", msg.From, err)
+	}
+	for _, to := range msg.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("mailer: invalid recipient address %q: %w", to, err)
+		}
+	}
+	return nil
+}
+
+// withDefaultFrom 返回设置了发件人的邮件副本.
+func withDefaultFrom(msg *Message, from string) *Message {
+	m := *msg
+	if m.From == "" {
+		m.From = from
+	}
+	return &m
+}
+
+// Bytes 将邮件编码为 RFC 5322 格式.
+func (msg *Message) Bytes() ([]byte, error) {
+	header := textproto.MIMEHeader{}
+	header.Set("From", msg.From)
+	header.Set("To", strings.Join(msg.To, ", "))
+	header.Set("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header.Set("Date", time.Now().Format(time.RFC1123Z))
+	header.Set("Message-ID", messageID(msg.From))
+	header.Set("MIME-Version", "1.0")
+
+	var body bytes.Buffer
+	if msg.Text == "" || msg.HTML == "" {
+		contentType, content := "text/plain; charset=utf-8", msg.Text
+		if msg.HTML != "" {
+			contentType, content = "text/html; charset=utf-8", msg.HTML
+		}
+		header.Set("Content-Type", contentType)
+		header.Set("Content-Transfer-Encoding", "quoted-printable")
+		if err := writeQuotedPrintable(&body, content); err != nil {
+			return nil, err
+		}
+	} else {
+		mw := multipart.NewWriter(&body)
+		header.Set("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+		for _, part := range []struct{ contentType, content string }{
+			{"text/plain; charset=utf-8", msg.Text},
+			{"text/html; charset=utf-8", msg.HTML},
+		} {
+			w, err := mw.CreatePart(textproto.MIMEHeader{
+				"Content-Type":              {part.contentType},
+				"Content-Transfer-Encoding": {"quoted-printable"},
+			})
+			if err != nil {
+				return nil, err
+			}
+			if err := writeQuotedPrintable(w, part.content); err != nil {
+				return nil, err
+			}
+		}
+		if err := mw.Close(); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, key := range []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type", "Content-Transfer-Encoding"} {
+		if value := header.Get(key); value != "" {
+			fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable 以 quoted-printable 编码写入正文.
+func writeQuotedPrintable(w io.Writer, content string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(content)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// messageID 生成邮件的 Message-ID，域名取自发件人地址.
+func messageID(from string) string {
+	domain := "localhost"
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if i := strings.LastIndex(addr.Address, "@"); i >= 0 {
+			domain = addr.Address[i+1:]
+		}
+	}
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(b), domain)
+}
@@ -0,0 +1,162 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package mailer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMessage() *Message {
+	return &Message{
+		To:      []string{"Alice <alice@example.com>"},
+		Subject: "验证你的邮箱",
+		Text:    "Hello alice",
+		HTML:    "<p>Hello <b>alice</b></p>",
+	}
+}
+
+func TestMessageBytes(t *testing.T) {
+	msg := withDefaultFrom(newTestMessage(), "MiniBlog <noreply@example.com>")
+	data, err := msg.Bytes()
+	require.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	require.NoError(t, err)
+	assert.Equal(t, "验证你的邮箱", subject)
+	assert.Contains(t, parsed.Header.Get("Message-ID"), "@example.com>")
+
+	// 同时包含纯文本与 HTML 两个分段
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var bodies []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{msg.Text, msg.HTML}, bodies)
+}
+
+func TestMemoryAndFile(t *testing.T) {
+	ctx := context.Background()
+
+	m := NewMemory("noreply@example.com")
+	require.NoError(t, m.Send(ctx, newTestMessage()))
+	assert.Len(t, m.Messages(), 1)
+	assert.Equal(t, "noreply@example.com", m.Last().From)
+	assert.ErrorIs(t, m.Send(ctx, &Message{Subject: "no recipients"}), ErrNoRecipients)
+	assert.Error(t, m.Send(ctx, &Message{To: []string{"not-an-address"}}))
+
+	dir := filepath.Join(t.TempDir(), "mail")
+	f, err := NewFile(dir, "noreply@example.com")
+	require.NoError(t, err)
+	require.NoError(t, f.Send(ctx, newTestMessage()))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.True(t, strings.HasSuffix(entries[0].Name(), ".eml"))
+}
+
+// fakeSMTPServer 为只支持最小命令集的 SMTP 服务器，记录收到的信封与邮件内容.
+func fakeSMTPServer(t *testing.T) (string, <-chan []string) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = io.WriteString(conn, line+"\r\n") }
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				reply("250 OK")
+			case "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil || l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				lines = append(lines, data.String())
+				reply("250 OK")
+			case "QUIT":
+				reply("221 Bye")
+				received <- lines
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+	return lis.Addr().String(), received
+}
+
+func TestSMTP(t *testing.T) {
+	addr, received := fakeSMTPServer(t)
+	host, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	portNum, err := net.LookupPort("tcp", port)
+	require.NoError(t, err)
+
+	m := NewSMTP(SMTPConfig{Host: host, Port: portNum, From: "MiniBlog <noreply@example.com>", TLS: TLSNone})
+	require.NoError(t, m.Send(context.Background(), newTestMessage()))
+
+	lines := <-received
+	require.Len(t, lines, 3)
+	assert.Equal(t, "MAIL FROM:<noreply@example.com>", strings.Split(lines[0], " BODY")[0])
+	assert.Equal(t, "RCPT TO:<alice@example.com>", lines[1])
+
+	parsed, err := mail.ReadMessage(strings.NewReader(lines[2]))
+	require.NoError(t, err)
+	assert.Equal(t, "MiniBlog <noreply@example.com>", parsed.Header.Get("From"))
+	body, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Hello alice")
+}
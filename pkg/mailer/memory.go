@@ -0,0 +1,58 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package mailer
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// 确保 *Memory 实现了 Mailer 接口.
+var _ Mailer = (*Memory)(nil)
+
+// Memory 将邮件保存在内存中，用于测试.
+type Memory struct {
+	from string
+
+	mu       sync.Mutex
+	messages []*Message
+}
+
+// NewMemory 创建一个保存在内存中的发件器.
+func NewMemory(from string) *Memory {
+	return &Memory{from: from}
+}
+
+// Send 实现 Mailer 接口.
+func (m *Memory) Send(_ context.Context, msg *Message) error {
+	msg = withDefaultFrom(msg, m.from)
+	if err := msg.validate(); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+// Messages 返回已发送的全部邮件.
+func (m *Memory) Messages() []*Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return slices.Clone(m.messages)
+}
+
+// Last 返回最后发送的邮件，没有邮件时返回 nil.
+func (m *Memory) Last() *Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.messages) == 0 {
+		return nil
+	}
+	return m.messages[len(m.messages)-1]
+}
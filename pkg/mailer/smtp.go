@@ -0,0 +1,148 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"time"
+)
+
+// SMTP 连接的加密方式.
+const (
+	// TLSNone 表示不加密，仅用于本地调试.
+	TLSNone = "none"
+	// TLSStartTLS 表示建立明文连接后通过 STARTTLS 升级，通常使用 587 端口.
+	TLSStartTLS = "starttls"
+	// TLSImplicit 表示直接建立 TLS 连接，通常使用 465 端口.
+	TLSImplicit = "tls"
+)
+
+// 确保 *SMTP 实现了 Mailer 接口.
+var _ Mailer = (*SMTP)(nil)
+
+// SMTPConfig 为 SMTP 服务器的连接配置.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	// From 为默认发件人
+	From string
+	// TLS 为连接的加密方式，默认为 TLSStartTLS
+	TLS string
+	// Timeout 为连接超时时间
+	Timeout time.Duration
+}
+
+// SMTP 通过 SMTP 服务器发送邮件，每封邮件使用一个新连接.
+type SMTP struct {
+	cfg SMTPConfig
+}
+
+// NewSMTP 创建一个 SMTP 发件器.
+func NewSMTP(cfg SMTPConfig) *SMTP {
+	if cfg.TLS == "" {
+		cfg.TLS = TLSStartTLS
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+	return &SMTP{cfg: cfg}
+}
+
+// Send 实现 Mailer 接口.
+func (m *SMTP) Send(ctx context.Context, msg *Message) error {
+	msg = withDefaultFrom(msg, m.cfg.From)
+	if err := msg.validate(); err != nil {
+		return err
+	}
+	data, err := msg.Bytes()
+	if err != nil {
+		return err
+	}
+
+	c, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if m.cfg.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)); err != nil {
+			return fmt.Errorf("smtp auth: %w", err)
+		}
+	}
+
+	// 信封中只使用邮箱地址，不包含显示名称
+	from, _ := mail.ParseAddress(msg.From)
+	if err := c.Mail(from.Address); err != nil {
+		return err
+	}
+	for _, to := range msg.To {
+		addr, _ := mail.ParseAddress(to)
+		if err := c.Rcpt(addr.Address); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// dial 连接 SMTP 服务器并按配置启用 TLS.
+func (m *SMTP) dial(ctx context.Context) (*smtp.Client, error) {
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	dialer := &net.Dialer{Timeout: m.cfg.Timeout}
+	tlsConfig := &tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if m.cfg.TLS == TLSImplicit {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 整个会话的读写都受超时控制
+	deadline := time.Now().Add(m.cfg.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	if m.cfg.TLS == TLSStartTLS {
+		if err := c.StartTLS(tlsConfig); err != nil {
+			_ = c.Close()
+			return nil, fmt.Errorf("smtp starttls: %w", err)
+		}
+	}
+	return c, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package options
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/clin211/miniblog-v2/pkg/clientip"
+	"github.com/clin211/miniblog-v2/pkg/geoip"
+	"github.com/clin211/miniblog-v2/pkg/ipwho"
+)
+
+var _ IOptions = (*GeoIPOptions)(nil)
+
+// GeoIPOptions 定义客户端 IP 解析与地理位置查询相关配置.
+type GeoIPOptions struct {
+	// Provider 为地理位置数据来源：none（不解析）、mmdb（离线数据库）、ipwho（在线接口）
+	Provider string `json:"provider" mapstructure:"provider"`
+	// MMDBPath 为 MaxMind GeoLite2/GeoIP2 或 DB-IP 的 mmdb 文件路径
+	MMDBPath string `json:"mmdb-path" mapstructure:"mmdb-path"`
+	// Language 为地名的语言，例如 en、zh-CN，仅 mmdb 生效
+	Language string `json:"language" mapstructure:"language"`
+	// Timeout 为 ipwho 单次查询的超时时间
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// CacheSize 为缓存的 IP 数量上限，0 表示不缓存
+	CacheSize int `json:"cache-size" mapstructure:"cache-size"`
+	// CacheTTL 为缓存有效期
+	CacheTTL time.Duration `json:"cache-ttl" mapstructure:"cache-ttl"`
+	// TrustedProxies 为受信任的代理网段，只有来自这些地址的请求才会采信 X-Forwarded-For 与 X-Real-IP
+	TrustedProxies []string `json:"trusted-proxies" mapstructure:"trusted-proxies"`
+}
+
+// NewGeoIPOptions 返回带默认值的 GeoIPOptions.
+func NewGeoIPOptions() *GeoIPOptions {
+	return &GeoIPOptions{
+		Provider:       "none",
+		Language:       "en",
+		Timeout:        2 * time.Second,
+		CacheSize:      10000,
+		CacheTTL:       time.Hour,
+		TrustedProxies: clientip.DefaultTrustedProxies,
+	}
+}
+
+// Validate 校验 GeoIPOptions 中的选项是否合法.
+func (o *GeoIPOptions) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	errs := []error{}
+
+	switch strings.ToLower(o.Provider) {
+	case "", "none", "ipwho":
+	case "mmdb":
+		if o.MMDBPath == "" {
+			errs = append(errs, fmt.Errorf("geoip.mmdb-path is required when provider is mmdb"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("geoip.provider must be one of none, mmdb, ipwho"))
+	}
+	if o.CacheSize < 0 {
+		errs = append(errs, fmt.Errorf("geoip.cache-size cannot be negative"))
+	}
+	if _, err := clientip.New(o.TrustedProxies); err != nil {
+		errs = append(errs, fmt.Errorf("geoip.trusted-proxies: %w", err))
+	}
+
+	return errs
+}
+
+// AddFlags 将 GeoIPOptions 相关的命令行参数添加到指定的 FlagSet 中.
+func (o *GeoIPOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Provider, "geoip.provider", o.Provider, "Client location provider, available options: none, mmdb, ipwho.")
+	fs.StringVar(&o.MMDBPath, "geoip.mmdb-path", o.MMDBPath, "Path to a MaxMind or DB-IP city/country mmdb file.")
+	fs.StringVar(&o.Language, "geoip.language", o.Language, "Language of location names read from the mmdb file.")
+	fs.DurationVar(&o.Timeout, "geoip.timeout", o.Timeout, "Timeout of a single ipwho lookup.")
+	fs.IntVar(&o.CacheSize, "geoip.cache-size", o.CacheSize, "Maximum number of cached IP locations, 0 disables the cache.")
+	fs.DurationVar(&o.CacheTTL, "geoip.cache-ttl", o.CacheTTL, "Time to live of a cached IP location.")
+	fs.StringSliceVar(&o.TrustedProxies, "geoip.trusted-proxies", o.TrustedProxies, ""+
+		"Trusted proxy IPs or CIDRs whose X-Forwarded-For and X-Real-IP headers are honoured.")
+}
+
+// NewResolver 根据配置创建地理位置解析器，Provider 为 none 时返回 nil.
+func (o *GeoIPOptions) NewResolver() (geoip.Resolver, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	var resolver geoip.Resolver
+	switch strings.ToLower(o.Provider) {
+	case "mmdb":
+		r, err := geoip.NewMMDBResolver(o.MMDBPath, o.Language)
+		if err != nil {
+			return nil, err
+		}
+		resolver = r
+	case "ipwho":
+		resolver = geoip.NewIPWhoResolver(ipwho.NewClient(ipwho.WithTimeout(o.Timeout)))
+	default:
+		return nil, nil
+	}
+
+	if o.CacheSize > 0 {
+		resolver = geoip.NewCache(resolver, o.CacheSize, o.CacheTTL)
+	}
+	return resolver, nil
+}
+
+// NewClientIPExtractor 根据受信任的代理配置创建客户端 IP 解析器.
+func (o *GeoIPOptions) NewClientIPExtractor() (*clientip.Extractor, error) {
+	if o == nil {
+		return clientip.New(clientip.DefaultTrustedProxies)
+	}
+	return clientip.New(o.TrustedProxies)
+}
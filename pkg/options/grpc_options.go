@@ -6,6 +6,7 @@
 package options
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -24,6 +25,18 @@ type GRPCOptions struct {
 
 	// Timeout with server timeout. Used by grpc client side.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// EnableReflection registers the gRPC server reflection service so that tools
+	// like grpcurl can discover services without proto files.
+	EnableReflection bool `json:"enable-reflection" mapstructure:"enable-reflection"`
+
+	// HealthCheckInterval is the interval between dependency checks that drive
+	// the grpc.health.v1 serving status.
+	HealthCheckInterval time.Duration `json:"health-check-interval" mapstructure:"health-check-interval"`
+
+	// EnableGRPCWeb accepts gRPC-Web requests from browsers. Only takes effect
+	// in the combined server mode.
+	EnableGRPCWeb bool `json:"enable-grpc-web" mapstructure:"enable-grpc-web"`
 }
 
 // NewGRPCOptions is for creating an unauthenticated, unauthorized, insecure port.
@@ -33,6 +46,10 @@ func NewGRPCOptions() *GRPCOptions {
 		Network: "tcp",
 		Addr:    "0.0.0.0:39090",
 		Timeout: 30 * time.Second,
+
+		EnableReflection:    false,
+		HealthCheckInterval: 10 * time.Second,
+		EnableGRPCWeb:       false,
 	}
 }
 
@@ -45,6 +62,10 @@ func (o *GRPCOptions) Validate() []error {
 		errors = append(errors, err)
 	}
 
+	if o.HealthCheckInterval < time.Second {
+		errors = append(errors, fmt.Errorf("--grpc.health-check-interval must be at least 1s, got %s", o.HealthCheckInterval))
+	}
+
 	return errors
 }
 
@@ -54,4 +75,7 @@ func (o *GRPCOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
 	fs.StringVar(&o.Network, "grpc.network", o.Network, "Specify the network for the gRPC server.")
 	fs.StringVar(&o.Addr, "grpc.addr", o.Addr, "Specify the gRPC server bind address and port.")
 	fs.DurationVar(&o.Timeout, "grpc.timeout", o.Timeout, "Timeout for server connections.")
+	fs.BoolVar(&o.EnableReflection, "grpc.enable-reflection", o.EnableReflection, "Register the gRPC server reflection service.")
+	fs.DurationVar(&o.HealthCheckInterval, "grpc.health-check-interval", o.HealthCheckInterval, "Interval between dependency checks of the gRPC health service.")
+	fs.BoolVar(&o.EnableGRPCWeb, "grpc.enable-grpc-web", o.EnableGRPCWeb, "Accept gRPC-Web requests in the combined server mode.")
 }
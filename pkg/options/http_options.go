@@ -23,6 +23,17 @@ type HTTPOptions struct {
 
 	// Timeout with server timeout. Used by http client side.
 	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+
+	// EnumAsString renders enum values by name instead of number in grpc-gateway
+	// responses.
+	EnumAsString bool `json:"enum-as-string" mapstructure:"enum-as-string"`
+
+	// UseProtoNames renders field names as declared in the proto files instead of
+	// their lowerCamelCase JSON names in grpc-gateway responses.
+	UseProtoNames bool `json:"use-proto-names" mapstructure:"use-proto-names"`
+
+	// EmitUnpopulated renders fields with zero values in grpc-gateway responses.
+	EmitUnpopulated bool `json:"emit-unpopulated" mapstructure:"emit-unpopulated"`
 }
 
 // NewHTTPOptions creates a HTTPOptions object with default parameters.
@@ -56,6 +67,9 @@ func (o *HTTPOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
 	fs.StringVar(&o.Network, "http.network", o.Network, "Specify the network for the HTTP server.")
 	fs.StringVar(&o.Addr, "http.addr", o.Addr, "Specify the HTTP server bind address and port.")
 	fs.DurationVar(&o.Timeout, "http.timeout", o.Timeout, "Timeout for server connections.")
+	fs.BoolVar(&o.EnumAsString, "http.enum-as-string", o.EnumAsString, "Render enum values by name instead of number in grpc-gateway responses.")
+	fs.BoolVar(&o.UseProtoNames, "http.use-proto-names", o.UseProtoNames, "Use the original proto field names instead of lowerCamelCase JSON names in grpc-gateway responses.")
+	fs.BoolVar(&o.EmitUnpopulated, "http.emit-unpopulated", o.EmitUnpopulated, "Render fields with zero values in grpc-gateway responses.")
 }
 
 // Complete fills in any fields not set that are required to have valid data.
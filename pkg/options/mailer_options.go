@@ -0,0 +1,126 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package options
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/clin211/miniblog-v2/pkg/mailer"
+)
+
+var _ IOptions = (*MailerOptions)(nil)
+
+// MailerOptions 定义发送邮件相关配置.
+type MailerOptions struct {
+	// Provider 为发件方式：none（不发送）、smtp、file（写入本地目录）、memory（仅保存在内存中）
+	Provider string `json:"provider" mapstructure:"provider"`
+	// From 为默认发件人，例如 "MiniBlog <noreply@example.com>"
+	From string `json:"from" mapstructure:"from"`
+	// Host 与 Port 为 SMTP 服务器地址
+	Host string `json:"host" mapstructure:"host"`
+	Port int    `json:"port" mapstructure:"port"`
+	// Username 与 Password 为 SMTP 认证信息，Username 为空时不认证
+	Username string `json:"username" mapstructure:"username"`
+	Password string `json:"password" mapstructure:"password"`
+	// TLS 为 SMTP 连接的加密方式：starttls、tls、none
+	TLS string `json:"tls" mapstructure:"tls"`
+	// Timeout 为发送单封邮件的超时时间
+	Timeout time.Duration `json:"timeout" mapstructure:"timeout"`
+	// Dir 为 file 方式下邮件的保存目录
+	Dir string `json:"dir" mapstructure:"dir"`
+}
+
+// NewMailerOptions 返回带默认值的 MailerOptions.
+func NewMailerOptions() *MailerOptions {
+	return &MailerOptions{
+		Provider: "none",
+		From:     "MiniBlog <noreply@localhost>",
+		Port:     587,
+		TLS:      mailer.TLSStartTLS,
+		Timeout:  10 * time.Second,
+		Dir:      "_output/mails",
+	}
+}
+
+// Validate 校验 MailerOptions 中的选项是否合法.
+func (o *MailerOptions) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	errs := []error{}
+
+	provider := strings.ToLower(o.Provider)
+	switch provider {
+	case "", "none", "memory":
+	case "smtp":
+		if o.Host == "" {
+			errs = append(errs, fmt.Errorf("mailer.host is required when provider is smtp"))
+		}
+		switch o.TLS {
+		case "", mailer.TLSStartTLS, mailer.TLSImplicit, mailer.TLSNone:
+		default:
+			errs = append(errs, fmt.Errorf("mailer.tls must be one of starttls, tls, none"))
+		}
+	case "file":
+		if o.Dir == "" {
+			errs = append(errs, fmt.Errorf("mailer.dir is required when provider is file"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("mailer.provider must be one of none, smtp, file, memory"))
+	}
+	if provider != "" && provider != "none" {
+		if _, err := mail.ParseAddress(o.From); err != nil {
+			errs = append(errs, fmt.Errorf("mailer.from: %w", err))
+		}
+	}
+
+	return errs
+}
+
+// AddFlags 将 MailerOptions 相关的命令行参数添加到指定的 FlagSet 中.
+func (o *MailerOptions) AddFlags(fs *pflag.FlagSet, prefixes ...string) {
+	fs.StringVar(&o.Provider, "mailer.provider", o.Provider, "Mail delivery method, available options: none, smtp, file, memory.")
+	fs.StringVar(&o.From, "mailer.from", o.From, "Default sender address of outgoing mails.")
+	fs.StringVar(&o.Host, "mailer.host", o.Host, "SMTP server host.")
+	fs.IntVar(&o.Port, "mailer.port", o.Port, "SMTP server port.")
+	fs.StringVar(&o.Username, "mailer.username", o.Username, "SMTP username, authentication is skipped when empty.")
+	fs.StringVar(&o.Password, "mailer.password", o.Password, "SMTP password.")
+	fs.StringVar(&o.TLS, "mailer.tls", o.TLS, "SMTP connection security, available options: starttls, tls, none.")
+	fs.DurationVar(&o.Timeout, "mailer.timeout", o.Timeout, "Timeout of sending a single mail.")
+	fs.StringVar(&o.Dir, "mailer.dir", o.Dir, "Directory that mails are written to when provider is file.")
+}
+
+// NewMailer 根据配置创建发件器，Provider 为 none 时返回 nil.
+func (o *MailerOptions) NewMailer() (mailer.Mailer, error) {
+	if o == nil {
+		return nil, nil
+	}
+
+	switch strings.ToLower(o.Provider) {
+	case "smtp":
+		return mailer.NewSMTP(mailer.SMTPConfig{
+			Host:     o.Host,
+			Port:     o.Port,
+			Username: o.Username,
+			Password: o.Password,
+			From:     o.From,
+			TLS:      o.TLS,
+			Timeout:  o.Timeout,
+		}), nil
+	case "file":
+		return mailer.NewFile(o.Dir, o.From)
+	case "memory":
+		return mailer.NewMemory(o.From), nil
+	default:
+		return nil, nil
+	}
+}
@@ -7,6 +7,7 @@ package options
 
 import (
 	"fmt"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -16,13 +17,30 @@ import (
 // UploadOptions 定义上传相关配置。
 type UploadOptions struct {
 	Provider     string           `json:"provider" mapstructure:"provider"`
-	MaxSize      string           `json:"maxSize" mapstructure:"maxSize"` // e.g. "20MB"
-	AllowedMIMEs []string         `json:"allowedMIMEs" mapstructure:"allowedMIMEs"`
-	Deduplicate  bool             `json:"deduplicate" mapstructure:"deduplicate"`
-	KeyTemplate  string           `json:"keyTemplate" mapstructure:"keyTemplate"`
+	MaxSize      string           `json:"maxSize" mapstructure:"maxSize"`           // e.g. "20MB"
+	AllowedMIMEs []string         `json:"allowedMIMEs" mapstructure:"allowedMIMEs"` // 支持 image/* 通配，留空不限制
+	Deduplicate  bool             `json:"deduplicate" mapstructure:"deduplicate"`   // 按 sha256 复用已上传的对象
+	KeyTemplate  string           `json:"keyTemplate" mapstructure:"keyTemplate"`   // 对象键模板，见 uploader.ParseKeyTemplate
 	Local        *LocalOptions    `json:"local" mapstructure:"local"`
 	AliOSS       *AliOSSOptions   `json:"alioss" mapstructure:"alioss"`
+	S3           *S3Options       `json:"s3" mapstructure:"s3"`
 	Multipart    *MultipartConfig `json:"multipart" mapstructure:"multipart"`
+	Image        *ImageOptions    `json:"image" mapstructure:"image"`
+	GC           *MediaGCOptions  `json:"gc" mapstructure:"gc"`
+	Quota        *QuotaOptions    `json:"quota" mapstructure:"quota"`
+	Scan         *ScanOptions     `json:"scan" mapstructure:"scan"`
+	Tus          *TusOptions      `json:"tus" mapstructure:"tus"`
+
+	// Scenes 按上传场景（UploadFileRequest.scene）覆盖 MaxSize 与 AllowedMIMEs，未配置的场景使用全局值
+	Scenes map[string]*SceneOptions `json:"scenes" mapstructure:"scenes"`
+}
+
+// SceneOptions 定义单个上传场景的限制，字段留空时回退到全局配置。
+type SceneOptions struct {
+	MaxSize      string   `json:"maxSize" mapstructure:"maxSize"`
+	AllowedMIMEs []string `json:"allowedMIMEs" mapstructure:"allowedMIMEs"`
+	// ACL 覆盖该场景对象的访问权限（private、public-read），目前仅 local 存储生效
+	ACL string `json:"acl" mapstructure:"acl"`
 }
 
 type LocalOptions struct {
@@ -30,6 +48,13 @@ type LocalOptions struct {
 	BasePath  string `json:"basePath" mapstructure:"basePath"`
 	BaseURL   string `json:"baseURL" mapstructure:"baseURL"`
 	MkdirPerm uint32 `json:"mkdirPerm" mapstructure:"mkdirPerm"`
+	// ACL 为对象的默认访问权限，语义与 OSS 一致：public-read（公开读）或 private（需要签名 URL），
+	// public-read-write 按 public-read 处理
+	ACL string `json:"acl" mapstructure:"acl"`
+	// SignKey 为签名 URL 使用的 HMAC 密钥，存在私有对象时必填
+	SignKey string `json:"signKey" mapstructure:"signKey"`
+	// URLExpires 为签名 URL 的有效期
+	URLExpires string `json:"urlExpires" mapstructure:"urlExpires"`
 }
 
 type AliOSSOptions struct {
@@ -44,6 +69,104 @@ type AliOSSOptions struct {
 	CallbackURL     string `json:"callbackURL" mapstructure:"callbackURL"`
 }
 
+// S3Options 定义 S3 兼容对象存储（AWS S3、MinIO、Ceph RGW 等）的配置。
+type S3Options struct {
+	// Endpoint 为服务地址，可带协议前缀，例如 s3.amazonaws.com、http://127.0.0.1:9000
+	Endpoint        string `json:"endpoint" mapstructure:"endpoint"`
+	Region          string `json:"region" mapstructure:"region"`
+	Bucket          string `json:"bucket" mapstructure:"bucket"`
+	AccessKeyID     string `json:"accessKeyID" mapstructure:"accessKeyID"`
+	SecretAccessKey string `json:"secretAccessKey" mapstructure:"secretAccessKey"`
+	SessionToken    string `json:"sessionToken" mapstructure:"sessionToken"`
+	// UseSSL 仅在 Endpoint 不带协议前缀时生效
+	UseSSL          bool `json:"useSSL" mapstructure:"useSSL"`
+	PathStyleAccess bool `json:"pathStyleAccess" mapstructure:"pathStyleAccess"`
+	// ACL 为写入对象时附带的 canned ACL，如 private、public-read；留空使用 bucket 默认策略
+	ACL string `json:"acl" mapstructure:"acl"`
+	// Prefix 为所有对象键的公共前缀，便于多个服务共用一个 bucket
+	Prefix string `json:"prefix" mapstructure:"prefix"`
+	// BaseURL 为对外访问前缀（如 CDN 域名）；留空时使用 Endpoint 拼接
+	BaseURL       string `json:"baseURL" mapstructure:"baseURL"`
+	UploadTimeout string `json:"uploadTimeout" mapstructure:"uploadTimeout"`
+}
+
+// ImageOptions 定义上传图片的后处理：去除元数据与生成衍生图，全部以纯 Go 实现。
+type ImageOptions struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// StripMetadata 为 true 时去除原图中的 EXIF/XMP（含 GPS 位置）等元数据，并按 EXIF 方向摆正图片
+	StripMetadata bool `json:"stripMetadata" mapstructure:"stripMetadata"`
+	// Variants 为等比缩放的衍生图，原图不超过目标尺寸时不生成，直接复用原图
+	Variants []*ImageVariant `json:"variants" mapstructure:"variants"`
+	// WebP 为 true 时为每个衍生图额外生成一份 WebP（无损）
+	WebP bool `json:"webp" mapstructure:"webp"`
+	// Quality 为衍生图与摆正后原图的 JPEG 质量（1-100）
+	Quality int `json:"quality" mapstructure:"quality"`
+	// MaxPixels 为参与解码的最大像素数，超过时跳过衍生图生成，防止解压炸弹
+	MaxPixels int64 `json:"maxPixels" mapstructure:"maxPixels"`
+}
+
+// MediaGCOptions 定义孤儿文件回收：定期删除超过宽限期且未被任何文章或用户引用的上传文件。
+type MediaGCOptions struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Interval 为两次回收之间的间隔
+	Interval string `json:"interval" mapstructure:"interval"`
+	// GracePeriod 为上传后的保留时间，避免删除刚上传、尚未被文章保存引用的文件
+	GracePeriod string `json:"gracePeriod" mapstructure:"gracePeriod"`
+	// BatchSize 为每批检查的文件记录数
+	BatchSize int `json:"batchSize" mapstructure:"batchSize"`
+}
+
+// QuotaOptions 定义按用户统计的上传配额，用量记录在 Redis 中。大小为 0 或留空表示不限制。
+type QuotaOptions struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Storage 按角色（如 role::user、role::contributor）配置总存储配额；用户拥有多个角色时取最宽松的配额
+	Storage map[string]string `json:"storage" mapstructure:"storage"`
+	// DefaultStorage 为未配置角色的用户的总存储配额
+	DefaultStorage string `json:"defaultStorage" mapstructure:"defaultStorage"`
+	// DailyLimit 为每个用户每天（UTC）可上传的字节数
+	DailyLimit string `json:"dailyLimit" mapstructure:"dailyLimit"`
+	// UsageCacheTTL 为存储用量在 Redis 中的缓存时间，过期后按上传记录重新统计
+	UsageCacheTTL string `json:"usageCacheTTL" mapstructure:"usageCacheTTL"`
+}
+
+// ScanOptions 定义上传内容扫描：文件写入最终对象键之前依次执行扫描器，命中的文件移入隔离目录。
+type ScanOptions struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// Scanners 为按顺序执行的扫描器：rules（内置规则）、clamd（ClamAV）
+	Scanners []string `json:"scanners" mapstructure:"scanners"`
+	// FailOpen 为 true 时扫描器不可用只记录日志并放行，否则拒绝上传
+	FailOpen bool `json:"failOpen" mapstructure:"failOpen"`
+	// QuarantineDir 为隔离文件的本地目录，与对象存储后端无关
+	QuarantineDir string `json:"quarantineDir" mapstructure:"quarantineDir"`
+	// BlockedExtensions 为内置规则拒绝的文件扩展名（按客户端提供的文件名判断）
+	BlockedExtensions []string       `json:"blockedExtensions" mapstructure:"blockedExtensions"`
+	ClamAV            *ClamAVOptions `json:"clamav" mapstructure:"clamav"`
+}
+
+// ClamAVOptions 定义 clamd 的连接方式。
+type ClamAVOptions struct {
+	// Address 为 clamd 地址，如 tcp://127.0.0.1:3310、unix:///var/run/clamav/clamd.ctl
+	Address string `json:"address" mapstructure:"address"`
+	Timeout string `json:"timeout" mapstructure:"timeout"`
+}
+
+// TusOptions 定义 tus 1.0 可续传上传端点，上传完成后的文件交给配置的 Uploader 处理。
+type TusOptions struct {
+	Enabled bool `json:"enabled" mapstructure:"enabled"`
+	// TempDir 为未完成上传的本地暂存目录，多副本部署时需要共享或按会话粘滞路由.
+	// 暂存的内容尚未经过扫描，不能位于 local 存储的 BaseDir 之内
+	TempDir string `json:"tempDir" mapstructure:"tempDir"`
+	// Expiration 为上传创建后的有效期，过期未完成的上传会被清理
+	Expiration string `json:"expiration" mapstructure:"expiration"`
+}
+
+// ImageVariant 定义一个衍生图规格，图片按比例缩放到不超过 Width x Height，0 表示该方向不限制。
+type ImageVariant struct {
+	Name   string `json:"name" mapstructure:"name"`
+	Width  int    `json:"width" mapstructure:"width"`
+	Height int    `json:"height" mapstructure:"height"`
+}
+
 type MultipartConfig struct {
 	Enabled                 bool   `json:"enabled" mapstructure:"enabled"`
 	MinSize                 string `json:"minSize" mapstructure:"minSize"`
@@ -66,19 +189,30 @@ func NewUploadOptions() *UploadOptions {
 		Provider:     "local",
 		MaxSize:      "20MB",
 		AllowedMIMEs: []string{"image/jpeg", "image/png", "image/gif", "image/webp", "application/pdf"},
-		Deduplicate:  true,
-		KeyTemplate:  "{date:2006/01/02}/{sha256:16}{ext}",
+		Scenes: map[string]*SceneOptions{
+			"avatar": {MaxSize: "2MB", AllowedMIMEs: []string{"image/jpeg", "image/png", "image/webp"}, ACL: "public-read"},
+		},
+		Deduplicate: true,
+		KeyTemplate: "{date:2006/01/02}/{sha256:16}{ext}",
 		Local: &LocalOptions{
-			BaseDir:   "/data/miniblog/uploads",
-			BasePath:  "/static/uploads",
-			BaseURL:   "http://localhost:5555/static/uploads",
-			MkdirPerm: 0755,
+			BaseDir:    "/data/miniblog/uploads",
+			BasePath:   "/static/uploads",
+			BaseURL:    "http://localhost:5555/static/uploads",
+			MkdirPerm:  0755,
+			ACL:        "public-read",
+			URLExpires: "15m",
 		},
 		AliOSS: &AliOSSOptions{
 			ACL:             "private",
 			PathStyleAccess: false,
 			UploadTimeout:   "30s",
 		},
+		S3: &S3Options{
+			Region:          "us-east-1",
+			UseSSL:          true,
+			PathStyleAccess: true,
+			UploadTimeout:   "30s",
+		},
 		Multipart: &MultipartConfig{
 			Enabled:                 true,
 			MinSize:                 "8MB",
@@ -94,9 +228,273 @@ func NewUploadOptions() *UploadOptions {
 			PresignExpires:          "15m",
 			PresignMode:             "direct",
 		},
+		Image: &ImageOptions{
+			Enabled:       true,
+			StripMetadata: true,
+			Variants: []*ImageVariant{
+				{Name: "thumb", Width: 320, Height: 320},
+				{Name: "medium", Width: 1280, Height: 1280},
+			},
+			WebP:      false,
+			Quality:   85,
+			MaxPixels: 50_000_000,
+		},
+		GC: &MediaGCOptions{
+			Enabled:     false,
+			Interval:    "1h",
+			GracePeriod: "72h",
+			BatchSize:   200,
+		},
+		Quota: &QuotaOptions{
+			Enabled: false,
+			Storage: map[string]string{
+				"role::contributor": "200MB",
+				"role::user":        "1GB",
+				"role::admin":       "0",
+			},
+			DefaultStorage: "200MB",
+			DailyLimit:     "500MB",
+			UsageCacheTTL:  "10m",
+		},
+		Scan: &ScanOptions{
+			Enabled:       false,
+			Scanners:      []string{"rules"},
+			FailOpen:      false,
+			QuarantineDir: "/data/miniblog/quarantine",
+			BlockedExtensions: []string{
+				".exe", ".dll", ".com", ".scr", ".msi", ".bat", ".cmd", ".ps1", ".vbs", ".jar", ".apk", ".sh",
+			},
+			ClamAV: &ClamAVOptions{
+				Address: "tcp://127.0.0.1:3310",
+				Timeout: "30s",
+			},
+		},
+		Tus: &TusOptions{
+			Enabled:    true,
+			TempDir:    "/data/miniblog/tus",
+			Expiration: "24h",
+		},
 	}
 }
 
+// Validate 校验上传配置是否合法。
+func (o *UploadOptions) Validate() []error {
+	if o == nil {
+		return nil
+	}
+
+	errs := []error{}
+
+	if _, err := ParseSize(o.MaxSize); err != nil {
+		errs = append(errs, fmt.Errorf("upload.maxSize: %w", err))
+	}
+	for name, scene := range o.Scenes {
+		if scene == nil {
+			continue
+		}
+		if _, err := ParseSize(scene.MaxSize); err != nil {
+			errs = append(errs, fmt.Errorf("upload.scenes.%s.maxSize: %w", name, err))
+		}
+		if !validACL(scene.ACL) {
+			errs = append(errs, fmt.Errorf("upload.scenes.%s.acl must be one of private, public-read, public-read-write", name))
+		}
+	}
+
+	switch strings.ToLower(o.Provider) {
+	case "", "local":
+		if l := o.Local; l != nil {
+			if !validACL(l.ACL) {
+				errs = append(errs, fmt.Errorf("upload.local.acl must be one of private, public-read, public-read-write"))
+			}
+			if o.HasPrivateLocalObjects() {
+				if len(l.SignKey) < 16 {
+					errs = append(errs, fmt.Errorf("upload.local.signKey must be at least 16 characters when private objects exist"))
+				}
+				if d, err := ParseDuration(l.URLExpires); err != nil || d <= 0 {
+					errs = append(errs, fmt.Errorf("upload.local.urlExpires must be a positive duration"))
+				}
+			}
+		}
+	case "s3":
+		if o.S3 == nil || o.S3.Endpoint == "" || o.S3.Bucket == "" {
+			errs = append(errs, fmt.Errorf("upload.s3.endpoint and upload.s3.bucket are required when provider is s3"))
+		} else if _, err := ParseDuration(o.S3.UploadTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("upload.s3.uploadTimeout: %w", err))
+		}
+	case "alioss":
+		if o.AliOSS == nil || o.AliOSS.Endpoint == "" || o.AliOSS.Bucket == "" {
+			errs = append(errs, fmt.Errorf("upload.alioss.endpoint and upload.alioss.bucket are required when provider is alioss"))
+		} else if _, err := ParseDuration(o.AliOSS.UploadTimeout); err != nil {
+			errs = append(errs, fmt.Errorf("upload.alioss.uploadTimeout: %w", err))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("upload.provider must be one of local, s3, alioss"))
+	}
+
+	if m := o.Multipart; m != nil && m.Enabled {
+		sizes := map[string]int64{}
+		for name, value := range map[string]string{
+			"minSize":          m.MinSize,
+			"partSize.min":     m.PartSizeMin,
+			"partSize.max":     m.PartSizeMax,
+			"partSize.default": m.PartSizeDefault,
+		} {
+			n, err := ParseSize(value)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("upload.multipart.%s: %w", name, err))
+				continue
+			}
+			sizes[name] = n
+		}
+		if lo, hi := sizes["partSize.min"], sizes["partSize.max"]; lo > 0 && hi > 0 && lo > hi {
+			errs = append(errs, fmt.Errorf("upload.multipart.partSize.min (%d) must not exceed partSize.max (%d)", lo, hi))
+		}
+		if def, lo, hi := sizes["partSize.default"], sizes["partSize.min"], sizes["partSize.max"]; def > 0 && ((lo > 0 && def < lo) || (hi > 0 && def > hi)) {
+			errs = append(errs, fmt.Errorf("upload.multipart.partSize.default (%d) must be between partSize.min and partSize.max", def))
+		}
+		if m.MaxParts < 0 {
+			errs = append(errs, fmt.Errorf("upload.multipart.maxParts must not be negative"))
+		}
+		for name, value := range map[string]string{"uploadIdTTL": m.UploadIDTTL, "presign.expires": m.PresignExpires} {
+			if _, err := ParseDuration(value); err != nil {
+				errs = append(errs, fmt.Errorf("upload.multipart.%s: %w", name, err))
+			}
+		}
+		switch strings.ToLower(m.PresignMode) {
+		case "", "direct", "proxy":
+		default:
+			errs = append(errs, fmt.Errorf("upload.multipart.presign.mode must be one of direct, proxy"))
+		}
+		switch strings.ToLower(m.Checksum) {
+		case "", "sha256", "md5":
+		default:
+			errs = append(errs, fmt.Errorf("upload.multipart.checksum must be one of sha256, md5"))
+		}
+	}
+
+	if img := o.Image; img != nil && img.Enabled {
+		if img.Quality < 1 || img.Quality > 100 {
+			errs = append(errs, fmt.Errorf("upload.image.quality must be between 1 and 100"))
+		}
+		if img.MaxPixels < 0 {
+			errs = append(errs, fmt.Errorf("upload.image.maxPixels must not be negative"))
+		}
+		names := map[string]bool{}
+		for i, v := range img.Variants {
+			switch {
+			case v == nil || !imageVariantNameRegex.MatchString(v.Name):
+				errs = append(errs, fmt.Errorf("upload.image.variants[%d].name must match %s", i, imageVariantNameRegex))
+			case names[v.Name]:
+				errs = append(errs, fmt.Errorf("upload.image.variants[%d].name %q is duplicated", i, v.Name))
+			case v.Width < 0 || v.Height < 0 || v.Width+v.Height == 0:
+				errs = append(errs, fmt.Errorf("upload.image.variants[%d] (%s) requires a positive width or height", i, v.Name))
+			}
+			if v != nil {
+				names[v.Name] = true
+			}
+		}
+	}
+
+	if gc := o.GC; gc != nil && gc.Enabled {
+		for name, value := range map[string]string{"interval": gc.Interval, "gracePeriod": gc.GracePeriod} {
+			if d, err := ParseDuration(value); err != nil || d <= 0 {
+				errs = append(errs, fmt.Errorf("upload.gc.%s must be a positive duration", name))
+			}
+		}
+		if gc.BatchSize <= 0 {
+			errs = append(errs, fmt.Errorf("upload.gc.batchSize must be positive"))
+		}
+	}
+
+	if q := o.Quota; q != nil && q.Enabled {
+		for role, value := range q.Storage {
+			if _, err := ParseSize(value); err != nil {
+				errs = append(errs, fmt.Errorf("upload.quota.storage.%s: %w", role, err))
+			}
+		}
+		for name, value := range map[string]string{"defaultStorage": q.DefaultStorage, "dailyLimit": q.DailyLimit} {
+			if _, err := ParseSize(value); err != nil {
+				errs = append(errs, fmt.Errorf("upload.quota.%s: %w", name, err))
+			}
+		}
+		if _, err := ParseDuration(q.UsageCacheTTL); err != nil {
+			errs = append(errs, fmt.Errorf("upload.quota.usageCacheTTL: %w", err))
+		}
+	}
+
+	if sc := o.Scan; sc != nil && sc.Enabled {
+		if sc.QuarantineDir == "" {
+			errs = append(errs, fmt.Errorf("upload.scan.quarantineDir is required when scan is enabled"))
+		}
+		for i, name := range sc.Scanners {
+			switch name {
+			case "rules":
+			case "clamd":
+				if sc.ClamAV == nil || sc.ClamAV.Address == "" {
+					errs = append(errs, fmt.Errorf("upload.scan.clamav.address is required when clamd scanner is enabled"))
+				} else if _, err := ParseDuration(sc.ClamAV.Timeout); err != nil {
+					errs = append(errs, fmt.Errorf("upload.scan.clamav.timeout: %w", err))
+				}
+			default:
+				errs = append(errs, fmt.Errorf("upload.scan.scanners[%d] must be one of rules, clamd", i))
+			}
+		}
+	}
+
+	if t := o.Tus; t != nil && t.Enabled {
+		if t.TempDir == "" {
+			errs = append(errs, fmt.Errorf("upload.tus.tempDir is required when tus is enabled"))
+		} else if o.Local != nil && o.Local.BaseDir != "" && isWithinDir(t.TempDir, o.Local.BaseDir) {
+			errs = append(errs, fmt.Errorf("upload.tus.tempDir must not be inside upload.local.baseDir"))
+		}
+		if d, err := ParseDuration(t.Expiration); err != nil || d <= 0 {
+			errs = append(errs, fmt.Errorf("upload.tus.expiration must be a positive duration"))
+		}
+	}
+
+	return errs
+}
+
+// isWithinDir 返回 path 是否为 dir 或位于 dir 之内.
+func isWithinDir(path string, dir string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(absDir, absPath)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// HasPrivateLocalObjects 返回 local 存储中是否存在私有对象：默认 ACL 或任一场景的 ACL 为 private。
+// 存在私有对象时不再将 BaseDir 作为静态目录公开，改由服务端校验签名后提供下载。
+func (o *UploadOptions) HasPrivateLocalObjects() bool {
+	if o.Local != nil && strings.EqualFold(o.Local.ACL, "private") {
+		return true
+	}
+	for _, scene := range o.Scenes {
+		if scene != nil && strings.EqualFold(scene.ACL, "private") {
+			return true
+		}
+	}
+	return false
+}
+
+// validACL 校验对象访问权限，留空表示继承上一级配置。
+func validACL(acl string) bool {
+	switch strings.ToLower(acl) {
+	case "", "private", "public-read", "public-read-write":
+		return true
+	}
+	return false
+}
+
+// imageVariantNameRegex 限制衍生图名称，名称会出现在对象键与元数据中。
+var imageVariantNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+
 // ParseSize 解析如 20MB/8MB/1GB 等表示，返回字节数。
 func ParseSize(s string) (int64, error) {
 	s = strings.TrimSpace(strings.ToUpper(s))
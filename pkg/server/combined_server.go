@@ -0,0 +1,115 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
+	"google.golang.org/grpc"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+)
+
+// CombinedServer 在同一个端口上同时提供 HTTP REST、gRPC 与可选的 gRPC-Web 服务.
+//
+// 按请求区分协议：
+//   - HTTP/2 且 Content-Type 为 application/grpc 的请求交给 gRPC 服务器；
+//   - 开启 gRPC-Web 时，Content-Type 为 application/grpc-web 的请求转换后交给 gRPC 服务器；
+//   - 其余请求交给 HTTP 处理器.
+//
+// 未开启 TLS 时通过 h2c（明文 HTTP/2）接收 gRPC 请求.
+type CombinedServer struct {
+	srv  *http.Server
+	grpc *GRPCServer
+}
+
+// NewCombinedServer 创建一个新的单端口服务器实例，监听 httpOptions.Addr.
+// gRPC 请求经由 grpc.Server.ServeHTTP 处理，TLS 由 HTTP 服务器统一终止.
+func NewCombinedServer(
+	httpOptions *genericoptions.HTTPOptions,
+	grpcOptions *genericoptions.GRPCOptions,
+	tlsOptions *genericoptions.TLSOptions,
+	serverOptions []grpc.ServerOption,
+	registerServer func(grpc.ServiceRegistrar),
+	handler http.Handler,
+) *CombinedServer {
+	grpcsrv := newGRPCServer(grpcOptions, serverOptions, registerServer)
+
+	var tlsConfig *tls.Config
+	if tlsOptions != nil && tlsOptions.UseTLS {
+		tlsConfig = tlsOptions.MustTLSConfig()
+	}
+
+	protocols := new(http.Protocols)
+	protocols.SetHTTP1(true)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+
+	return &CombinedServer{
+		srv: &http.Server{
+			Addr:      httpOptions.Addr,
+			Handler:   combinedHandler(grpcsrv.srv, handler, grpcOptions.EnableGRPCWeb),
+			TLSConfig: tlsConfig,
+			Protocols: protocols,
+		},
+		grpc: grpcsrv,
+	}
+}
+
+// combinedHandler 根据协议将请求分发给 gRPC 服务器或 HTTP 处理器.
+func combinedHandler(grpcsrv *grpc.Server, handler http.Handler, enableGRPCWeb bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		// gRPC-Web 的 Content-Type 同样以 application/grpc 开头，需要优先判断
+		case enableGRPCWeb && isGRPCWebRequest(r):
+			serveGRPCWeb(grpcsrv, w, r)
+		case enableGRPCWeb && isGRPCWebPreflight(r):
+			writeGRPCWebPreflight(w, r)
+		case r.ProtoMajor == 2 && strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc"):
+			grpcsrv.ServeHTTP(w, r)
+		default:
+			handler.ServeHTTP(w, r)
+		}
+	})
+}
+
+// StartHealthChecks 启动 grpc.health.v1 依赖检查，参见 GRPCServer.StartHealthChecks.
+func (s *CombinedServer) StartHealthChecks(services []string, interval time.Duration, checks ...HealthCheck) {
+	s.grpc.StartHealthChecks(services, interval, checks...)
+}
+
+// RunOrDie 启动单端口服务器并在出错时记录致命错误.
+func (s *CombinedServer) RunOrDie() {
+	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv)+"+grpc", "addr", s.srv.Addr)
+	serveFn := func() error { return s.srv.ListenAndServe() }
+	if s.srv.TLSConfig != nil {
+		serveFn = func() error { return s.srv.ListenAndServeTLS("", "") }
+	}
+
+	if err := serveFn(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalw("Failed to serve combined server", "err", err)
+	}
+}
+
+// GracefulStop 优雅地关闭单端口服务器. 先将健康状态置为 NOT_SERVING，再等待进行中的请求完成.
+func (s *CombinedServer) GracefulStop(ctx context.Context) {
+	log.Infow("Gracefully stop combined server")
+	if s.grpc.stopHealthChecks != nil {
+		s.grpc.stopHealthChecks()
+	}
+	s.grpc.health.Shutdown()
+	if err := s.srv.Shutdown(ctx); err != nil {
+		log.Errorw("Combined server forced to shutdown", "err", err)
+	}
+	// 流式调用可能在超时后仍未结束，强制关闭剩余的 gRPC 流
+	s.grpc.srv.Stop()
+}
@@ -0,0 +1,89 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+
+	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+func TestCombinedServer(t *testing.T) {
+	rest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("rest"))
+	})
+	srv := NewCombinedServer(
+		&genericoptions.HTTPOptions{},
+		&genericoptions.GRPCOptions{EnableGRPCWeb: true},
+		nil,
+		nil,
+		func(grpc.ServiceRegistrar) {},
+		rest,
+	)
+	ts := httptest.NewUnstartedServer(srv.srv.Handler)
+	ts.Config.Protocols = srv.srv.Protocols
+	ts.Start()
+	defer ts.Close()
+
+	// HTTP/1.1 请求交给 REST 处理器
+	resp, err := http.Get(ts.URL + "/healthz")
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.Equal(t, "rest", string(body))
+
+	// 明文 HTTP/2 的 gRPC 请求交给 gRPC 服务器
+	conn, err := grpc.NewClient(ts.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	health, err := grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.GetStatus())
+
+	// gRPC-Web 的二进制与文本格式
+	for _, contentType := range []string{"application/grpc-web+proto", "application/grpc-web-text"} {
+		data, err := proto.Marshal(&grpc_health_v1.HealthCheckRequest{Service: "unknown"})
+		require.NoError(t, err)
+		frame := append([]byte{0, 0, 0, 0, 0}, data...)
+		binary.BigEndian.PutUint32(frame[1:5], uint32(len(data)))
+		reqBody := frame
+		if contentType == "application/grpc-web-text" {
+			reqBody = []byte(base64.StdEncoding.EncodeToString(frame))
+		}
+
+		req, _ := http.NewRequest(http.MethodPost, ts.URL+"/grpc.health.v1.Health/Check", bytes.NewReader(reqBody))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("X-Grpc-Web", "1")
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		respBody, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.Equal(t, contentType, resp.Header.Get("Content-Type"))
+		if contentType == "application/grpc-web-text" {
+			respBody, err = base64.StdEncoding.DecodeString(string(respBody))
+			require.NoError(t, err)
+		}
+
+		// 未注册的服务返回 NOT_FOUND，只有 trailer 帧
+		require.GreaterOrEqual(t, len(respBody), 5)
+		assert.Equal(t, byte(grpcWebTrailerFlag), respBody[0])
+		assert.Contains(t, string(respBody[5:]), "grpc-status: 5\r\n")
+	}
+}
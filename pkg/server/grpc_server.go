@@ -21,8 +21,11 @@ import (
 
 // GRPCServer 代表一个 GRPC 服务器.
 type GRPCServer struct {
-	srv *grpc.Server
-	lis net.Listener
+	srv    *grpc.Server
+	lis    net.Listener
+	health *health.Server
+	// stopHealthChecks 停止依赖检查，未启动时为 nil
+	stopHealthChecks context.CancelFunc
 }
 
 // NewGRPCServer 创建一个新的 GRPC 服务器实例.
@@ -43,16 +46,33 @@ func NewGRPCServer(
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
 	}
 
+	s := newGRPCServer(grpcOptions, serverOptions, registerServer)
+	s.lis = lis
+	return s, nil
+}
+
+// newGRPCServer 创建 gRPC 服务器并注册业务服务、健康检查服务和可选的反射服务，不监听端口.
+func newGRPCServer(
+	grpcOptions *genericoptions.GRPCOptions,
+	serverOptions []grpc.ServerOption,
+	registerServer func(grpc.ServiceRegistrar),
+) *GRPCServer {
 	grpcsrv := grpc.NewServer(serverOptions...)
 
 	registerServer(grpcsrv)
-	registerHealthServer(grpcsrv)
-	reflection.Register(grpcsrv)
+
+	// 注册 grpc.health.v1 健康检查服务，供 grpc_health_probe、Kubernetes gRPC 探针与 Envoy 使用
+	healthServer := newHealthServer()
+	grpc_health_v1.RegisterHealthServer(grpcsrv, healthServer)
+
+	if grpcOptions.EnableReflection {
+		reflection.Register(grpcsrv)
+	}
 
 	return &GRPCServer{
-		srv: grpcsrv,
-		lis: lis,
-	}, nil
+		srv:    grpcsrv,
+		health: healthServer,
+	}
 }
 
 // RunOrDie 启动 GRPC 服务器并在出错时记录致命错误.
@@ -63,20 +83,12 @@ func (s *GRPCServer) RunOrDie() {
 	}
 }
 
-// GracefulStop 优雅地关闭 GRPC 服务器.
+// GracefulStop 优雅地关闭 GRPC 服务器. 关闭前先将健康状态置为 NOT_SERVING，使负载均衡器停止转发新请求.
 func (s *GRPCServer) GracefulStop(ctx context.Context) {
 	log.Infow("Gracefully stop grpc server")
+	if s.stopHealthChecks != nil {
+		s.stopHealthChecks()
+	}
+	s.health.Shutdown()
 	s.srv.GracefulStop()
 }
-
-// registerHealthServer 注册健康检查服务.
-func registerHealthServer(grpcsrv *grpc.Server) {
-	// 创建健康检查服务实例
-	healthServer := health.NewServer()
-
-	// 设定服务的健康状态
-	healthServer.SetServingStatus("MiniBlog", grpc_health_v1.HealthCheckResponse_SERVING)
-
-	// 注册健康检查服务
-	grpc_health_v1.RegisterHealthServer(grpcsrv, healthServer)
-}
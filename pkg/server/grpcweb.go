@@ -0,0 +1,168 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// grpcWebContentType 为 gRPC-Web 二进制格式的 Content-Type 前缀.
+	grpcWebContentType = "application/grpc-web"
+	// grpcWebTextContentType 为 gRPC-Web base64 文本格式的 Content-Type 前缀.
+	grpcWebTextContentType = "application/grpc-web-text"
+	// grpcWebTrailerFlag 为 gRPC-Web 响应中 trailer 帧的标志位.
+	grpcWebTrailerFlag = 0x80
+)
+
+// isGRPCWebRequest 判断是否为 gRPC-Web 请求.
+func isGRPCWebRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost && strings.HasPrefix(r.Header.Get("Content-Type"), grpcWebContentType)
+}
+
+// isGRPCWebPreflight 判断是否为浏览器发起的 gRPC-Web 跨域预检请求.
+func isGRPCWebPreflight(r *http.Request) bool {
+	return r.Method == http.MethodOptions &&
+		r.Header.Get("Access-Control-Request-Method") != "" &&
+		strings.Contains(strings.ToLower(r.Header.Get("Access-Control-Request-Headers")), "x-grpc-web")
+}
+
+// writeGRPCWebPreflight 响应 gRPC-Web 跨域预检请求.
+func writeGRPCWebPreflight(w http.ResponseWriter, r *http.Request) {
+	h := w.Header()
+	h.Set("Access-Control-Allow-Origin", "*")
+	h.Set("Access-Control-Allow-Methods", "POST, OPTIONS")
+	h.Set("Access-Control-Allow-Headers", r.Header.Get("Access-Control-Request-Headers"))
+	h.Set("Access-Control-Max-Age", "600")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// serveGRPCWeb 将 gRPC-Web 请求转换为 gRPC 请求交给 grpcHandler 处理，并把 trailer 编码为响应体中的 trailer 帧.
+// 两种协议的消息帧格式相同，区别仅在于 gRPC-Web 不使用 HTTP trailer，且文本格式需要 base64 编解码.
+func serveGRPCWeb(grpcHandler http.Handler, w http.ResponseWriter, r *http.Request) {
+	contentType := r.Header.Get("Content-Type")
+	text := strings.HasPrefix(contentType, grpcWebTextContentType)
+
+	req := r.Clone(r.Context())
+	req.ProtoMajor, req.ProtoMinor, req.Proto = 2, 0, "HTTP/2.0"
+	req.Header.Set("Content-Type", grpcContentType(contentType))
+	req.Header.Del("Content-Length")
+	req.ContentLength = -1
+	if text {
+		req.Body = io.NopCloser(base64.NewDecoder(base64.StdEncoding, r.Body))
+	}
+
+	rw := newGRPCWebResponseWriter(w, contentType, text)
+	grpcHandler.ServeHTTP(rw, req)
+	rw.finish()
+}
+
+// grpcContentType 将 gRPC-Web 的 Content-Type 转换为对应的 gRPC Content-Type，保留 +proto 等子类型.
+func grpcContentType(contentType string) string {
+	for _, prefix := range []string{grpcWebTextContentType, grpcWebContentType} {
+		if strings.HasPrefix(contentType, prefix) {
+			return "application/grpc" + strings.TrimPrefix(contentType, prefix)
+		}
+	}
+	return contentType
+}
+
+// grpcWebResponseWriter 将 gRPC 响应转换为 gRPC-Web 响应.
+type grpcWebResponseWriter struct {
+	w           http.ResponseWriter
+	header      http.Header
+	contentType string
+	// body 为实际写入响应体的 Writer，文本格式时为 base64 编码器
+	body        io.Writer
+	encoder     io.WriteCloser
+	wroteHeader bool
+}
+
+// newGRPCWebResponseWriter 创建 grpcWebResponseWriter.
+func newGRPCWebResponseWriter(w http.ResponseWriter, contentType string, text bool) *grpcWebResponseWriter {
+	rw := &grpcWebResponseWriter{w: w, header: make(http.Header), contentType: contentType, body: w}
+	if text {
+		rw.encoder = base64.NewEncoder(base64.StdEncoding, w)
+		rw.body = rw.encoder
+	}
+	return rw
+}
+
+// Header 实现 http.ResponseWriter 接口.
+func (rw *grpcWebResponseWriter) Header() http.Header {
+	return rw.header
+}
+
+// WriteHeader 实现 http.ResponseWriter 接口. trailer 声明不会作为响应头发送.
+func (rw *grpcWebResponseWriter) WriteHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+
+	h := rw.w.Header()
+	for key, values := range rw.header {
+		if key == "Trailer" || strings.HasPrefix(key, http.TrailerPrefix) {
+			continue
+		}
+		h[key] = values
+	}
+	h.Set("Content-Type", rw.contentType)
+	h.Set("Access-Control-Allow-Origin", "*")
+	h.Set("Access-Control-Expose-Headers", "grpc-status, grpc-message, grpc-status-details-bin")
+	rw.w.WriteHeader(code)
+}
+
+// Write 实现 http.ResponseWriter 接口.
+func (rw *grpcWebResponseWriter) Write(b []byte) (int, error) {
+	rw.WriteHeader(http.StatusOK)
+	return rw.body.Write(b)
+}
+
+// Flush 实现 http.Flusher 接口，grpc.Server.ServeHTTP 要求 ResponseWriter 支持 Flush.
+func (rw *grpcWebResponseWriter) Flush() {
+	rw.WriteHeader(http.StatusOK)
+	if flusher, ok := rw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// finish 将 gRPC 的 trailer 写为 gRPC-Web 的 trailer 帧.
+func (rw *grpcWebResponseWriter) finish() {
+	rw.WriteHeader(http.StatusOK)
+
+	var trailer bytes.Buffer
+	writeField := func(key string, values []string) {
+		for _, value := range values {
+			trailer.WriteString(strings.ToLower(key) + ": " + value + "\r\n")
+		}
+	}
+	for _, declared := range rw.header.Values("Trailer") {
+		for _, key := range strings.Split(declared, ",") {
+			key = strings.TrimSpace(key)
+			writeField(key, rw.header.Values(key))
+		}
+	}
+	for key, values := range rw.header {
+		if strings.HasPrefix(key, http.TrailerPrefix) {
+			writeField(strings.TrimPrefix(key, http.TrailerPrefix), values)
+		}
+	}
+
+	frame := make([]byte, 5, 5+trailer.Len())
+	frame[0] = grpcWebTrailerFlag
+	binary.BigEndian.PutUint32(frame[1:], uint32(trailer.Len()))
+	_, _ = rw.body.Write(append(frame, trailer.Bytes()...))
+	if rw.encoder != nil {
+		_ = rw.encoder.Close()
+	}
+	rw.Flush()
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/clin211/miniblog-v2/internal/pkg/log"
+)
+
+// HealthCheck 定义一个依赖的健康检查.
+type HealthCheck struct {
+	// Name 为依赖名称，同时作为 grpc.health.v1 中的服务名，可以单独探测该依赖
+	Name string
+	// Check 返回 nil 表示依赖健康
+	Check func(ctx context.Context) error
+}
+
+// StartHealthChecks 立即执行一次依赖检查，之后每隔 interval 执行一次，并据此更新 grpc.health.v1 的服务状态：
+// 每个依赖的状态单独设置；services 与整体状态（空服务名）只有在全部依赖健康时才为 SERVING.
+// GracefulStop 时检查停止，所有状态变为 NOT_SERVING.
+func (s *GRPCServer) StartHealthChecks(services []string, interval time.Duration, checks ...HealthCheck) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopHealthChecks = cancel
+
+	s.checkHealth(ctx, services, interval, checks)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.checkHealth(ctx, services, interval, checks)
+			}
+		}
+	}()
+}
+
+// checkHealth 执行一轮依赖检查，单个依赖的超时不超过检查间隔.
+func (s *GRPCServer) checkHealth(ctx context.Context, services []string, interval time.Duration, checks []HealthCheck) {
+	overall := grpc_health_v1.HealthCheckResponse_SERVING
+	for _, check := range checks {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := check.Check(checkCtx)
+		cancel()
+
+		status := grpc_health_v1.HealthCheckResponse_SERVING
+		if err != nil {
+			log.Warnw("Dependency health check failed", "dependency", check.Name, "err", err)
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			overall = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		s.health.SetServingStatus(check.Name, status)
+	}
+
+	s.health.SetServingStatus("", overall)
+	for _, service := range services {
+		s.health.SetServingStatus(service, overall)
+	}
+}
+
+// newHealthServer 创建健康检查服务，未执行依赖检查前整体状态为 SERVING.
+func newHealthServer() *health.Server {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	return healthServer
+}
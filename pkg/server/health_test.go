@@ -0,0 +1,62 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	genericoptions "github.com/clin211/miniblog-v2/pkg/options"
+)
+
+func TestGRPCServerHealthChecks(t *testing.T) {
+	srv, err := NewGRPCServer(&genericoptions.GRPCOptions{Addr: "127.0.0.1:0"}, nil, nil, func(grpc.ServiceRegistrar) {})
+	require.NoError(t, err)
+
+	status := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		resp, err := srv.health.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return resp.GetStatus()
+	}
+
+	var redisErr error
+	srv.StartHealthChecks([]string{"v1.MiniBlog", "MiniBlog"}, time.Hour,
+		HealthCheck{Name: "mysql", Check: func(context.Context) error { return nil }},
+		HealthCheck{Name: "redis", Check: func(context.Context) error { return redisErr }},
+	)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("v1.MiniBlog"))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("MiniBlog"))
+
+	// 任一依赖不健康时整体与服务状态为 NOT_SERVING，其余依赖不受影响
+	redisErr = errors.New("connection refused")
+	srv.checkHealth(context.Background(), []string{"v1.MiniBlog"}, time.Second, []HealthCheck{
+		{Name: "mysql", Check: func(context.Context) error { return nil }},
+		{Name: "redis", Check: func(context.Context) error { return redisErr }},
+	})
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status("v1.MiniBlog"))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status("redis"))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("mysql"))
+
+	redisErr = nil
+	srv.checkHealth(context.Background(), []string{"v1.MiniBlog"}, time.Second, []HealthCheck{
+		{Name: "redis", Check: func(context.Context) error { return redisErr }},
+	})
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(""))
+
+	// 优雅关停时所有服务变为 NOT_SERVING
+	srv.GracefulStop(context.Background())
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(""))
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status("mysql"))
+}
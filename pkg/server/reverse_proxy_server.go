@@ -34,6 +34,7 @@ func NewGRPCGatewayServer(
 	grpcOptions *genericoptions.GRPCOptions,
 	tlsOptions *genericoptions.TLSOptions,
 	registerHandler func(mux *runtime.ServeMux, conn *grpc.ClientConn) error,
+	muxOptions ...runtime.ServeMuxOption,
 ) (*GRPCGatewayServer, error) {
 	var tlsConfig *tls.Config
 	if tlsOptions != nil && tlsOptions.UseTLS {
@@ -60,13 +61,10 @@ func NewGRPCGatewayServer(
 		return nil, err
 	}
 
-	gwmux := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
-		MarshalOptions: protojson.MarshalOptions{
-			// 设置序列化 protobuf 数据时，枚举类型的字段以数字格式输出.
-			// 否则，默认会以字符串格式输出，跟枚举类型定义不一致，带来理解成本.
-			UseEnumNumbers: true,
-		},
-	}))
+	muxOptions = append([]runtime.ServeMuxOption{
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, newGatewayMarshaler(httpOptions)),
+	}, muxOptions...)
+	gwmux := runtime.NewServeMux(muxOptions...)
 	if err := registerHandler(gwmux, conn); err != nil {
 		log.Errorw("Failed to register handler", "err", err)
 		return nil, err
@@ -81,6 +79,22 @@ func NewGRPCGatewayServer(
 	}, nil
 }
 
+// newGatewayMarshaler 根据 HTTPOptions 创建网关使用的 JSON 序列化器.
+func newGatewayMarshaler(httpOptions *genericoptions.HTTPOptions) *runtime.JSONPb {
+	return &runtime.JSONPb{
+		MarshalOptions: protojson.MarshalOptions{
+			// 默认枚举类型的字段以数字格式输出，与 Gin 模式保持一致.
+			UseEnumNumbers:  !httpOptions.EnumAsString,
+			UseProtoNames:   httpOptions.UseProtoNames,
+			EmitUnpopulated: httpOptions.EmitUnpopulated,
+		},
+		UnmarshalOptions: protojson.UnmarshalOptions{
+			// 与 Gin 的 JSON 绑定一致，忽略请求中的未知字段
+			DiscardUnknown: true,
+		},
+	}
+}
+
 // RunOrDie 启动 GRPC 网关服务器并在出错时记录致命错误.
 func (s *GRPCGatewayServer) RunOrDie() {
 	log.Infow("Start to listening the incoming requests", "protocol", protocolName(s.srv), "addr", s.srv.Addr)
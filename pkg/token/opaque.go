@@ -0,0 +1,20 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package token
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+)
+
+// NewOpaque 生成 256 位随机的不透明令牌，如刷新令牌、邮件验证令牌. 服务端应只保存其摘要.
+func NewOpaque() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
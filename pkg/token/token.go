@@ -9,13 +9,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	jwt "github.com/golang-jwt/jwt/v4"
+	"github.com/google/uuid"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/auth"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -29,6 +32,9 @@ type Config struct {
 	expiration time.Duration
 }
 
+// ErrMissingAuthorization 表示请求未携带 Authorization 请求头或 authorization 元数据.
+var ErrMissingAuthorization = errors.New("the length of the `Authorization` header is zero")
+
 var (
 	config = Config{"Rtg8BPKNEf2mB4mgvKONGPZZQSaJWNLijxR42qRgq0iBb5", "identityKey", 2 * time.Hour}
 	once   sync.Once // 确保配置只被初始化一次
@@ -49,8 +55,29 @@ func Init(key string, identityKey string, expiration time.Duration) {
 	})
 }
 
+// Claims 为从 token 中解析出的声明.
+type Claims struct {
+	// Identity 为用户身份
+	Identity string
+	// ID 为 token 的唯一标识（jti），早期签发的 token 中可能为空
+	ID string
+	// IssuedAt 为 token 签发时间，精确到毫秒
+	IssuedAt time.Time
+	// ExpiresAt 为 token 过期时间
+	ExpiresAt time.Time
+}
+
 // Parse 使用指定的密钥 key 解析 token，解析成功返回 token 上下文，否则报错.
 func Parse(tokenString string, key string) (string, error) {
+	claims, err := ParseClaims(tokenString, key)
+	if err != nil {
+		return "", err
+	}
+	return claims.Identity, nil
+}
+
+// ParseClaims 使用指定的密钥 key 解析 token，解析成功返回 token 中的声明.
+func ParseClaims(tokenString string, key string) (*Claims, error) {
 	// 解析 token
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		// 确保 token 加密算法是预期的加密算法
@@ -62,27 +89,42 @@ func Parse(tokenString string, key string) (string, error) {
 	})
 	// 解析失败
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	var identityKey string
-	// 如果解析成功，从 token 中取出 token 的主题
-	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		if key, exists := claims[config.identityKey]; exists {
-			if identity, valid := key.(string); valid {
-				identityKey = identity // 获取身份键
-			}
-		}
+	mapClaims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, jwt.ErrSignatureInvalid
 	}
-	if identityKey == "" {
-		return "", jwt.ErrSignatureInvalid
+
+	// 从 token 中取出用户身份
+	identity, _ := mapClaims[config.identityKey].(string)
+	if identity == "" {
+		return nil, jwt.ErrSignatureInvalid
 	}
 
-	return identityKey, nil
+	claims := &Claims{Identity: identity}
+	claims.ID, _ = mapClaims["jti"].(string)
+	if iat, ok := mapClaims["iat"].(float64); ok {
+		claims.IssuedAt = time.UnixMilli(int64(math.Round(iat * 1e3)))
+	}
+	if exp, ok := mapClaims["exp"].(float64); ok {
+		claims.ExpiresAt = time.Unix(int64(exp), 0)
+	}
+	return claims, nil
 }
 
 // ParseRequest 从请求头中获取令牌，并将其传递给 Parse 函数以解析令牌.
 func ParseRequest(ctx context.Context) (string, error) {
+	claims, err := ParseRequestClaims(ctx)
+	if err != nil {
+		return "", err
+	}
+	return claims.Identity, nil
+}
+
+// ParseRequestClaims 从请求头中获取令牌并解析，返回令牌中的声明.
+func ParseRequestClaims(ctx context.Context) (*Claims, error) {
 	var (
 		token string
 		err   error
@@ -93,34 +135,39 @@ func ParseRequest(ctx context.Context) (string, error) {
 	case *gin.Context:
 		header := typed.Request.Header.Get("Authorization")
 		if len(header) == 0 {
-			//nolint: err113
-			return "", errors.New("the length of the `Authorization` header is zero") // 返回错误
+			return nil, ErrMissingAuthorization // 返回错误
 		}
 
 		// 从请求头中取出 token
 		_, _ = fmt.Sscanf(header, "Bearer %s", &token) // 解析 Bearer token
 	// 使用 google.golang.org/grpc 框架开发的 gRPC 服务
 	default:
+		// 与 Gin 保持一致，未携带令牌时返回相同的错误
+		if values := metadata.ValueFromIncomingContext(typed, "authorization"); len(values) == 0 || values[0] == "" {
+			return nil, ErrMissingAuthorization
+		}
 		token, err = auth.AuthFromMD(typed, "Bearer")
 		if err != nil {
-			return "", status.Errorf(codes.Unauthenticated, "invalid auth token")
+			return nil, status.Errorf(codes.Unauthenticated, "invalid auth token")
 		}
 	}
 
-	return Parse(token, config.key) // 解析 token
+	return ParseClaims(token, config.key) // 解析 token
 }
 
 // Sign 使用 jwtSecret 签发 token，token 的 claims 中会存放传入的 subject.
 func Sign(identityKey string) (string, time.Time, error) {
 	// 计算过期时间
-	expireAt := time.Now().Add(config.expiration)
+	now := time.Now()
+	expireAt := now.Add(config.expiration)
 
 	// Token 的内容
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		config.identityKey: identityKey,       // 存放用户身份
-		"nbf":              time.Now().Unix(), // token 生效时间
-		"iat":              time.Now().Unix(), // token 签发时间
-		"exp":              expireAt.Unix(),   // token 过期时间
+		config.identityKey: identityKey,                    // 存放用户身份
+		"jti":              uuid.New().String(),            // token 唯一标识，用于吊销单个 token
+		"nbf":              now.Unix(),                     // token 生效时间
+		"iat":              float64(now.UnixMilli()) / 1e3, // token 签发时间，精确到毫秒以便按时间吊销
+		"exp":              expireAt.Unix(),                // token 过期时间
 	})
 
 	// 签发 token
@@ -0,0 +1,43 @@
+// Copyright 2025 长林啊 <767425412@qq.com>. All rights reserved.
+// Use of this source code is governed by a MIT style
+// license that can be found in the LICENSE file. The original repo for
+// this file is https://github.com/clin211/miniblog-v2.git.
+
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignAndParseClaims(t *testing.T) {
+	before := time.Now().Truncate(time.Millisecond)
+	first, expireAt, err := Sign("user-1")
+	require.NoError(t, err)
+	second, _, err := Sign("user-1")
+	require.NoError(t, err)
+
+	claims, err := ParseClaims(first, config.key)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", claims.Identity)
+	assert.NotEmpty(t, claims.ID)
+	assert.Equal(t, expireAt.Unix(), claims.ExpiresAt.Unix())
+	// 签发时间精确到毫秒
+	assert.False(t, claims.IssuedAt.Before(before))
+	assert.WithinDuration(t, time.Now(), claims.IssuedAt, time.Second)
+
+	// 每个 token 的 jti 都不相同
+	other, err := ParseClaims(second, config.key)
+	require.NoError(t, err)
+	assert.NotEqual(t, claims.ID, other.ID)
+
+	identity, err := Parse(first, config.key)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", identity)
+
+	_, err = ParseClaims(first, "wrong-key")
+	assert.Error(t, err)
+}